@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+// scaffold holds the values substituted into the project templates.
+type scaffold struct {
+	ID         string
+	Name       string
+	Module     string
+	SDKModule  string
+	SDKVersion string
+	GoVersion  string
+}
+
+const sdkModule = "github.com/kiket-dev/kiket/sdk/go"
+
+var nonIDChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+func runInit(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("init", "init [flags] <directory>", stderr)
+	id := fs.String("id", "", "extension ID (default com.example.<directory name>)")
+	module := fs.String("module", "", "Go module path (default the directory name)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	dir := fs.Arg(0)
+	name := strings.Trim(nonIDChars.ReplaceAllString(strings.ToLower(filepath.Base(dir)), "-"), "-")
+	if name == "" {
+		return fmt.Errorf("cannot derive an extension name from %q", dir)
+	}
+	s := scaffold{
+		ID:         *id,
+		Name:       name,
+		Module:     *module,
+		SDKModule:  sdkModule,
+		SDKVersion: kiket.SDKVersion,
+		GoVersion:  "1.21",
+	}
+	if s.ID == "" {
+		s.ID = "com.example." + name
+	}
+	if s.Module == "" {
+		s.Module = name
+	}
+
+	files, err := renderScaffold(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists", path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		if err := os.WriteFile(path, file.content, 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, "created", path)
+	}
+
+	fmt.Fprintf(stdout, "\nNext steps:\n  cd %s\n  go mod tidy\n  KIKET_WEBHOOK_SECRET=dev KIKET_EXTENSION_API_KEY=... go run .\n  kiket-ext send -secret dev issue.created\n", dir)
+	return nil
+}
+
+type scaffoldFile struct {
+	name    string
+	content []byte
+}
+
+// renderScaffold renders the project templates in the order they are
+// written.
+func renderScaffold(s scaffold) ([]scaffoldFile, error) {
+	names := []string{"extension.yaml", "main.go", "Dockerfile", "go.mod"}
+	files := make([]scaffoldFile, 0, len(names))
+	for _, name := range names {
+		var buf bytes.Buffer
+		if err := scaffoldTemplates.ExecuteTemplate(&buf, name, s); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", name, err)
+		}
+		files = append(files, scaffoldFile{name: name, content: buf.Bytes()})
+	}
+	return files, nil
+}
+
+var scaffoldTemplates = template.Must(template.New("").Parse(`{{define "extension.yaml"}}id: {{.ID}}
+version: 0.1.0
+name: {{.Name}}
+sdk: go
+sdk_version: {{.SDKVersion}}
+
+settings:
+  - key: greeting
+    type: string
+    default: Hello
+    description: Prefix for log messages
+
+events:
+  - issue.created
+
+scopes:
+  - issues:read
+
+endpoints:
+  - path: /webhook
+{{end}}{{define "main.go"}}package main
+
+import (
+	"context"
+	"embed"
+	"log"
+	"net/http"
+	"os"
+
+	"{{.SDKModule}}/kiket"
+)
+
+//go:embed extension.yaml
+var manifestFS embed.FS
+
+func main() {
+	// Credentials come from KIKET_WEBHOOK_SECRET and KIKET_EXTENSION_API_KEY.
+	config, err := kiket.ApplyEnv(kiket.Config{
+		ManifestFS:   manifestFS,
+		ManifestPath: "extension.yaml",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sdk, err := kiket.New(config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer sdk.Close()
+
+	sdk.On("issue.created", handleIssueCreated)
+
+	if err := sdk.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "9292"
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/webhook", sdk)
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	log.Printf("Listening on :%s", port)
+	log.Fatal(http.ListenAndServe(":"+port, mux))
+}
+
+func handleIssueCreated(ctx context.Context, payload kiket.WebhookPayload, hctx *kiket.HandlerContext) (interface{}, error) {
+	issue, err := hctx.Payload.Issue()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("%s: issue %v created: %s", hctx.Settings.GetString("greeting", "Hello"), issue.ID, issue.Title)
+	return map[string]string{"status": "ok"}, nil
+}
+{{end}}{{define "Dockerfile"}}# syntax=docker/dockerfile:1
+FROM golang:1.23-alpine AS builder
+
+WORKDIR /app
+
+COPY go.mod go.sum* ./
+RUN go mod download
+
+COPY . .
+RUN CGO_ENABLED=0 GOOS=linux go build -ldflags="-s -w" -o extension .
+
+FROM alpine:3.20
+
+WORKDIR /app
+
+RUN apk add --no-cache curl ca-certificates
+
+COPY --from=builder /app/extension .
+
+RUN adduser -D -s /bin/sh kiket && \
+    chown -R kiket:kiket /app
+
+USER kiket
+
+ENV PORT=9292
+EXPOSE 9292
+
+HEALTHCHECK --interval=30s --timeout=5s --start-period=5s --retries=3 \
+  CMD curl -f http://localhost:${PORT}/health || exit 1
+
+CMD ["./extension"]
+{{end}}{{define "go.mod"}}module {{.Module}}
+
+go {{.GoVersion}}
+
+require {{.SDKModule}} v{{.SDKVersion}}
+{{end}}`))
@@ -0,0 +1,101 @@
+// Command kiket-ext is the development tool for Kiket extensions written in
+// Go. It scaffolds new projects, validates manifests, generates typed
+// settings bindings, sends signed test webhooks to a local server, tunnels
+// real workspace events to it, and checks an instance's API for drift:
+//
+//	kiket-ext init my-extension
+//	kiket-ext validate -manifest extension.yaml
+//	kiket-ext settings -manifest extension.yaml -out settings_gen.go
+//	kiket-ext send -url http://localhost:9292/webhook -secret dev issue.created
+//	kiket-ext tunnel -forward http://localhost:9292/webhook
+//	kiket-ext speccheck -base-url https://kiket.internal
+//
+// Install it with:
+//
+//	go install github.com/kiket-dev/kiket/sdk/go/cmd/kiket-ext@latest
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `Usage: kiket-ext <command> [flags] [args]
+
+Commands:
+  init      scaffold a new extension project
+  validate  check a manifest for errors
+  settings  generate typed settings bindings from a manifest
+  send      send a signed test webhook to a local server
+  tunnel    relay real workspace events to a local server
+  speccheck check an instance's OpenAPI document for API drift
+
+Run "kiket-ext <command> -h" for the flags of a command.
+`
+
+// errUsage reports invalid arguments after the usage has been printed.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, "kiket-ext:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return errUsage
+	}
+
+	commands := map[string]func(args []string, stdout, stderr io.Writer) error{
+		"init":      runInit,
+		"validate":  runValidate,
+		"settings":  runSettings,
+		"send":      runSend,
+		"tunnel":    runTunnel,
+		"speccheck": runSpeccheck,
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+			fmt.Fprint(stdout, usage)
+			return nil
+		}
+		fmt.Fprintf(stderr, "kiket-ext: unknown command %q\n\n%s", args[0], usage)
+		return errUsage
+	}
+	return command(args[1:], stdout, stderr)
+}
+
+// newFlagSet creates the flag set of a command, printing errors and usage
+// to stderr.
+func newFlagSet(name, synopsis string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: kiket-ext %s\n\nFlags:\n", synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args, which the flag set reports errors for itself.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
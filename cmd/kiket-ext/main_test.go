@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func TestInitScaffold(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Triage Bot")
+	var stdout bytes.Buffer
+	if err := run([]string{"init", "-module", "example.com/triage", dir}, &stdout, io.Discard); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	manifest, err := kiket.LoadManifest(filepath.Join(dir, "extension.yaml"), kiket.WithStrictManifest())
+	if err != nil {
+		t.Fatalf("Scaffolded manifest does not load: %v", err)
+	}
+	if manifest.ID != "com.example.triage-bot" {
+		t.Errorf("Expected ID com.example.triage-bot, got %s", manifest.ID)
+	}
+	goMod, _ := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if !strings.HasPrefix(string(goMod), "module example.com/triage\n") {
+		t.Errorf("Unexpected go.mod %q", goMod)
+	}
+	for _, name := range []string{"main.go", "Dockerfile"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected %s: %v", name, err)
+		}
+	}
+
+	if err := run([]string{"init", dir}, io.Discard, io.Discard); err == nil {
+		t.Error("Expected init to refuse overwriting files")
+	}
+	if err := run([]string{"validate", "-manifest", filepath.Join(dir, "extension.yaml")}, io.Discard, io.Discard); err != nil {
+		t.Errorf("Expected scaffolded manifest to validate, got %v", err)
+	}
+}
+
+func TestGenerateSettings(t *testing.T) {
+	manifest, err := kiket.ParseManifest([]byte(`
+id: com.example.ext
+version: 1.0.0
+settings:
+  - key: slack_api_url
+    description: Incoming webhook URL
+  - key: batch_size
+    default: 50
+  - key: poll-interval
+    type: duration
+  - key: labels
+    type: list
+`))
+	if err != nil {
+		t.Fatalf("ParseManifest failed: %v", err)
+	}
+
+	code, err := generateSettings(manifest, "extension.yaml", "main", "Settings")
+	if err != nil {
+		t.Fatalf("generateSettings failed: %v", err)
+	}
+	for _, want := range []string{
+		"\t// Incoming webhook URL\n\tSlackAPIURL  string",
+		"BatchSize    int",
+		"PollInterval time.Duration",
+		`Labels:       s.GetStringSlice("labels", nil),`,
+		`BatchSize:    s.GetInt("batch_size", 0),`,
+	} {
+		if !strings.Contains(string(code), want) {
+			t.Errorf("Expected generated code to contain %q:\n%s", want, code)
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	sdk, err := kiket.New(kiket.Config{ExtensionAPIKey: "key", WebhookSecret: "dev"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	var title interface{}
+	sdk.On("issue.created", func(ctx context.Context, payload kiket.WebhookPayload, hctx *kiket.HandlerContext) (interface{}, error) {
+		title = payload["issue"].(map[string]interface{})["title"]
+		return map[string]string{"status": "ok"}, nil
+	})
+	server := httptest.NewServer(sdk)
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	if err := run([]string{"send", "-url", server.URL, "-secret", "dev", "issue.created"}, &stdout, io.Discard); err != nil {
+		t.Fatalf("send failed: %v", err)
+	}
+	if title == nil || !strings.Contains(stdout.String(), "200 OK") {
+		t.Errorf("Expected delivered sample payload, got %q", stdout.String())
+	}
+
+	if err := run([]string{"send", "-url", server.URL, "-secret", "wrong", "issue.created"}, io.Discard, io.Discard); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected 401 for a wrong secret, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+	"github.com/kiket-dev/kiket/sdk/go/kiket/kikettest"
+)
+
+func runSend(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("send", "send [flags] <event>", stderr)
+	target := fs.String("url", "http://localhost:9292/webhook", "webhook URL of the local server")
+	secret := fs.String("secret", os.Getenv(kiket.EnvWebhookSecret), "webhook secret (default $"+kiket.EnvWebhookSecret+")")
+	version := fs.String("version", "v1", "event version")
+	payloadPath := fs.String("payload", "", "JSON payload file (default a sample payload for the event)")
+	workspace := fs.String("workspace", "", "workspace ID sent as "+kiket.WorkspaceIDHeader)
+	timeout := fs.Duration("timeout", 30*time.Second, "request timeout")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	event := fs.Arg(0)
+
+	body, err := webhookBody(event, *version, *payloadPath)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, *target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	signature, timestamp := kiket.GenerateSignature(*secret, string(body), nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Kiket-Signature", signature)
+	req.Header.Set("X-Kiket-Timestamp", timestamp)
+	req.Header.Set("X-Kiket-Event-Version", *version)
+	req.Header.Set("X-Kiket-Delivery-Id", fmt.Sprintf("dev-%d", time.Now().UnixNano()))
+	if *workspace != "" {
+		req.Header.Set(kiket.WorkspaceIDHeader, *workspace)
+	}
+
+	client := &http.Client{Timeout: *timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	fmt.Fprintf(stdout, "%s %s -> %s\n", event, *version, resp.Status)
+	if len(respBody) > 0 {
+		fmt.Fprintln(stdout, strings.TrimSpace(string(respBody)))
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("server responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// webhookBody reads the payload file, or the SDK's sample payload for the
+// event, and sets its "event" field.
+func webhookBody(event, version, payloadPath string) ([]byte, error) {
+	var payload map[string]interface{}
+	if payloadPath != "" {
+		content, err := os.ReadFile(payloadPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(content, &payload); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", payloadPath, err)
+		}
+	} else {
+		sample, err := kikettest.LoadPayload(event, version)
+		if err != nil {
+			return nil, fmt.Errorf("%w; pass -payload for events without a sample (samples exist for %s)", err, strings.Join(kikettest.PayloadEvents(), ", "))
+		}
+		payload = sample
+	}
+	if payload == nil {
+		payload = map[string]interface{}{}
+	}
+	payload["event"] = event
+	return json.Marshal(payload)
+}
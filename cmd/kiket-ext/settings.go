@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+// settingTypes maps manifest setting types to a Go type and the
+// kiket.Settings accessor that reads it.
+var settingTypes = map[string]struct{ goType, getter, zero string }{
+	"string":   {"string", "GetString", `""`},
+	"integer":  {"int", "GetInt", "0"},
+	"number":   {"float64", "GetFloat", "0"},
+	"boolean":  {"bool", "GetBool", "false"},
+	"duration": {"time.Duration", "GetDuration", "0"},
+	"list":     {"[]string", "GetStringSlice", "nil"},
+}
+
+var initialisms = map[string]string{
+	"id": "ID", "ids": "IDs", "url": "URL", "api": "API", "uri": "URI", "http": "HTTP",
+}
+
+func runSettings(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("settings", "settings [flags]", stderr)
+	manifestPath := fs.String("manifest", "extension.yaml", "manifest declaring the settings")
+	out := fs.String("out", "settings_gen.go", `Go file to write ("-" for stdout)`)
+	pkg := fs.String("package", "main", "package name of the generated file")
+	typeName := fs.String("type", "Settings", "name of the generated struct")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	manifest, err := loadManifest(*manifestPath, false)
+	if err != nil {
+		return err
+	}
+	code, err := generateSettings(manifest, *manifestPath, *pkg, *typeName)
+	if err != nil {
+		return err
+	}
+
+	if *out == "-" {
+		_, err := stdout.Write(code)
+		return err
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, "wrote", *out)
+	return nil
+}
+
+// generateSettings renders a struct with one typed field per manifest
+// setting and a loader reading them from kiket.Settings.
+func generateSettings(manifest *kiket.Manifest, source, pkg, typeName string) ([]byte, error) {
+	type field struct {
+		key, name, doc string
+		goType, getter string
+		zero           string
+	}
+
+	var fields []field
+	usesTime := false
+	seen := make(map[string]string)
+	for _, setting := range manifest.Settings {
+		t, ok := settingTypes[settingType(setting)]
+		if !ok {
+			return nil, fmt.Errorf("setting %q: unsupported type %q", setting.Key, setting.Type)
+		}
+		name := goName(setting.Key)
+		if name == "" {
+			return nil, fmt.Errorf("setting %q: cannot derive a Go field name", setting.Key)
+		}
+		if other, ok := seen[name]; ok {
+			return nil, fmt.Errorf("settings %q and %q both map to field %s", other, setting.Key, name)
+		}
+		seen[name] = setting.Key
+		usesTime = usesTime || t.goType == "time.Duration"
+		fields = append(fields, field{
+			key: setting.Key, name: name, doc: setting.Description,
+			goType: t.goType, getter: t.getter, zero: t.zero,
+		})
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by kiket-ext settings from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	buf.WriteString("import (\n")
+	if usesTime {
+		buf.WriteString("\t\"time\"\n\n")
+	}
+	fmt.Fprintf(&buf, "\t%q\n)\n\n", sdkModule+"/kiket")
+
+	fmt.Fprintf(&buf, "// %s holds the settings declared in %s.\n", typeName, source)
+	fmt.Fprintf(&buf, "type %s struct {\n", typeName)
+	for _, f := range fields {
+		if f.doc != "" {
+			fmt.Fprintf(&buf, "\t// %s\n", strings.ReplaceAll(f.doc, "\n", " "))
+		}
+		fmt.Fprintf(&buf, "\t%s %s `json:%q`\n", f.name, f.goType, f.key)
+	}
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(&buf, "// Load%s reads the typed settings, such as hctx.Settings or\n", typeName)
+	fmt.Fprintf(&buf, "// sdk.Settings(). Manifest defaults are already applied by the SDK.\n")
+	fmt.Fprintf(&buf, "func Load%s(s kiket.Settings) %s {\n", typeName, typeName)
+	fmt.Fprintf(&buf, "\treturn %s{\n", typeName)
+	for _, f := range fields {
+		fmt.Fprintf(&buf, "\t\t%s: s.%s(%q, %s),\n", f.name, f.getter, f.key, f.zero)
+	}
+	buf.WriteString("\t}\n}\n")
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return code, nil
+}
+
+// settingType returns a setting's declared type, inferring it from the
+// default when unset.
+func settingType(setting kiket.ManifestSetting) string {
+	if setting.Type != "" {
+		return setting.Type
+	}
+	switch v := setting.Default.(type) {
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "list"
+	}
+	return "string"
+}
+
+// goName converts a setting key such as "slack_api_url" to "SlackAPIURL".
+func goName(key string) string {
+	parts := strings.FieldsFunc(key, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, part := range parts {
+		if upper, ok := initialisms[strings.ToLower(part)]; ok {
+			b.WriteString(upper)
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	name := b.String()
+	if name != "" && unicode.IsDigit(rune(name[0])) {
+		name = "Setting" + name
+	}
+	return name
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+	"github.com/kiket-dev/kiket/sdk/go/kiket/speccheck"
+)
+
+func runSpeccheck(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("speccheck", "speccheck [flags]", stderr)
+	baseURL := fs.String("base-url", envOr(kiket.EnvBaseURL, "https://kiket.dev"), "Kiket instance whose OpenAPI document is checked")
+	spec := fs.String("spec", "", "OpenAPI document file or URL to check instead (default <base-url>"+speccheck.DefaultSpecPath+")")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var doc *speccheck.Document
+	var err error
+	source := *spec
+	switch {
+	case source == "":
+		source = strings.TrimSuffix(*baseURL, "/") + speccheck.DefaultSpecPath
+		doc, err = speccheck.Fetch(ctx, *baseURL, nil)
+	case strings.HasPrefix(source, "http://"), strings.HasPrefix(source, "https://"):
+		doc, err = speccheck.Fetch(ctx, source, nil)
+	default:
+		doc, err = speccheck.Load(source)
+	}
+	if err != nil {
+		return err
+	}
+
+	report := speccheck.Check(doc, speccheck.Endpoints)
+	for _, finding := range report.Findings {
+		fmt.Fprintf(stdout, "%s: %s\n", source, finding)
+	}
+	if !report.OK() {
+		return fmt.Errorf("%s: %d difference(s) from the SDK (SDK %s)", source, len(report.Findings), kiket.SDKVersion)
+	}
+
+	fmt.Fprintf(stdout, "%s: ok (%d endpoints checked)\n", source, report.Checked)
+	return nil
+}
+
+func envOr(name, def string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return def
+}
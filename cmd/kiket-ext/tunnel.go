@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func runTunnel(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("tunnel", "tunnel [flags]", stderr)
+	manifestPath := fs.String("manifest", "extension.yaml", "manifest of the extension")
+	forward := fs.String("forward", "http://localhost:9292/webhook", `local webhook URL deliveries are forwarded to ("" to only print them)`)
+	events := fs.String("events", "", "comma-separated events to receive (default the manifest's events)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	// Credentials come from KIKET_EXTENSION_API_KEY and friends.
+	config, err := kiket.ApplyEnv(kiket.Config{ManifestPath: *manifestPath})
+	if err != nil {
+		return err
+	}
+	config.Logger = log.New(stderr, "", log.LstdFlags)
+	sdk, err := kiket.New(config)
+	if err != nil {
+		return err
+	}
+	defer sdk.Close()
+
+	opts := kiket.TunnelOptions{ForwardURL: *forward, Output: stdout}
+	if *events != "" {
+		for _, event := range strings.Split(*events, ",") {
+			if event = strings.TrimSpace(event); event != "" {
+				opts.Events = append(opts.Events, event)
+			}
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return sdk.Tunnel(ctx, opts)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func runValidate(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("validate", "validate [flags]", stderr)
+	manifestPath := fs.String("manifest", "extension.yaml", "manifest to validate")
+	strict := fs.Bool("strict", false, "reject unknown manifest fields")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	manifest, err := loadManifest(*manifestPath, *strict)
+	if err != nil {
+		return err
+	}
+
+	issues := kiket.ValidateManifest(manifest)
+
+	// Check that defaults satisfy their own schema; settings without a
+	// default are configured in Kiket and are not checked here.
+	withDefaults := &kiket.Manifest{}
+	for _, setting := range manifest.Settings {
+		if setting.Default != nil {
+			withDefaults.Settings = append(withDefaults.Settings, setting)
+		}
+	}
+	issues = append(issues, kiket.ValidateSettings(withDefaults, kiket.SettingsDefaults(withDefaults))...)
+
+	errorCount := 0
+	for _, issue := range issues {
+		fmt.Fprintf(stdout, "%s: %s\n", *manifestPath, issue.Error())
+		if issue.Severity == kiket.SeverityError {
+			errorCount++
+		}
+	}
+	if errorCount > 0 {
+		return fmt.Errorf("%s has %d error(s)", *manifestPath, errorCount)
+	}
+
+	fmt.Fprintf(stdout, "%s: ok (%d warning(s))\n", *manifestPath, len(issues))
+	return nil
+}
+
+func loadManifest(path string, strict bool) (*kiket.Manifest, error) {
+	var opts []kiket.ManifestOption
+	if strict {
+		opts = append(opts, kiket.WithStrictManifest())
+	}
+	return kiket.LoadManifest(path, opts...)
+}
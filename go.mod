@@ -1,7 +1,9 @@
 module github.com/kiket-dev/kiket/sdk/go
 
-go 1.21
+go 1.22
 
 require (
+	github.com/klauspost/compress v1.18.0
+	google.golang.org/protobuf v1.36.0
 	gopkg.in/yaml.v3 v3.0.1
 )
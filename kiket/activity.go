@@ -0,0 +1,79 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// activityClient implements the ActivityClient interface.
+type activityClient struct {
+	client Client
+}
+
+// NewActivityClient creates a new activity client.
+func NewActivityClient(client Client) ActivityClient {
+	return &activityClient{client: client}
+}
+
+func (c *activityClient) buildParams(opts *ActivityListOptions) map[string]string {
+	params := map[string]string{}
+	if opts == nil {
+		return params
+	}
+
+	if !opts.Since.IsZero() {
+		params["since"] = opts.Since.UTC().Format(time.RFC3339)
+	}
+	if !opts.Until.IsZero() {
+		params["until"] = opts.Until.UTC().Format(time.RFC3339)
+	}
+	if len(opts.Actions) > 0 {
+		params["actions"] = strings.Join(opts.Actions, ",")
+	}
+	if opts.ActorID != nil {
+		params["actor_id"] = fmt.Sprintf("%v", opts.ActorID)
+	}
+	if opts.Limit > 0 {
+		params["limit"] = strconv.Itoa(opts.Limit)
+	}
+	if opts.Cursor != "" {
+		params["cursor"] = opts.Cursor
+	}
+
+	return params
+}
+
+func (c *activityClient) list(ctx context.Context, path string, opts *ActivityListOptions) (*ActivityListResponse, error) {
+	resp, err := c.client.Get(ctx, path, &RequestOptions{
+		Params: c.buildParams(opts),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result ActivityListResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
+
+func (c *activityClient) ForIssue(ctx context.Context, issueID interface{}, opts *ActivityListOptions) (*ActivityListResponse, error) {
+	if issueID == nil || issueID == "" {
+		return nil, errors.New("issue ID is required")
+	}
+	return c.list(ctx, joinPath(issuesPath, issueID, "activity"), opts)
+}
+
+func (c *activityClient) ForProject(ctx context.Context, projectID interface{}, opts *ActivityListOptions) (*ActivityListResponse, error) {
+	if projectID == nil || projectID == "" {
+		return nil, errors.New("projectID is required for project activity")
+	}
+	return c.list(ctx, joinPath(projectsPath, projectID, "activity"), opts)
+}
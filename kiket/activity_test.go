@@ -0,0 +1,57 @@
+package kiket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestActivityClient_BuildsRequests(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		w.Write([]byte(`{"data": [{"id": 1, "action": "issue.updated", "actor_name": "Ada", "subject_type": "issue", "subject_id": 42, "changes": [{"field": "state", "from": "open", "to": "done"}], "occurred_at": "2024-03-01T09:00:00Z"}], "next_cursor": "c2"}`))
+	}))
+	defer server.Close()
+
+	activity := NewActivityClient(NewHTTPClient(WithBaseURL(server.URL)))
+	ctx := context.Background()
+
+	page, err := activity.ForIssue(ctx, 42, &ActivityListOptions{
+		Since:   time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Actions: []string{"issue.updated", "comment.created"},
+		ActorID: 9,
+		Limit:   20,
+		Cursor:  "c1",
+	})
+	if err != nil {
+		t.Fatalf("ForIssue failed: %v", err)
+	}
+	if page.NextCursor != "c2" || len(page.Data) != 1 {
+		t.Fatalf("Unexpected page %+v", page)
+	}
+	entry := page.Data[0]
+	if entry.Action != "issue.updated" || entry.ActorName != "Ada" || len(entry.Changes) != 1 || entry.Changes[0].To != "done" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+
+	if _, err := activity.ForProject(ctx, "ops", nil); err != nil {
+		t.Fatalf("ForProject failed: %v", err)
+	}
+
+	expected := []string{
+		"/api/v1/ext/issues/42/activity?actions=issue.updated%2Ccomment.created&actor_id=9&cursor=c1&limit=20&since=2024-03-01T00%3A00%3A00Z",
+		"/api/v1/ext/projects/ops/activity",
+	}
+	for i, want := range expected {
+		if requests[i] != want {
+			t.Errorf("Expected request %q, got %q", want, requests[i])
+		}
+	}
+
+	if _, err := activity.ForProject(ctx, nil, nil); err == nil {
+		t.Error("Expected error when projectID is missing")
+	}
+}
@@ -0,0 +1,122 @@
+package kiket
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const requestIDHeader = "X-Request-Id"
+
+// apiErrorFields are the fields of the Kiket error envelope.
+type apiErrorFields struct {
+	Code      string                 `json:"code"`
+	Message   string                 `json:"message"`
+	Details   map[string]interface{} `json:"details"`
+	RequestID string                 `json:"request_id"`
+}
+
+// newAPIError builds the error for a failed response, parsing the error
+// envelope, {"error": {"code", "message", "details", "request_id"}}, when the
+// body has one. A plain {"error": "message"} body and top-level fields are
+// accepted too; the request ID falls back to the X-Request-Id header.
+func newAPIError(status int, body []byte, header http.Header) *APIError {
+	apiErr := &APIError{StatusCode: status, Body: string(body)}
+
+	var envelope struct {
+		apiErrorFields
+		Error json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(body, &envelope) == nil {
+		fields := envelope.apiErrorFields
+		var nested apiErrorFields
+		var message string
+		switch {
+		case json.Unmarshal(envelope.Error, &nested) == nil:
+			fields = mergeAPIErrorFields(nested, fields)
+		case json.Unmarshal(envelope.Error, &message) == nil && fields.Message == "":
+			fields.Message = message
+		}
+		apiErr.Code = fields.Code
+		apiErr.Message = fields.Message
+		apiErr.Details = fields.Details
+		apiErr.RequestID = fields.RequestID
+	}
+	if apiErr.RequestID == "" && header != nil {
+		apiErr.RequestID = strings.TrimSpace(header.Get(requestIDHeader))
+	}
+	return apiErr
+}
+
+// mergeAPIErrorFields fills the fields unset in primary from fallback.
+func mergeAPIErrorFields(primary, fallback apiErrorFields) apiErrorFields {
+	if primary.Code == "" {
+		primary.Code = fallback.Code
+	}
+	if primary.Message == "" {
+		primary.Message = fallback.Message
+	}
+	if primary.Details == nil {
+		primary.Details = fallback.Details
+	}
+	if primary.RequestID == "" {
+		primary.RequestID = fallback.RequestID
+	}
+	return primary
+}
+
+// apiErrorStatus returns the status of the *APIError in err's chain, or 0.
+func apiErrorStatus(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is a 404 API error.
+func IsNotFound(err error) bool {
+	return apiErrorStatus(err) == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err is a 401 API error: the credential is
+// missing, invalid, or expired.
+func IsUnauthorized(err error) bool {
+	return apiErrorStatus(err) == http.StatusUnauthorized
+}
+
+// IsForbidden reports whether err is a 403 API error or a
+// *MissingScopeError caught by scope preflight.
+func IsForbidden(err error) bool {
+	var scopeErr *MissingScopeError
+	return apiErrorStatus(err) == http.StatusForbidden || errors.As(err, &scopeErr)
+}
+
+// IsConflict reports whether err is a 409 API error.
+func IsConflict(err error) bool {
+	return apiErrorStatus(err) == http.StatusConflict
+}
+
+// IsRateLimited reports whether err is a 429 API error (see RateLimitError).
+func IsRateLimited(err error) bool {
+	return apiErrorStatus(err) == http.StatusTooManyRequests
+}
+
+// IsValidation reports whether err is an API error rejecting the request's
+// content, a 400 or 422. Details usually holds the per-field errors.
+func IsValidation(err error) bool {
+	status := apiErrorStatus(err)
+	return status == http.StatusBadRequest || status == http.StatusUnprocessableEntity
+}
+
+// isRetryableAPIError reports whether a failed call is transient: a
+// transport error, a 5xx, or a 429. Rejections such as 400 or 401 are not
+// worth retrying.
+func isRetryableAPIError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500 || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError_ParsesEnvelope(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected APIError
+		check    func(error) bool
+	}{
+		{
+			name:     "envelope",
+			status:   http.StatusUnprocessableEntity,
+			body:     `{"error":{"code":"validation_failed","message":"title is required","details":{"field":"title"},"request_id":"req_1"}}`,
+			expected: APIError{Code: "validation_failed", Message: "title is required", RequestID: "req_1"},
+			check:    IsValidation,
+		},
+		{
+			name:     "plain message",
+			status:   http.StatusNotFound,
+			body:     `{"error":"issue not found"}`,
+			expected: APIError{Message: "issue not found", RequestID: "hdr_1"},
+			check:    IsNotFound,
+		},
+		{
+			name:     "top-level fields",
+			status:   http.StatusConflict,
+			body:     `{"code":"conflict","message":"label exists"}`,
+			expected: APIError{Code: "conflict", Message: "label exists", RequestID: "hdr_1"},
+			check:    IsConflict,
+		},
+		{
+			name:     "not JSON",
+			status:   http.StatusForbidden,
+			body:     `forbidden`,
+			expected: APIError{RequestID: "hdr_1"},
+			check:    IsForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-Id", "hdr_1")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := NewHTTPClient(WithBaseURL(server.URL)).Get(context.Background(), "/api/v1/ext/issues/1", nil)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected *APIError, got %v", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Body != tt.body {
+				t.Errorf("Expected status %d and the raw body, got %d %q", tt.status, apiErr.StatusCode, apiErr.Body)
+			}
+			if apiErr.Code != tt.expected.Code || apiErr.Message != tt.expected.Message || apiErr.RequestID != tt.expected.RequestID {
+				t.Errorf("Expected %+v, got %+v", tt.expected, apiErr)
+			}
+			if !tt.check(err) || IsRateLimited(err) {
+				t.Errorf("Unexpected classification of %v", err)
+			}
+		})
+	}
+}
+
+func TestAPIError_Message(t *testing.T) {
+	err := newAPIError(http.StatusUnprocessableEntity, []byte(`{"error":{"code":"validation_failed","message":"title is required","details":{"field":"title"},"request_id":"req_1"}}`), nil)
+	if err.Error() != "API error (status 422, validation_failed): title is required (request req_1)" {
+		t.Errorf("Unexpected message %q", err.Error())
+	}
+	if err.Details["field"] != "title" {
+		t.Errorf("Expected details, got %v", err.Details)
+	}
+	if !IsRateLimited(newRateLimitError(http.Header{}, nil)) {
+		t.Error("Expected a *RateLimitError to be rate limited")
+	}
+}
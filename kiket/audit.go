@@ -2,11 +2,12 @@ package kiket
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"net/url"
 	"sort"
 	"strconv"
 	"time"
@@ -14,11 +15,11 @@ import (
 
 // AuditClient handles blockchain audit verification operations.
 type AuditClient struct {
-	client *Client
+	client Client
 }
 
 // NewAuditClient creates a new audit client.
-func NewAuditClient(client *Client) *AuditClient {
+func NewAuditClient(client Client) *AuditClient {
 	return &AuditClient{client: client}
 }
 
@@ -66,6 +67,22 @@ type BlockchainProof struct {
 	VerificationURL *string  `json:"verification_url"`
 }
 
+// ConsistencyProof proves that the audit log committed to by a later
+// anchor's root extends the log committed to by an earlier one, i.e. that
+// the records up to the earlier anchor were not rewritten. Sizes are the
+// log's cumulative leaf counts at each anchor.
+type ConsistencyProof struct {
+	FromRoot string   `json:"from_root"`
+	FromSize int64    `json:"from_size"`
+	ToRoot   string   `json:"to_root"`
+	ToSize   int64    `json:"to_size"`
+	Proof    []string `json:"proof"`
+}
+
+// ErrAnchorsInconsistent is returned by VerifyConsistency when the later
+// anchor does not extend the earlier one.
+var ErrAnchorsInconsistent = errors.New("audit anchors are inconsistent")
+
 // VerificationResult is the result of a blockchain verification.
 type VerificationResult struct {
 	Verified           bool    `json:"verified"`
@@ -85,10 +102,14 @@ type VerificationResult struct {
 type ListAnchorsOptions struct {
 	Status  string
 	Network string
-	From    *time.Time
-	To      *time.Time
-	Page    int
-	PerPage int
+	// Only anchors containing records of this type, e.g. "AuditLog"
+	RecordType string
+	// Only the anchor containing the record with this content hash
+	ContentHash string
+	From        *time.Time
+	To          *time.Time
+	Page        int
+	PerPage     int
 }
 
 // ListAnchorsResult is the result of listing blockchain anchors.
@@ -106,32 +127,34 @@ type PaginationInfo struct {
 }
 
 // ListAnchors lists blockchain anchors for the organization.
-func (c *AuditClient) ListAnchors(opts ListAnchorsOptions) (*ListAnchorsResult, error) {
-	params := url.Values{}
+func (c *AuditClient) ListAnchors(ctx context.Context, opts ListAnchorsOptions) (*ListAnchorsResult, error) {
+	params := map[string]string{"page": "1", "per_page": "25"}
 	if opts.Page > 0 {
-		params.Set("page", strconv.Itoa(opts.Page))
-	} else {
-		params.Set("page", "1")
+		params["page"] = strconv.Itoa(opts.Page)
 	}
 	if opts.PerPage > 0 {
-		params.Set("per_page", strconv.Itoa(opts.PerPage))
-	} else {
-		params.Set("per_page", "25")
+		params["per_page"] = strconv.Itoa(opts.PerPage)
 	}
 	if opts.Status != "" {
-		params.Set("status", opts.Status)
+		params["status"] = opts.Status
 	}
 	if opts.Network != "" {
-		params.Set("network", opts.Network)
+		params["network"] = opts.Network
+	}
+	if opts.RecordType != "" {
+		params["record_type"] = opts.RecordType
+	}
+	if opts.ContentHash != "" {
+		params["content_hash"] = opts.ContentHash
 	}
 	if opts.From != nil {
-		params.Set("from", opts.From.Format(time.RFC3339))
+		params["from"] = opts.From.Format(time.RFC3339)
 	}
 	if opts.To != nil {
-		params.Set("to", opts.To.Format(time.RFC3339))
+		params["to"] = opts.To.Format(time.RFC3339)
 	}
 
-	resp, err := c.client.Get("/api/v1/audit/anchors?" + params.Encode())
+	resp, err := c.client.Get(ctx, "/api/v1/audit/anchors", &RequestOptions{Params: params})
 	if err != nil {
 		return nil, err
 	}
@@ -144,14 +167,38 @@ func (c *AuditClient) ListAnchors(opts ListAnchorsOptions) (*ListAnchorsResult,
 	return &result, nil
 }
 
+// Anchors iterates over every anchor matching opts, starting at opts.Page.
+// Pass WithPrefetch to fetch the next page while the current one is being
+// processed.
+func (c *AuditClient) Anchors(ctx context.Context, opts ListAnchorsOptions, iterOpts ...IteratorOption) *Iterator[BlockchainAnchor] {
+	fetch := func(ctx context.Context, token string) ([]BlockchainAnchor, string, error) {
+		pageOpts := opts
+		if token != "" {
+			pageOpts.Page = pageNumber(token)
+		}
+		result, err := c.ListAnchors(ctx, pageOpts)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var next string
+		if result.Pagination.Page < result.Pagination.TotalPages {
+			next = strconv.Itoa(result.Pagination.Page + 1)
+		}
+		return result.Anchors, next, nil
+	}
+	return NewIterator(ctx, fetch, iterOpts...)
+}
+
 // GetAnchor gets details of a specific anchor by merkle root.
-func (c *AuditClient) GetAnchor(merkleRoot string, includeRecords bool) (*BlockchainAnchor, error) {
-	path := "/api/v1/audit/anchors/" + merkleRoot
+func (c *AuditClient) GetAnchor(ctx context.Context, merkleRoot string, includeRecords bool) (*BlockchainAnchor, error) {
+	path := joinPath(apiPrefix+"/audit/anchors", merkleRoot)
+	var opts *RequestOptions
 	if includeRecords {
-		path += "?include_records=true"
+		opts = &RequestOptions{Params: map[string]string{"include_records": "true"}}
 	}
 
-	resp, err := c.client.Get(path)
+	resp, err := c.client.Get(ctx, path, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -165,19 +212,20 @@ func (c *AuditClient) GetAnchor(merkleRoot string, includeRecords bool) (*Blockc
 }
 
 // GetProof gets the blockchain proof for a specific audit record (defaults to AuditLog type).
-func (c *AuditClient) GetProof(recordID int64) (*BlockchainProof, error) {
-	return c.GetProofWithType(recordID, "AuditLog")
+func (c *AuditClient) GetProof(ctx context.Context, recordID int64) (*BlockchainProof, error) {
+	return c.GetProofWithType(ctx, recordID, "AuditLog")
 }
 
 // GetProofWithType gets the blockchain proof for a specific audit record of the given type.
 // recordType should be "AuditLog" or "AIAuditLog".
-func (c *AuditClient) GetProofWithType(recordID int64, recordType string) (*BlockchainProof, error) {
-	path := fmt.Sprintf("/api/v1/audit/records/%d/proof", recordID)
+func (c *AuditClient) GetProofWithType(ctx context.Context, recordID int64, recordType string) (*BlockchainProof, error) {
+	path := joinPath(apiPrefix+"/audit/records", recordID, "proof")
+	var opts *RequestOptions
 	if recordType != "AuditLog" {
-		path += "?record_type=" + recordType
+		opts = &RequestOptions{Params: map[string]string{"record_type": recordType}}
 	}
 
-	resp, err := c.client.Get(path)
+	resp, err := c.client.Get(ctx, path, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -191,7 +239,7 @@ func (c *AuditClient) GetProofWithType(recordID int64, recordType string) (*Bloc
 }
 
 // Verify verifies a blockchain proof via the API.
-func (c *AuditClient) Verify(proof *BlockchainProof) (*VerificationResult, error) {
+func (c *AuditClient) Verify(ctx context.Context, proof *BlockchainProof) (*VerificationResult, error) {
 	payload := map[string]interface{}{
 		"content_hash": proof.ContentHash,
 		"merkle_root":  proof.MerkleRoot,
@@ -200,12 +248,7 @@ func (c *AuditClient) Verify(proof *BlockchainProof) (*VerificationResult, error
 		"tx_hash":      proof.TxHash,
 	}
 
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal payload: %w", err)
-	}
-
-	resp, err := c.client.Post("/api/v1/audit/verify", body)
+	resp, err := c.client.Post(ctx, "/api/v1/audit/verify", payload, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -218,6 +261,41 @@ func (c *AuditClient) Verify(proof *BlockchainProof) (*VerificationResult, error
 	return &result, nil
 }
 
+// GetConsistencyProof gets the proof that the log anchored at toRoot extends
+// the log anchored at fromRoot.
+func (c *AuditClient) GetConsistencyProof(ctx context.Context, fromRoot, toRoot string) (*ConsistencyProof, error) {
+	path := joinPath(apiPrefix+"/audit/anchors", toRoot, "consistency")
+	resp, err := c.client.Get(ctx, path, &RequestOptions{Params: map[string]string{"from": fromRoot}})
+	if err != nil {
+		return nil, err
+	}
+
+	var proof ConsistencyProof
+	if err := json.Unmarshal(resp, &proof); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &proof, nil
+}
+
+// VerifyConsistency gets the consistency proof between two anchors and
+// checks it locally, so the result does not rely on the API. It returns an
+// error wrapping ErrAnchorsInconsistent when the proof does not hold.
+func (c *AuditClient) VerifyConsistency(ctx context.Context, fromRoot, toRoot string) (*ConsistencyProof, error) {
+	proof, err := c.GetConsistencyProof(ctx, fromRoot, toRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(normalizeHash(proof.FromRoot), normalizeHash(fromRoot)) || !bytes.Equal(normalizeHash(proof.ToRoot), normalizeHash(toRoot)) {
+		return proof, fmt.Errorf("%w: proof is for %s..%s, not the requested anchors", ErrAnchorsInconsistent, proof.FromRoot, proof.ToRoot)
+	}
+	if !VerifyConsistencyLocally(proof.FromSize, proof.ToSize, fromRoot, toRoot, proof.Proof) {
+		return proof, fmt.Errorf("%w: %s (%d leaves) is not a prefix of %s (%d leaves)", ErrAnchorsInconsistent, fromRoot, proof.FromSize, toRoot, proof.ToSize)
+	}
+	return proof, nil
+}
+
 // ComputeContentHash computes the content hash for a record (for local verification).
 func ComputeContentHash(data map[string]interface{}) string {
 	// Sort keys for canonical JSON
@@ -256,6 +334,58 @@ func VerifyProofLocally(contentHash string, proofPath []string, leafIndex int, m
 	return bytes.Equal(current, expected)
 }
 
+// VerifyConsistencyLocally verifies a Merkle consistency proof between the
+// log of fromSize leaves with root fromRoot and the log of toSize leaves
+// with root toRoot, without making an API call. The log is hashed as in
+// RFC 9162, with the pair hash VerifyProofLocally uses.
+func VerifyConsistencyLocally(fromSize, toSize int64, fromRoot, toRoot string, proofPath []string) bool {
+	first, second := normalizeHash(fromRoot), normalizeHash(toRoot)
+	switch {
+	case fromSize <= 0 || fromSize > toSize:
+		return false
+	case fromSize == toSize:
+		return len(proofPath) == 0 && bytes.Equal(first, second)
+	case len(proofPath) == 0:
+		return false
+	}
+
+	path := make([][]byte, 0, len(proofPath)+1)
+	if fromSize&(fromSize-1) == 0 {
+		// The old root is a node of the new tree, so it starts the path
+		path = append(path, first)
+	}
+	for _, node := range proofPath {
+		path = append(path, normalizeHash(node))
+	}
+
+	fn, sn := fromSize-1, toSize-1
+	for fn&1 == 1 {
+		fn >>= 1
+		sn >>= 1
+	}
+
+	fr, sr := path[0], path[0]
+	for _, node := range path[1:] {
+		if sn == 0 {
+			return false
+		}
+		if fn&1 == 1 || fn == sn {
+			fr = hashPair(node, fr)
+			sr = hashPair(node, sr)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			sr = hashPair(sr, node)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+
+	return sn == 0 && bytes.Equal(fr, first) && bytes.Equal(sr, second)
+}
+
 func normalizeHash(h string) []byte {
 	if len(h) >= 2 && h[:2] == "0x" {
 		h = h[2:]
@@ -0,0 +1,198 @@
+package kiket
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuditClient_ListAnchorsFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/api/v1/audit/anchors" || query.Get("record_type") != "AuditLog" || query.Get("content_hash") != "abc123" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"anchors":[{"id":3,"merkle_root":"root"}],"pagination":{"page":1,"per_page":25,"total":1,"total_pages":1}}`))
+	}))
+	defer server.Close()
+
+	audit := NewAuditClient(NewHTTPClient(WithBaseURL(server.URL)))
+	result, err := audit.ListAnchors(context.Background(), ListAnchorsOptions{RecordType: "AuditLog", ContentHash: "abc123"})
+	if err != nil {
+		t.Fatalf("ListAnchors failed: %v", err)
+	}
+	if len(result.Anchors) != 1 || result.Anchors[0].MerkleRoot != "root" {
+		t.Errorf("Expected the matching anchor, got %+v", result.Anchors)
+	}
+}
+
+// testTreeHash is the RFC 9162 tree hash of leaves under hashPair.
+func testTreeHash(leaves [][]byte) []byte {
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	k := testSplit(len(leaves))
+	return hashPair(testTreeHash(leaves[:k]), testTreeHash(leaves[k:]))
+}
+
+// testSplit returns the largest power of two smaller than n.
+func testSplit(n int) int {
+	k := 1
+	for k*2 < n {
+		k *= 2
+	}
+	return k
+}
+
+// testConsistencyProof is RFC 9162's SUBPROOF(m, leaves, complete).
+func testConsistencyProof(m int, leaves [][]byte, complete bool) [][]byte {
+	if m == len(leaves) {
+		if complete {
+			return nil
+		}
+		return [][]byte{testTreeHash(leaves)}
+	}
+	k := testSplit(len(leaves))
+	if m <= k {
+		return append(testConsistencyProof(m, leaves[:k], complete), testTreeHash(leaves[k:]))
+	}
+	return append(testConsistencyProof(m-k, leaves[k:], false), testTreeHash(leaves[:k]))
+}
+
+func testHex(hashes ...[]byte) []string {
+	out := make([]string, len(hashes))
+	for i, h := range hashes {
+		out[i] = "0x" + hex.EncodeToString(h)
+	}
+	return out
+}
+
+func TestVerifyConsistencyLocally(t *testing.T) {
+	var leaves [][]byte
+	for i := 0; i < 13; i++ {
+		leaves = append(leaves, normalizeHash(ComputeContentHash(map[string]interface{}{"id": i})))
+	}
+
+	for n := 1; n <= len(leaves); n++ {
+		toRoot := testHex(testTreeHash(leaves[:n]))[0]
+		for m := 1; m <= n; m++ {
+			fromRoot := testHex(testTreeHash(leaves[:m]))[0]
+			proof := testHex(testConsistencyProof(m, leaves[:n], true)...)
+			if !VerifyConsistencyLocally(int64(m), int64(n), fromRoot, toRoot, proof) {
+				t.Errorf("Expected consistency %d -> %d to verify", m, n)
+			}
+			if m == n {
+				continue
+			}
+
+			rewritten := append([][]byte(nil), leaves[:n]...)
+			rewritten[m-1] = normalizeHash(ComputeContentHash(map[string]interface{}{"id": "forged"}))
+			if VerifyConsistencyLocally(int64(m), int64(n), fromRoot, testHex(testTreeHash(rewritten))[0], proof) {
+				t.Errorf("Expected a rewritten log %d -> %d to fail", m, n)
+			}
+			tampered := append([]string(nil), proof...)
+			tampered[len(tampered)-1] = testHex(leaves[0])[0]
+			if VerifyConsistencyLocally(int64(m), int64(n), fromRoot, toRoot, tampered) {
+				t.Errorf("Expected a tampered proof %d -> %d to fail", m, n)
+			}
+		}
+	}
+}
+
+func TestAuditClient_VerifyConsistency(t *testing.T) {
+	var leaves [][]byte
+	for i := 0; i < 6; i++ {
+		leaves = append(leaves, normalizeHash(ComputeContentHash(map[string]interface{}{"id": i})))
+	}
+	fromRoot, toRoot := testHex(testTreeHash(leaves[:3]))[0], testHex(testTreeHash(leaves))[0]
+	proof, _ := json.Marshal(testHex(testConsistencyProof(3, leaves, true)...))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/audit/anchors/"+toRoot+"/consistency" || r.URL.Query().Get("from") != fromRoot {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		fmt.Fprintf(w, `{"from_root":%q,"from_size":3,"to_root":%q,"to_size":6,"proof":%s}`, fromRoot, toRoot, proof)
+	}))
+	defer server.Close()
+
+	audit := NewAuditClient(NewHTTPClient(WithBaseURL(server.URL)))
+	if _, err := audit.VerifyConsistency(context.Background(), fromRoot, toRoot); err != nil {
+		t.Errorf("Expected consistent anchors, got %v", err)
+	}
+}
+
+func TestAuditClient_VerifyConsistencyRejectsOtherRoots(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"from_root":"0x01","from_size":1,"to_root":"0x01","to_size":1,"proof":[]}`))
+	}))
+	defer server.Close()
+
+	audit := NewAuditClient(NewHTTPClient(WithBaseURL(server.URL)))
+	_, err := audit.VerifyConsistency(context.Background(), "0x02", "0x02")
+	if !errors.Is(err, ErrAnchorsInconsistent) {
+		t.Errorf("Expected ErrAnchorsInconsistent, got %v", err)
+	}
+}
+
+func TestHashRecords_StreamsIntoMerkleBuilder(t *testing.T) {
+	records := []map[string]interface{}{}
+	var leaves [][]byte
+	var ndjson strings.Builder
+	for i := 0; i < 11; i++ {
+		record := map[string]interface{}{"id": float64(i), "action": "update"}
+		records = append(records, record)
+		leaves = append(leaves, normalizeHash(ComputeContentHash(record)))
+		data, _ := json.Marshal(record)
+		ndjson.Write(data)
+		ndjson.WriteByte('\n')
+	}
+	array, _ := json.Marshal(records)
+	expected := testHex(testTreeHash(leaves))[0]
+
+	channel := make(chan map[string]interface{})
+	go func() {
+		for _, record := range records {
+			channel <- record
+		}
+		close(channel)
+	}()
+
+	for name, source := range map[string]RecordIterator{
+		"ndjson":  NewJSONRecordReader(strings.NewReader(ndjson.String())),
+		"array":   NewJSONRecordReader(strings.NewReader("  " + string(array))),
+		"channel": ChannelRecords(channel),
+	} {
+		builder := NewMerkleBuilder()
+		n, err := HashRecords(source, builder.Add)
+		if err != nil || n != 11 || builder.Len() != 11 {
+			t.Errorf("%s: Expected 11 records hashed, got %d (%v)", name, n, err)
+		}
+		if root := builder.Root(); root != expected {
+			t.Errorf("%s: Expected root %s, got %s", name, expected, root)
+		}
+	}
+
+	n, err := HashRecords(NewJSONRecordReader(strings.NewReader(`{"id":1}`+"\n"+`{"id":`)), func(string) error { return nil })
+	if err == nil || n != 1 {
+		t.Errorf("Expected an error after one record, got %d (%v)", n, err)
+	}
+	builder := NewMerkleBuilder()
+	for i, leaf := range leaves {
+		builder.Add(testHex(leaf)[0])
+		if root := builder.Root(); root != testHex(testTreeHash(leaves[:i+1]))[0] {
+			t.Errorf("Expected the root of %d leaves, got %s", i+1, root)
+		}
+	}
+	if err := builder.Add("0xnothex"); err == nil {
+		t.Error("Expected an invalid hash to be rejected")
+	}
+	if NewMerkleBuilder().Root() != "" {
+		t.Error("Expected an empty builder to have no root")
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"math"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -21,8 +23,103 @@ func (e *AuthenticationError) Error() string {
 	return e.Message
 }
 
+// SignatureOption configures signature generation and verification.
+type SignatureOption func(*signatureOptions)
+
+type signatureOptions struct {
+	clock     Clock
+	tolerance time.Duration
+}
+
+// DefaultSignatureTolerance is how far a delivery's timestamp may be from
+// the current time before its signature is rejected as a replay.
+const DefaultSignatureTolerance = 5 * time.Minute
+
+// WithSignatureClock checks and generates timestamps against clock instead
+// of the system time.
+func WithSignatureClock(clock Clock) SignatureOption {
+	return func(o *signatureOptions) {
+		if clock != nil {
+			o.clock = clock
+		}
+	}
+}
+
+// WithSignatureTolerance accepts timestamps up to d away from the current
+// time, for deliveries that are relayed with delay, such as through an
+// event bus.
+func WithSignatureTolerance(d time.Duration) SignatureOption {
+	return func(o *signatureOptions) {
+		if d > 0 {
+			o.tolerance = d
+		}
+	}
+}
+
+func newSignatureOptions(opts []SignatureOption) *signatureOptions {
+	o := &signatureOptions{clock: SystemClock, tolerance: DefaultSignatureTolerance}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// signer holds a keyed HMAC and scratch space so that verifying a delivery
+// does not allocate. Signers are pooled and re-keyed only when the secret
+// changes.
+type signer struct {
+	secret  string
+	mac     hash.Hash
+	scratch [2 * sha256.Size]byte
+	sum     [sha256.Size]byte
+	hexSum  [2 * sha256.Size]byte
+}
+
+var signerPool = sync.Pool{
+	New: func() interface{} { return new(signer) },
+}
+
+func acquireSigner(secret string) *signer {
+	s := signerPool.Get().(*signer)
+	if s.mac == nil || s.secret != secret {
+		s.secret = secret
+		s.mac = hmac.New(sha256.New, []byte(secret))
+	} else {
+		s.mac.Reset()
+	}
+	return s
+}
+
+func releaseSigner(s *signer) {
+	signerPool.Put(s)
+}
+
+// sign writes "<timestamp>.<body>" into the HMAC and leaves the hex digest
+// in s.hexSum.
+func (s *signer) sign(timestamp string, body []byte) {
+	s.signDomain("", timestamp, body)
+}
+
+// signDomain writes "<domain><timestamp>.<body>" into the HMAC and leaves
+// the hex digest in s.hexSum.
+func (s *signer) signDomain(domain, timestamp string, body []byte) {
+	prefix := append(append(append(s.scratch[:0], domain...), timestamp...), '.')
+	s.mac.Write(prefix)
+	s.mac.Write(body)
+	s.mac.Sum(s.sum[:0])
+	hex.Encode(s.hexSum[:], s.sum[:])
+}
+
 // VerifySignature verifies the HMAC signature of a webhook payload.
-func VerifySignature(secret string, body []byte, headers Headers) error {
+func VerifySignature(secret string, body []byte, headers Headers, opts ...SignatureOption) error {
+	if len(opts) == 0 {
+		return verifySignature(secret, body, headers, SystemClock, DefaultSignatureTolerance)
+	}
+	o := newSignatureOptions(opts)
+	return verifySignature(secret, body, headers, o.clock, o.tolerance)
+}
+
+func verifySignature(secret string, body []byte, headers Headers, clock Clock, tolerance time.Duration) error {
 	if secret == "" {
 		return &AuthenticationError{Message: "webhook secret not configured"}
 	}
@@ -43,51 +140,70 @@ func VerifySignature(secret string, body []byte, headers Headers) error {
 		return &AuthenticationError{Message: "missing X-Kiket-Timestamp header"}
 	}
 
+	return checkSignature("", secret, body, signature, timestamp, "X-Kiket-Timestamp", clock, tolerance)
+}
+
+// checkSignature checks signature against the HMAC of
+// "<domain><timestamp>.<body>" and that timestamp is within tolerance.
+// timestampHeader names the header in error messages.
+func checkSignature(domain, secret string, body []byte, signature, timestamp, timestampHeader string, clock Clock, tolerance time.Duration) error {
 	// Parse and validate timestamp
 	requestTime, err := strconv.ParseInt(timestamp, 10, 64)
 	if err != nil {
-		return &AuthenticationError{Message: "invalid X-Kiket-Timestamp header"}
+		return &AuthenticationError{Message: "invalid " + timestampHeader + " header"}
 	}
 
-	now := time.Now().Unix()
+	now := clock.Now().Unix()
 	timeDiff := math.Abs(float64(now - requestTime))
-	if timeDiff > 300 {
+	if timeDiff > tolerance.Seconds() {
 		return &AuthenticationError{
 			Message: fmt.Sprintf("request timestamp too old or too far in future: %.0fs", timeDiff),
 		}
 	}
 
-	// Compute expected signature
-	payload := timestamp + "." + string(body)
-	mac := hmac.New(sha256.New, []byte(secret))
-	mac.Write([]byte(payload))
-	expectedSignature := hex.EncodeToString(mac.Sum(nil))
+	if len(signature) != 2*sha256.Size {
+		return &AuthenticationError{Message: "invalid signature"}
+	}
+
+	// Compute the expected signature and compare in constant time, reusing
+	// the signer's buffers instead of building intermediate strings.
+	s := acquireSigner(secret)
+	defer releaseSigner(s)
 
-	// Constant-time comparison
-	if subtle.ConstantTimeCompare([]byte(signature), []byte(expectedSignature)) != 1 {
+	s.signDomain(domain, timestamp, body)
+	got := append(s.scratch[:0], signature...)
+	if subtle.ConstantTimeCompare(got, s.hexSum[:]) != 1 {
 		return &AuthenticationError{Message: "invalid signature"}
 	}
 
 	return nil
 }
 
+// signatureClock resolves the clock from opts, skipping the options struct
+// in the common case of no options.
+func signatureClock(opts []SignatureOption) Clock {
+	if len(opts) == 0 {
+		return SystemClock
+	}
+	return newSignatureOptions(opts).clock
+}
+
 // GenerateSignature generates an HMAC signature for a payload (for testing).
-func GenerateSignature(secret string, body string, timestamp *int64) (signature string, ts string) {
+func GenerateSignature(secret string, body string, timestamp *int64, opts ...SignatureOption) (signature string, ts string) {
 	var tsVal int64
 	if timestamp != nil {
 		tsVal = *timestamp
 	} else {
-		tsVal = time.Now().Unix()
+		tsVal = signatureClock(opts).Now().Unix()
 	}
 
 	tsStr := strconv.FormatInt(tsVal, 10)
-	payload := tsStr + "." + body
 
-	mac := hmac.New(sha256.New, []byte(secret))
-	mac.Write([]byte(payload))
-	sig := hex.EncodeToString(mac.Sum(nil))
+	s := acquireSigner(secret)
+	defer releaseSigner(s)
 
-	return sig, tsStr
+	s.sign(tsStr, []byte(body))
+	return string(s.hexSum[:]), tsStr
 }
 
 // IsAuthenticationError checks if an error is an AuthenticationError.
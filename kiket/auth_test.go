@@ -0,0 +1,103 @@
+package kiket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVerifySignature_RoundTrip(t *testing.T) {
+	body := []byte(`{"event":"issue.created"}`)
+	signature, timestamp := GenerateSignature("secret", string(body), nil)
+	headers := Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp}
+
+	if err := VerifySignature("secret", body, headers); err != nil {
+		t.Fatalf("Expected valid signature, got %v", err)
+	}
+	if err := VerifySignature("other", body, headers); !IsAuthenticationError(err) {
+		t.Errorf("Expected authentication error for wrong secret, got %v", err)
+	}
+
+	headers["X-Kiket-Signature"] = signature[:len(signature)-1]
+	if err := VerifySignature("secret", body, headers); !IsAuthenticationError(err) {
+		t.Errorf("Expected authentication error for truncated signature, got %v", err)
+	}
+}
+
+func TestVerifySignature_DoesNotAllocate(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector allocates")
+	}
+	body := []byte(`{"event":"issue.created","issue":{"id":1}}`)
+	signature, timestamp := GenerateSignature("secret", string(body), nil)
+	headers := Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := VerifySignature("secret", body, headers); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Expected 0 allocations, got %.1f", allocs)
+	}
+}
+
+func BenchmarkVerifySignature(b *testing.B) {
+	body := make([]byte, 4096)
+	signature, timestamp := GenerateSignature("secret", string(body), nil)
+	headers := Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	for i := 0; i < b.N; i++ {
+		if err := VerifySignature("secret", body, headers); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSDK_SignResponses(t *testing.T) {
+	sdk, err := New(Config{
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+		SignResponses:   true,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		return map[string]string{"status": "ok"}, nil
+	})
+
+	body := `{"event":"issue.created"}`
+	signature, timestamp := GenerateSignature("secret", body, nil)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set("X-Kiket-Signature", signature)
+	req.Header.Set("X-Kiket-Timestamp", timestamp)
+	rec := httptest.NewRecorder()
+	sdk.ServeHTTP(rec, req)
+
+	headers := Headers{
+		ResponseSignatureHeader: rec.Header().Get(ResponseSignatureHeader),
+		ResponseTimestampHeader: rec.Header().Get(ResponseTimestampHeader),
+	}
+	if err := VerifyResponseSignature("secret", rec.Body.Bytes(), headers); err != nil {
+		t.Errorf("Expected a valid response signature, got %v", err)
+	}
+	if err := VerifyResponseSignature("secret", []byte(`{"status":"forged"}`), headers); !IsAuthenticationError(err) {
+		t.Errorf("Expected a tampered body to fail verification, got %v", err)
+	}
+
+	// A captured response must not pass as a signed delivery of its body
+	replay := Headers{
+		"X-Kiket-Signature": headers[ResponseSignatureHeader],
+		"X-Kiket-Timestamp": headers[ResponseTimestampHeader],
+	}
+	if err := VerifySignature("secret", rec.Body.Bytes(), replay); !IsAuthenticationError(err) {
+		t.Errorf("Expected a replayed response to fail delivery verification, got %v", err)
+	}
+}
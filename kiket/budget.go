@@ -0,0 +1,94 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxBudgetHeadroom caps the default headroom reserved from a processing
+// budget.
+const maxBudgetHeadroom = 2 * time.Second
+
+// ErrBudgetExhausted is returned by API calls made after a delivery's
+// processing budget, less its headroom, has run out (see
+// Config.ProcessingBudget).
+var ErrBudgetExhausted = errors.New("delivery processing budget exhausted")
+
+// processingBudget is the deadline HandleWebhook set for a delivery and the
+// time reserved before it for answering. deadline is measured on clock.
+type processingBudget struct {
+	deadline time.Time
+	headroom time.Duration
+	clock    Clock
+}
+
+type budgetContextKey struct{}
+
+// withProcessingBudget bounds ctx by budget and records the headroom API
+// calls leave before the deadline, which is measured on clock. A deadline
+// already on ctx that is earlier wins.
+func withProcessingBudget(ctx context.Context, budget, headroom time.Duration, clock Clock) (context.Context, context.CancelFunc) {
+	if headroom <= 0 {
+		headroom = budget / 10
+		if headroom > maxBudgetHeadroom {
+			headroom = maxBudgetHeadroom
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if until := time.Until(deadline); until < budget {
+			budget = until
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, budget)
+	return context.WithValue(ctx, budgetContextKey{}, processingBudget{
+		deadline: clock.Now().Add(budget),
+		headroom: headroom,
+		clock:    clock,
+	}), cancel
+}
+
+// callContext bounds an API call on ctx so it ends the headroom before the
+// delivery's budget does, leaving time to answer the delivery. It returns
+// ErrBudgetExhausted when no time is left for the call.
+func callContext(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	budget, ok := ctx.Value(budgetContextKey{}).(processingBudget)
+	if !ok {
+		return ctx, func() {}, nil
+	}
+
+	remaining := budget.remaining()
+	if remaining <= 0 {
+		return ctx, func() {}, fmt.Errorf("%w: %s reserved for the response", ErrBudgetExhausted, budget.headroom)
+	}
+	ctx, cancel := context.WithTimeout(ctx, remaining)
+	return ctx, cancel, nil
+}
+
+// BudgetRemaining returns how long API calls on ctx may still take before
+// the delivery's processing budget, less its headroom, runs out. It reports
+// false when ctx carries no budget.
+func BudgetRemaining(ctx context.Context) (time.Duration, bool) {
+	budget, ok := ctx.Value(budgetContextKey{}).(processingBudget)
+	if !ok {
+		return 0, false
+	}
+	remaining := budget.remaining()
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true
+}
+
+// remaining returns the time left for API calls, less the headroom.
+func (b processingBudget) remaining() time.Duration {
+	return b.deadline.Add(-b.headroom).Sub(b.clock.Now())
+}
+
+// isBudgetError reports whether err ended a delivery because its processing
+// budget ran out.
+func isBudgetError(err error) bool {
+	return errors.Is(err, ErrBudgetExhausted) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,133 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSDK_ProcessingBudgetReservesHeadroom(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer slow.Close()
+
+	sdk, err := New(Config{
+		WebhookSecret:    "secret",
+		ExtensionAPIKey:  "key",
+		BaseURL:          slow.URL,
+		ProcessingBudget: 500 * time.Millisecond,
+		BudgetHeadroom:   200 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	var callErr, laterErr error
+	var deadline time.Time
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		deadline = hctx.Deadline
+		_, callErr = hctx.Client.Get(ctx, "/api/v1/ext/issues", nil)
+		_, laterErr = hctx.Client.Get(ctx, "/api/v1/ext/issues", nil)
+		return nil, callErr
+	})
+
+	body := `{"event":"issue.created"}`
+	signature, timestamp := GenerateSignature("secret", body, nil)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set("X-Kiket-Signature", signature)
+	req.Header.Set("X-Kiket-Timestamp", timestamp)
+	rec := httptest.NewRecorder()
+
+	start := time.Now()
+	sdk.ServeHTTP(rec, req)
+	elapsed := time.Since(start)
+
+	if elapsed > 450*time.Millisecond {
+		t.Errorf("Expected the call to end within the headroom, took %s", elapsed)
+	}
+	if !errors.Is(callErr, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", callErr)
+	}
+	if !errors.Is(laterErr, ErrBudgetExhausted) {
+		t.Errorf("Expected ErrBudgetExhausted, got %v", laterErr)
+	}
+	if deadline.IsZero() {
+		t.Error("Expected HandlerContext.Deadline to be set")
+	}
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Errorf("Expected status 504, got %d", rec.Code)
+	}
+}
+
+// budgetTestClock is a Clock that only moves when advanced.
+type budgetTestClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *budgetTestClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *budgetTestClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func TestSDK_ProcessingBudgetUsesConfiguredClock(t *testing.T) {
+	clock := &budgetTestClock{now: time.Now()}
+	sdk, err := New(Config{
+		WebhookSecret:    "secret",
+		ExtensionAPIKey:  "key",
+		BaseURL:          "http://127.0.0.1:0",
+		ProcessingBudget: time.Minute,
+		BudgetHeadroom:   10 * time.Second,
+		Clock:            clock,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	var before, after time.Duration
+	var callErr error
+	var deadline time.Time
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		deadline = hctx.Deadline
+		before, _ = BudgetRemaining(ctx)
+		clock.advance(50 * time.Second)
+		after, _ = BudgetRemaining(ctx)
+		_, callErr = hctx.Client.Get(ctx, "/api/v1/ext/issues", nil)
+		return nil, nil
+	})
+
+	body := `{"event":"issue.created"}`
+	signature, timestamp := GenerateSignature("secret", body, nil)
+	if _, err := sdk.HandleWebhook(context.Background(), []byte(body), Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp}); err != nil {
+		t.Fatalf("HandleWebhook failed: %v", err)
+	}
+
+	start := clock.now.Add(-50 * time.Second)
+	if !deadline.Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected the deadline a minute after the clock's start, got %s", deadline.Sub(start))
+	}
+	if before != 50*time.Second || after != 0 {
+		t.Errorf("Expected 50s remaining, then none, got %s and %s", before, after)
+	}
+	if !errors.Is(callErr, ErrBudgetExhausted) {
+		t.Errorf("Expected ErrBudgetExhausted once the clock passed the headroom, got %v", callErr)
+	}
+}
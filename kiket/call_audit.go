@@ -0,0 +1,128 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// CallAuditEntry is one line of the outbound call audit log (see
+// WithAuditLog). Credentials and secret values are never recorded.
+type CallAuditEntry struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	// API path without the query string
+	Path string `json:"path"`
+	// Query parameters, with credential-like values redacted
+	Query map[string]string `json:"query,omitempty"`
+	// HTTP status (0 when no response was received)
+	Status     int     `json:"status"`
+	DurationMs float64 `json:"duration_ms"`
+	// Transport error, when the call failed without a response
+	Error string `json:"error,omitempty"`
+
+	// Delivery that made the call (empty outside handlers)
+	Event      string `json:"event,omitempty"`
+	DeliveryID string `json:"delivery_id,omitempty"`
+	// Workspace the call acted for (see ContextWithWorkspace)
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	// User the call was made on behalf of (see Endpoints.ActAs)
+	ActAs string `json:"act_as,omitempty"`
+}
+
+// callAuditLog writes CallAuditEntry records as JSON lines.
+type callAuditLog struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// WithAuditLog writes one JSON line per outbound API call to w: method,
+// path, status, duration, and the event and delivery that made it. Enable
+// it when compliance reviews need a record of the extension's behavior.
+// Writes are serialized, so w need not be safe for concurrent use.
+func WithAuditLog(w io.Writer) ClientOption {
+	return func(c *HTTPClient) {
+		if w != nil {
+			c.auditLog = &callAuditLog{enc: json.NewEncoder(w)}
+		}
+	}
+}
+
+func (l *callAuditLog) record(ctx context.Context, c *HTTPClient, method, path string, opts *RequestOptions, status int, start time.Time, err error) {
+	entry := CallAuditEntry{
+		Time:        start.UTC(),
+		Method:      method,
+		Path:        path,
+		Status:      status,
+		DurationMs:  float64(time.Since(start).Microseconds()) / 1000,
+		WorkspaceID: WorkspaceFromContext(ctx),
+		ActAs:       c.actAs,
+	}
+	if opts != nil {
+		if actAs := opts.Headers[ActAsHeader]; actAs != "" {
+			entry.ActAs = actAs
+		}
+		if len(opts.Params) > 0 {
+			entry.Query = make(map[string]string, len(opts.Params))
+			for key, value := range opts.Params {
+				if sensitiveKey(key, nil) {
+					value = redacted
+				}
+				entry.Query[key] = value
+			}
+		}
+	}
+	var apiErr *APIError
+	if err != nil && !errors.As(err, &apiErr) {
+		// Transport errors can echo the URL, so only the kind is kept
+		entry.Error = auditErrorKind(err)
+	}
+	if hctx := handlerFromContext(ctx); hctx != nil {
+		entry.Event = hctx.Event
+		entry.DeliveryID = hctx.DeliveryID
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_ = l.enc.Encode(entry)
+}
+
+// auditErrorKind summarizes a failed call without its message.
+func auditErrorKind(err error) string {
+	var timeout interface{ Timeout() bool }
+	switch {
+	case errors.Is(err, ErrBudgetExhausted):
+		return "budget exhausted"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &timeout) && timeout.Timeout():
+		return "timeout"
+	default:
+		return "request failed"
+	}
+}
+
+// auditStatus returns the HTTP status carried by err, or 0.
+func auditStatus(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+type handlerContextKey struct{}
+
+// contextWithHandler attaches the delivery being handled, so API calls
+// made on ctx can be attributed to it.
+func contextWithHandler(ctx context.Context, hctx *HandlerContext) context.Context {
+	return context.WithValue(ctx, handlerContextKey{}, hctx)
+}
+
+func handlerFromContext(ctx context.Context) *HandlerContext {
+	hctx, _ := ctx.Value(handlerContextKey{}).(*HandlerContext)
+	return hctx
+}
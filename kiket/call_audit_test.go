@@ -0,0 +1,81 @@
+package kiket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSDK_AuditLogRecordsOutboundCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/ext/issues/404" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	var audit bytes.Buffer
+	sdk, err := New(Config{
+		WebhookSecret:   "secret",
+		ExtensionAPIKey: "key",
+		BaseURL:         server.URL,
+		AuditLog:        &audit,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		hctx.Client.Get(ctx, "/api/v1/ext/issues", &RequestOptions{Params: map[string]string{"state": "open", "access_token": "tok_123"}})
+		hctx.Client.Get(ctx, "/api/v1/ext/issues/404", nil)
+		return nil, nil
+	})
+
+	body := `{"event":"issue.created"}`
+	signature, timestamp := GenerateSignature("secret", body, nil)
+	_, err = sdk.HandleWebhook(context.Background(), []byte(body), Headers{
+		"X-Kiket-Signature":   signature,
+		"X-Kiket-Timestamp":   timestamp,
+		"X-Kiket-Delivery-Id": "del_1",
+		WorkspaceIDHeader:     "ws_1",
+	})
+	if err != nil {
+		t.Fatalf("HandleWebhook failed: %v", err)
+	}
+
+	if strings.Contains(audit.String(), "tok_123") {
+		t.Errorf("Expected credentials redacted, got %s", audit.String())
+	}
+	var entries []CallAuditEntry
+	dec := json.NewDecoder(&audit)
+	for dec.More() {
+		var entry CallAuditEntry
+		if err := dec.Decode(&entry); err != nil {
+			t.Fatalf("Expected JSON lines, got error %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 audit entries, got %d", len(entries))
+	}
+
+	list := entries[0]
+	if list.Method != "GET" || list.Path != "/api/v1/ext/issues" || list.Status != 200 {
+		t.Errorf("Expected GET /api/v1/ext/issues 200, got %+v", list)
+	}
+	if list.Query["state"] != "open" || list.Query["access_token"] != "[redacted]" {
+		t.Errorf("Expected redacted query, got %v", list.Query)
+	}
+	if list.Event != "issue.created" || list.DeliveryID != "del_1" || list.WorkspaceID != "ws_1" {
+		t.Errorf("Expected caller attribution, got %+v", list)
+	}
+	if entries[1].Status != 404 || entries[1].Error != "" {
+		t.Errorf("Expected 404 without transport error, got %+v", entries[1])
+	}
+}
@@ -0,0 +1,79 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// sdkVersionHeader tells the API which SDK and version made a call.
+const sdkVersionHeader = "X-Kiket-SDK-Version"
+
+// sdkVersionValue is sent in sdkVersionHeader, e.g. "go/0.1.0".
+const sdkVersionValue = "go/" + SDKVersion
+
+// Capabilities describes the API features a Kiket instance supports.
+// Self-hosted instances can lag behind kiket.dev, so check for a feature
+// before relying on it.
+type Capabilities struct {
+	// Kiket server version, e.g. "2024.6.1" ("" before capability discovery)
+	Version string `json:"version"`
+	// API version, e.g. "v1"
+	APIVersion string `json:"api_version"`
+	// Supported features, e.g. "events.stream", "custom_data.permissions"
+	Features []string `json:"features"`
+	// Oldest SDK version the instance accepts ("" when unrestricted)
+	MinSDKVersion string `json:"min_sdk_version,omitempty"`
+}
+
+// Supports reports whether the instance supports a feature.
+func (c *Capabilities) Supports(feature string) bool {
+	for _, f := range c.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// capabilityCache holds the capabilities once fetched; scoped copies of
+// Endpoints share it.
+type capabilityCache struct {
+	mu           sync.Mutex
+	capabilities *Capabilities
+}
+
+// Capabilities reports which API features the Kiket instance supports. The
+// result is fetched once and cached. Instances that predate capability
+// discovery report no features rather than an error.
+func (e *Endpoints) Capabilities(ctx context.Context) (*Capabilities, error) {
+	e.capabilities.mu.Lock()
+	defer e.capabilities.mu.Unlock()
+	if e.capabilities.capabilities != nil {
+		return e.capabilities.capabilities, nil
+	}
+
+	resp, err := e.client.Get(ctx, apiPrefix+"/ext/capabilities", nil)
+	if err != nil {
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+			return nil, err
+		}
+		resp = []byte(`{"data":{}}`)
+	}
+
+	result, err := decodeData[Capabilities](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	e.capabilities.capabilities = &result
+	return e.capabilities.capabilities, nil
+}
+
+// Supports reports whether the Kiket instance supports a feature (see
+// Capabilities). Errors are treated as unsupported.
+func (e *Endpoints) Supports(ctx context.Context, feature string) bool {
+	capabilities, err := e.Capabilities(ctx)
+	return err == nil && capabilities.Supports(feature)
+}
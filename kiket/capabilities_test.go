@@ -0,0 +1,50 @@
+package kiket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEndpoints_Capabilities(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.Header.Get(sdkVersionHeader); got != "go/"+SDKVersion {
+			t.Errorf("Expected SDK version header, got %q", got)
+		}
+		w.Write([]byte(`{"data":{"version":"2024.6.1","api_version":"v1","features":["events.stream"]}}`))
+	}))
+	defer server.Close()
+
+	endpoints := NewEndpoints(NewHTTPClient(WithBaseURL(server.URL)), "com.example.ext", "1.0.0")
+	ctx := context.Background()
+	capabilities, err := endpoints.Capabilities(ctx)
+	if err != nil {
+		t.Fatalf("Capabilities failed: %v", err)
+	}
+	if capabilities.Version != "2024.6.1" || !capabilities.Supports("events.stream") {
+		t.Errorf("Unexpected capabilities %+v", capabilities)
+	}
+	if !endpoints.ForProject(1).Supports(ctx, "events.stream") || endpoints.Supports(ctx, "grpc") {
+		t.Error("Unexpected Supports result")
+	}
+	if requests != 1 {
+		t.Errorf("Expected capabilities to be cached, got %d requests", requests)
+	}
+}
+
+func TestEndpoints_CapabilitiesLegacyInstance(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	endpoints := NewEndpoints(NewHTTPClient(WithBaseURL(server.URL)), "com.example.ext", "1.0.0")
+	capabilities, err := endpoints.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error for legacy instance, got %v", err)
+	}
+	if len(capabilities.Features) != 0 {
+		t.Errorf("Expected no features, got %v", capabilities.Features)
+	}
+}
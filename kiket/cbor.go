@@ -0,0 +1,271 @@
+package kiket
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+type cborFormat struct{}
+
+// CBORFormat returns the CBOR payload format ("application/cbor"). Byte
+// strings become base64 strings; tags are dropped and their content kept.
+func CBORFormat() PayloadFormat {
+	return cborFormat{}
+}
+
+func (cborFormat) ContentType() string { return "application/cbor" }
+
+func (cborFormat) AppendJSON(dst, data []byte) ([]byte, error) {
+	d := cborDecoder{data: data}
+	dst, err := d.value(dst, 0)
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, errors.New("trailing data after payload")
+	}
+	return dst, nil
+}
+
+// cborBreak marks the end of an indefinite-length item.
+const cborBreak = 0xff
+
+const cborIndefinite = -1
+
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *cborDecoder) next(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.pos < n {
+		return nil, errFormatTruncated
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// head reads an item's major type and argument. For indefinite-length
+// items indefinite is true.
+func (d *cborDecoder) head() (major byte, arg uint64, indefinite bool, err error) {
+	b, err := d.next(1)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	major, info := b[0]>>5, b[0]&0x1f
+
+	switch {
+	case info < 24:
+		return major, uint64(info), false, nil
+	case info == 31:
+		return major, 0, true, nil
+	case info > 27:
+		return 0, 0, false, fmt.Errorf("invalid CBOR additional info %d", info)
+	}
+
+	ext, err := d.next(1 << (info - 24))
+	if err != nil {
+		return 0, 0, false, err
+	}
+	switch len(ext) {
+	case 1:
+		arg = uint64(ext[0])
+	case 2:
+		arg = uint64(binary.BigEndian.Uint16(ext))
+	case 4:
+		arg = uint64(binary.BigEndian.Uint32(ext))
+	default:
+		arg = binary.BigEndian.Uint64(ext)
+	}
+	return major, arg, false, nil
+}
+
+func (d *cborDecoder) count(arg uint64, indefinite bool) (int, error) {
+	if indefinite {
+		return cborIndefinite, nil
+	}
+	if arg > uint64(len(d.data)) {
+		return 0, errFormatTruncated
+	}
+	return int(arg), nil
+}
+
+func (d *cborDecoder) atBreak() bool {
+	if d.pos < len(d.data) && d.data[d.pos] == cborBreak {
+		d.pos++
+		return true
+	}
+	return false
+}
+
+func (d *cborDecoder) value(dst []byte, depth int) ([]byte, error) {
+	if depth > maxFormatDepth {
+		return nil, errors.New("payload nested too deeply")
+	}
+	start := d.pos
+	major, arg, indefinite, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case 0:
+		return strconv.AppendUint(dst, arg, 10), nil
+	case 1:
+		if arg == math.MaxUint64 {
+			return append(dst, "-18446744073709551616"...), nil
+		}
+		dst = append(dst, '-')
+		return strconv.AppendUint(dst, arg+1, 10), nil
+	case 2:
+		raw, err := d.chunks(2, arg, indefinite)
+		if err != nil {
+			return nil, err
+		}
+		return appendJSONBase64(dst, raw), nil
+	case 3:
+		raw, err := d.chunks(3, arg, indefinite)
+		if err != nil {
+			return nil, err
+		}
+		return appendJSONString(dst, raw), nil
+	case 4:
+		n, err := d.count(arg, indefinite)
+		if err != nil {
+			return nil, err
+		}
+		dst = append(dst, '[')
+		for i := 0; n == cborIndefinite || i < n; i++ {
+			if n == cborIndefinite && d.atBreak() {
+				break
+			}
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			if dst, err = d.value(dst, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return append(dst, ']'), nil
+	case 5:
+		n, err := d.count(arg, indefinite)
+		if err != nil {
+			return nil, err
+		}
+		dst = append(dst, '{')
+		for i := 0; n == cborIndefinite || i < n; i++ {
+			if n == cborIndefinite && d.atBreak() {
+				break
+			}
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			if dst, err = d.key(dst); err != nil {
+				return nil, err
+			}
+			dst = append(dst, ':')
+			if dst, err = d.value(dst, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return append(dst, '}'), nil
+	case 6:
+		// Tags annotate the following item; JSON has no equivalent.
+		return d.value(dst, depth+1)
+	}
+
+	// Major type 7: simple values and floats.
+	if indefinite {
+		return nil, errors.New("unexpected CBOR break")
+	}
+	switch info := d.data[start] & 0x1f; {
+	case info == 20:
+		return append(dst, "false"...), nil
+	case info == 21:
+		return append(dst, "true"...), nil
+	case info == 22 || info == 23:
+		return append(dst, "null"...), nil
+	case info == 25:
+		return appendJSONFloat(dst, halfToFloat(uint16(arg)))
+	case info == 26:
+		return appendJSONFloat(dst, float64(math.Float32frombits(uint32(arg))))
+	case info == 27:
+		return appendJSONFloat(dst, math.Float64frombits(arg))
+	}
+	return nil, fmt.Errorf("unsupported CBOR simple value %d", arg)
+}
+
+// chunks reads a byte or text string, joining indefinite-length chunks.
+func (d *cborDecoder) chunks(major byte, arg uint64, indefinite bool) ([]byte, error) {
+	if !indefinite {
+		n, err := d.count(arg, false)
+		if err != nil {
+			return nil, err
+		}
+		return d.next(n)
+	}
+
+	var out []byte
+	for !d.atBreak() {
+		m, n, ind, err := d.head()
+		if err != nil {
+			return nil, err
+		}
+		if m != major || ind {
+			return nil, errors.New("invalid CBOR string chunk")
+		}
+		chunk, err := d.chunks(major, n, false)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, chunk...)
+	}
+	return out, nil
+}
+
+// key transcodes a map key, which JSON requires to be a string. Integer
+// keys are quoted.
+func (d *cborDecoder) key(dst []byte) ([]byte, error) {
+	if d.pos >= len(d.data) {
+		return nil, errFormatTruncated
+	}
+	switch d.data[d.pos] >> 5 {
+	case 3:
+		return d.value(dst, 0)
+	case 0, 1:
+		dst = append(dst, '"')
+		dst, err := d.value(dst, 0)
+		if err != nil {
+			return nil, err
+		}
+		return append(dst, '"'), nil
+	}
+	return nil, errors.New("unsupported CBOR map key type")
+}
+
+// halfToFloat converts an IEEE 754 half-precision value.
+func halfToFloat(h uint16) float64 {
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = mant * math.Pow(2, -24)
+	case 31:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = (1 + mant/1024) * math.Pow(2, float64(exp-15))
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
@@ -0,0 +1,147 @@
+package kiket
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// FieldChange describes a single field change.
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// FieldChanges is the "changes" section of an issue.updated payload. It
+// decodes both the v1 form, an object keyed by field, and the v2 list;
+// the v1 form is sorted by field.
+//
+//	changes, err := payload.Changes()
+//	if changes.ChangedTo("state", "done") {
+//		// ...
+//	}
+type FieldChanges []FieldChange
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *FieldChanges) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*c = nil
+		return nil
+	case len(data) > 0 && data[0] == '[':
+		var list []FieldChange
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		*c = list
+		return nil
+	}
+
+	var byField map[string]struct {
+		From interface{} `json:"from"`
+		To   interface{} `json:"to"`
+	}
+	if err := json.Unmarshal(data, &byField); err != nil {
+		return err
+	}
+	list := make(FieldChanges, 0, len(byField))
+	for field, change := range byField {
+		list = append(list, FieldChange{Field: field, From: change.From, To: change.To})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Field < list[j].Field })
+	*c = list
+	return nil
+}
+
+// Get returns the change of field.
+func (c FieldChanges) Get(field string) (FieldChange, bool) {
+	for _, change := range c {
+		if change.Field == field {
+			return change, true
+		}
+	}
+	return FieldChange{}, false
+}
+
+// Changed reports whether field changed.
+func (c FieldChanges) Changed(field string) bool {
+	_, ok := c.Get(field)
+	return ok
+}
+
+// ChangedTo reports whether field changed to value. Numbers compare by
+// value, so ChangedTo("assignee_id", 42) matches a decoded 42.0.
+func (c FieldChanges) ChangedTo(field string, value interface{}) bool {
+	change, ok := c.Get(field)
+	return ok && changeValueEqual(change.To, value)
+}
+
+// ChangedFrom reports whether field changed from value.
+func (c FieldChanges) ChangedFrom(field string, value interface{}) bool {
+	change, ok := c.Get(field)
+	return ok && changeValueEqual(change.From, value)
+}
+
+// Fields returns the names of the changed fields.
+func (c FieldChanges) Fields() []string {
+	fields := make([]string, len(c))
+	for i, change := range c {
+		fields[i] = change.Field
+	}
+	return fields
+}
+
+// changeValueEqual compares a decoded JSON value with a Go value.
+func changeValueEqual(decoded, value interface{}) bool {
+	if a, ok := changeNumber(decoded); ok {
+		b, ok := changeNumber(value)
+		return ok && a == b
+	}
+	return reflect.DeepEqual(decoded, value)
+}
+
+func changeNumber(v interface{}) (float64, bool) {
+	if n, ok := v.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// Changes returns the field changes of an issue.updated payload, or nil
+// when the payload has none.
+func (p WebhookPayload) Changes() (FieldChanges, error) {
+	var changes FieldChanges
+	if _, ok := p["changes"]; !ok {
+		return nil, nil
+	}
+	if err := p.Decode("changes", &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// Changes returns the field changes of an issue.updated payload, or nil
+// when the payload has none.
+func (p *RawPayload) Changes() (FieldChanges, error) {
+	var changes FieldChanges
+	if _, ok := p.Field("changes"); !ok {
+		return nil, nil
+	}
+	if err := p.Decode("changes", &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,46 @@
+package kiket
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFieldChanges_DecodesV1AndV2(t *testing.T) {
+	payloads := map[string]string{
+		"v1": `{"changes": {"state": {"from": "open", "to": "done"}, "assignee_id": {"from": null, "to": 42}}}`,
+		"v2": `{"changes": [{"field": "assignee_id", "from": null, "to": 42}, {"field": "state", "from": "open", "to": "done"}]}`,
+	}
+	for version, body := range payloads {
+		var payload WebhookPayload
+		if err := json.Unmarshal([]byte(body), &payload); err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		changes, err := payload.Changes()
+		if err != nil {
+			t.Fatalf("%s: Changes failed: %v", version, err)
+		}
+
+		if got := changes.Fields(); !reflect.DeepEqual(got, []string{"assignee_id", "state"}) {
+			t.Errorf("%s: Expected assignee_id and state, got %v", version, got)
+		}
+		if !changes.ChangedTo("state", "done") || !changes.ChangedFrom("state", "open") {
+			t.Errorf("%s: Expected state open -> done, got %+v", version, changes)
+		}
+		if !changes.ChangedTo("assignee_id", 42) || changes.ChangedTo("assignee_id", 7) {
+			t.Errorf("%s: Expected assignee 42 to match by value, got %+v", version, changes)
+		}
+		if changes.Changed("priority") {
+			t.Errorf("%s: Expected priority unchanged", version)
+		}
+
+		raw, err := parseRawPayload([]byte(body))
+		if err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		rawChanges, err := raw.Changes()
+		if err != nil || !reflect.DeepEqual(rawChanges, changes) {
+			t.Errorf("%s: Expected the raw payload to decode the same changes, got %+v, %v", version, rawChanges, err)
+		}
+	}
+}
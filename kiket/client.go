@@ -8,20 +8,73 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const (
 	defaultTimeout = 30 * time.Second
 	defaultBaseURL = "https://kiket.dev"
+	apiKeyHeader   = "X-Kiket-API-Key"
 )
 
+// maxPooledBuffer caps the size of buffers returned to bufferPool so one
+// large export doesn't pin memory for the life of the process.
+const maxPooledBuffer = 1 << 20
+
+// bufferPool holds buffers for reading responses, which keeps allocations
+// flat under high request rates. Request bodies are not pooled: the
+// transport may still be writing them after the call returns.
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		bufferPool.Put(buf)
+	}
+}
+
 // HTTPClient implements the Client interface using net/http.
 type HTTPClient struct {
 	baseURL      string
 	httpClient   *http.Client
 	token        string
+	apiKey       string
 	runtimeToken string
+	actAs        string
+	credentials  CredentialResolver
+
+	persistedQueries bool
+
+	codecs          []ContentCodec
+	encodingMu      sync.RWMutex
+	serverEncodings map[string]bool
+
+	formats []PayloadFormat
+
+	grpc GRPCInvoker
+
+	onDeprecation DeprecationHandler
+	deprecations  sync.Map // "METHOD path" already reported
+
+	auditLog *callAuditLog // set by WithAuditLog
+
+	maxRedirects      int
+	sameHostRedirects bool
+
+	granted atomic.Pointer[grantedScopes] // set by SDK.PreflightScopes
+
+	rateLimit rateLimitTracker
+
+	middleware []Middleware
 }
 
 // ClientOption configures the HTTP client.
@@ -41,6 +94,13 @@ func WithToken(token string) ClientOption {
 	}
 }
 
+// WithAPIKey sets the extension API key used for /api/v1/ext endpoints.
+func WithAPIKey(apiKey string) ClientOption {
+	return func(c *HTTPClient) {
+		c.apiKey = apiKey
+	}
+}
+
 // WithRuntimeToken sets the runtime token for per-invocation auth.
 func WithRuntimeToken(token string) ClientOption {
 	return func(c *HTTPClient) {
@@ -48,6 +108,13 @@ func WithRuntimeToken(token string) ClientOption {
 	}
 }
 
+// WithActAs makes every call on behalf of a user (see Endpoints.ActAs).
+func WithActAs(userID string) ClientOption {
+	return func(c *HTTPClient) {
+		c.actAs = userID
+	}
+}
+
 // WithTimeout sets the HTTP client timeout.
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *HTTPClient) {
@@ -62,7 +129,9 @@ func NewHTTPClient(opts ...ClientOption) *HTTPClient {
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
+		maxRedirects: defaultMaxRedirects,
 	}
+	c.httpClient.CheckRedirect = c.checkRedirect
 
 	for _, opt := range opts {
 		opt(c)
@@ -72,6 +141,41 @@ func NewHTTPClient(opts ...ClientOption) *HTTPClient {
 }
 
 func (c *HTTPClient) doRequest(ctx context.Context, method, path string, body interface{}, opts *RequestOptions) ([]byte, error) {
+	start := time.Now()
+	ctx, cancel, err := callContext(ctx)
+	defer cancel()
+
+	if err == nil {
+		err = validatePath(path)
+	}
+	if err == nil {
+		err = c.checkScopes(ctx, method, path, opts)
+	}
+
+	var status int
+	var respBody []byte
+	if err == nil {
+		status, respBody, err = c.request(ctx, method, path, body, opts)
+	}
+	if c.auditLog != nil {
+		c.auditLog.record(ctx, c, method, path, opts, status, start, err)
+	}
+	return respBody, err
+}
+
+// request performs an API call and returns the response status (0 when
+// none was received) with the body.
+func (c *HTTPClient) request(ctx context.Context, method, path string, body interface{}, opts *RequestOptions) (int, []byte, error) {
+	if c.grpc != nil {
+		if rpc, fields, ok := matchGRPCRoute(method, path); ok {
+			respBody, err := c.invokeGRPC(ctx, rpc, fields, body, opts)
+			if err != nil {
+				return auditStatus(err), nil, err
+			}
+			return http.StatusOK, respBody, nil
+		}
+	}
+
 	fullURL := c.baseURL + path
 
 	if opts != nil && len(opts.Params) > 0 {
@@ -82,57 +186,185 @@ func (c *HTTPClient) doRequest(ctx context.Context, method, path string, body in
 		fullURL += "?" + params.Encode()
 	}
 
-	var bodyReader io.Reader
+	var payload []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return 0, nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
+
+	var resp *http.Response
+	var respBody []byte
+	for attempt := 1; ; attempt++ {
+		var err error
+		codec := c.requestCodec(len(payload))
+		resp, respBody, err = c.send(ctx, method, fullURL, payload, codec, opts)
+		if err == nil && codec != nil && resp.StatusCode == http.StatusUnsupportedMediaType {
+			// The server stopped accepting the encoding; resend uncompressed.
+			c.forgetEncoding(codec.Encoding())
+			resp, respBody, err = c.send(ctx, method, fullURL, payload, nil, opts)
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return 0, nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			break
+		}
+
+		rateErr := newRateLimitError(resp.Header, respBody)
+		if !c.rateLimit.waitRateLimited(ctx, rateErr, attempt) {
+			return resp.StatusCode, nil, rateErr
+		}
+	}
+
+	if resp.StatusCode >= 400 {
+		return resp.StatusCode, nil, newAPIError(resp.StatusCode, respBody, resp.Header)
+	}
+
+	return resp.StatusCode, respBody, nil
+}
+
+// send performs one HTTP round trip, compressing payload with codec when
+// set, and returns the response with its decoded body.
+func (c *HTTPClient) send(ctx context.Context, method, fullURL string, payload []byte, codec ContentCodec, opts *RequestOptions) (*http.Response, []byte, error) {
+	var bodyReader io.Reader
+	if payload != nil {
+		bodyReader = bytes.NewReader(payload)
+		if codec != nil {
+			var zbuf bytes.Buffer
+			w, err := codec.NewWriter(&zbuf)
+			if err == nil {
+				_, err = w.Write(payload)
+				if closeErr := w.Close(); err == nil {
+					err = closeErr
+				}
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to compress request body: %w", err)
+			}
+			bodyReader = bytes.NewReader(zbuf.Bytes())
 		}
-		bodyReader = bytes.NewReader(jsonBody)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept", acceptHeader(c.formats))
+	if len(c.codecs) > 0 {
+		req.Header.Set("Accept-Encoding", c.acceptEncoding())
+	}
+	if codec != nil {
+		req.Header.Set("Content-Encoding", codec.Encoding())
+	}
+
+	if err := c.setHeaders(ctx, req.Header, opts); err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.roundTrip(c.httpClient, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	c.learnEncodings(resp.Header)
+	c.noteDeprecation(req, resp.Header)
+	c.rateLimit.note(resp.Header)
+
+	reader, err := c.decodeResponse(resp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	defer reader.Close()
+
+	respBuf := getBuffer()
+	defer putBuffer(respBuf)
+	if _, err := respBuf.ReadFrom(reader); err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if format := findFormat(c.formats, resp.Header.Get("Content-Type")); format != nil {
+		body, err := transcode(format, respBuf.Bytes())
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp, body, nil
+	}
+	// Callers keep the returned slice, so copy it out of the pooled buffer.
+	return resp, append([]byte(nil), respBuf.Bytes()...), nil
+}
 
-	// Set authentication
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+// setHeaders applies the SDK version, authentication, and the caller's
+// custom headers.
+func (c *HTTPClient) setHeaders(ctx context.Context, header http.Header, opts *RequestOptions) error {
+	header.Set(sdkVersionHeader, sdkVersionValue)
+	if err := c.setAuthHeaders(ctx, header); err != nil {
+		return err
 	}
 	if c.runtimeToken != "" {
-		req.Header.Set("X-Kiket-Runtime-Token", c.runtimeToken)
+		header.Set("X-Kiket-Runtime-Token", c.runtimeToken)
+	}
+	if c.actAs != "" {
+		header.Set(ActAsHeader, c.actAs)
 	}
 
-	// Apply custom headers
 	if opts != nil && opts.Headers != nil {
 		for k, v := range opts.Headers {
-			req.Header.Set(k, v)
+			header.Set(k, v)
+		}
+	}
+	return nil
+}
+
+// Stream performs a GET request and returns the response body unread, for
+// long-lived responses such as event streams. The client timeout does not
+// apply; cancel ctx to end the stream.
+func (c *HTTPClient) Stream(ctx context.Context, path string, opts *RequestOptions) (io.ReadCloser, error) {
+	if err := validatePath(path); err != nil {
+		return nil, err
+	}
+	fullURL := c.baseURL + path
+	if opts != nil && len(opts.Params) > 0 {
+		params := url.Values{}
+		for k, v := range opts.Params {
+			params.Set(k, v)
 		}
+		fullURL += "?" + params.Encode()
 	}
 
-	resp, err := c.httpClient.Do(req)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if err := c.setHeaders(ctx, req.Header, opts); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	streamClient := &http.Client{Transport: c.httpClient.Transport, CheckRedirect: c.httpClient.CheckRedirect}
+	start := time.Now()
+	resp, err := c.roundTrip(streamClient, req)
+	if c.auditLog != nil {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		c.auditLog.record(ctx, c, http.MethodGet, path, opts, status, start, err)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, &APIError{
-			StatusCode: resp.StatusCode,
-			Body:       string(respBody),
-		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return nil, newAPIError(resp.StatusCode, body, resp.Header)
 	}
 
-	return respBody, nil
+	return resp.Body, nil
 }
 
 // Get performs a GET request.
@@ -166,12 +398,31 @@ func (c *HTTPClient) Close() error {
 	return nil
 }
 
-// APIError represents an API error response.
+// APIError represents an API error response. Code, Message, Details, and
+// RequestID are parsed from the error envelope when the body has one; see
+// IsNotFound, IsValidation, and the other helpers to branch on the kind.
 type APIError struct {
 	StatusCode int
 	Body       string
+	// Machine-readable error code, e.g. "not_found"
+	Code    string
+	Message string
+	// Further context, e.g. the fields that failed validation
+	Details map[string]interface{}
+	// ID of the failed request, for Kiket support
+	RequestID string
 }
 
 func (e *APIError) Error() string {
-	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+	if e.Message == "" {
+		return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+	}
+	msg := fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
+	if e.Code != "" {
+		msg = fmt.Sprintf("API error (status %d, %s): %s", e.StatusCode, e.Code, e.Message)
+	}
+	if e.RequestID != "" {
+		msg += " (request " + e.RequestID + ")"
+	}
+	return msg
 }
@@ -0,0 +1,102 @@
+package kiket
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPClient_ResponsesDoNotShareBuffers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	first, err := client.Post(ctx, "/echo", map[string]string{"n": "first"}, nil)
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	if _, err := client.Post(ctx, "/echo", map[string]string{"n": "second"}, nil); err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+
+	if string(first) != `{"n":"first"}` {
+		t.Errorf("Expected first response to be unchanged, got %q", first)
+	}
+}
+
+func BenchmarkHTTPClient_Post(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(`{"data": {"id": 1, "title": "Checkout page times out", "state": "open"}}`))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(WithBaseURL(server.URL))
+	ctx := context.Background()
+	body := map[string]interface{}{"issue": map[string]interface{}{"title": "Checkout page times out", "labels": []string{"payments"}}}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.Post(ctx, "/api/v1/ext/issues", body, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestHTTPClient_NegotiatesContentEncoding(t *testing.T) {
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+
+		body := r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("Invalid gzip body: %v", err)
+				return
+			}
+			body = zr
+		}
+		data, _ := io.ReadAll(body)
+
+		w.Header().Set("Accept-Encoding", "zstd, gzip")
+		if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Header().Set("Content-Encoding", "gzip")
+			zw := gzip.NewWriter(w)
+			zw.Write(data)
+			zw.Close()
+			return
+		}
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(WithBaseURL(server.URL), WithContentEncoding(GzipCodec()))
+	ctx := context.Background()
+	large := map[string]string{"blob": strings.Repeat("x", 4096)}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(ctx, "/echo", large, nil)
+		if err != nil {
+			t.Fatalf("Post failed: %v", err)
+		}
+		if !strings.Contains(string(resp), strings.Repeat("x", 4096)) {
+			t.Errorf("Expected decompressed echo, got %d bytes", len(resp))
+		}
+	}
+
+	// The first request is sent plain; the server's Accept-Encoding enables
+	// gzip for the second.
+	if len(encodings) != 2 || encodings[0] != "" || encodings[1] != "gzip" {
+		t.Errorf("Unexpected request encodings: %v", encodings)
+	}
+}
@@ -0,0 +1,19 @@
+package kiket
+
+import "time"
+
+// Clock supplies the current time. The SDK uses it for signature
+// timestamps, telemetry, and processing budgets so tests can freeze time
+// (see kikettest.FakeClock).
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock backed by time.Now.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
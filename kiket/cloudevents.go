@@ -0,0 +1,113 @@
+package kiket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// CloudEventTypePrefix is stripped from CloudEvents types to get the Kiket
+// event name: "dev.kiket.issue.created" is handled as "issue.created".
+const CloudEventTypePrefix = "dev.kiket."
+
+// cloudEventsContentType marks a structured-mode CloudEvent.
+const cloudEventsContentType = "application/cloudevents+json"
+
+// versionSuffix matches a trailing event version in a CloudEvents type, as
+// in "dev.kiket.issue.created.v2".
+var versionSuffix = regexp.MustCompile(`\.(v[0-9]+)$`)
+
+// CloudEvent holds the context attributes of a delivery received in
+// CloudEvents 1.0 format, in either binary or structured mode.
+type CloudEvent struct {
+	SpecVersion     string `json:"specversion"`
+	ID              string `json:"id"`
+	Source          string `json:"source"`
+	Type            string `json:"type"`
+	Subject         string `json:"subject,omitempty"`
+	Time            string `json:"time,omitempty"`
+	DataContentType string `json:"datacontenttype,omitempty"`
+	DataSchema      string `json:"dataschema,omitempty"`
+}
+
+// Event returns the Kiket event name and version encoded in the type.
+func (ce *CloudEvent) Event() (event, version string) {
+	event = strings.TrimPrefix(ce.Type, CloudEventTypePrefix)
+	if m := versionSuffix.FindStringSubmatch(event); m != nil {
+		return strings.TrimSuffix(event, m[0]), m[1]
+	}
+	return event, ""
+}
+
+// parseCloudEvent detects a CloudEvents delivery and returns its attributes
+// and the Kiket payload it carries. It returns a nil event for native Kiket
+// deliveries.
+func parseCloudEvent(body []byte, headers Headers) (*CloudEvent, []byte, error) {
+	contentType := headerValue(headers, "Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaType == cloudEventsContentType {
+		// Structured mode: attributes and data share the JSON envelope.
+		var envelope struct {
+			CloudEvent
+			Data       json.RawMessage `json:"data"`
+			DataBase64 string          `json:"data_base64"`
+		}
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			return nil, nil, fmt.Errorf("invalid CloudEvent: %w", err)
+		}
+		if envelope.DataBase64 != "" {
+			return nil, nil, errors.New("invalid CloudEvent: data_base64 is not supported")
+		}
+		ce := envelope.CloudEvent
+		if err := ce.validate(); err != nil {
+			return nil, nil, err
+		}
+		return &ce, envelope.Data, nil
+	}
+
+	specVersion := headerValue(headers, "Ce-Specversion")
+	if specVersion == "" {
+		return nil, body, nil
+	}
+
+	// Binary mode: attributes travel as ce- headers, the body is the data.
+	ce := &CloudEvent{
+		SpecVersion:     specVersion,
+		ID:              headerValue(headers, "Ce-Id"),
+		Source:          headerValue(headers, "Ce-Source"),
+		Type:            headerValue(headers, "Ce-Type"),
+		Subject:         headerValue(headers, "Ce-Subject"),
+		Time:            headerValue(headers, "Ce-Time"),
+		DataContentType: contentType,
+		DataSchema:      headerValue(headers, "Ce-Dataschema"),
+	}
+	if err := ce.validate(); err != nil {
+		return nil, nil, err
+	}
+	return ce, body, nil
+}
+
+func (ce *CloudEvent) validate() error {
+	if !strings.HasPrefix(ce.SpecVersion, "1.") {
+		return fmt.Errorf("unsupported CloudEvents specversion %q", ce.SpecVersion)
+	}
+	if ce.ID == "" || ce.Type == "" || ce.Source == "" {
+		return errors.New("invalid CloudEvent: id, type, and source are required")
+	}
+	return nil
+}
+
+// headerValue looks name up as given, in canonical form, and in lower case,
+// since headers reach HandleWebhook from net/http and from other sources.
+func headerValue(headers Headers, name string) string {
+	if v, ok := headers[name]; ok {
+		return v
+	}
+	if v, ok := headers[http.CanonicalHeaderKey(name)]; ok {
+		return v
+	}
+	return headers[strings.ToLower(name)]
+}
@@ -0,0 +1,79 @@
+package kiket
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSDK_HandleWebhookCloudEvents(t *testing.T) {
+	sdk, err := New(Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	var got *HandlerContext
+	var title interface{}
+	handler := func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		got = hctx
+		title = payload["issue"].(map[string]interface{})["title"]
+		return nil, nil
+	}
+	sdk.On("issue.created", handler)
+	sdk.On("issue.created", handler, "v2")
+
+	t.Run("binary", func(t *testing.T) {
+		body := `{"issue":{"id":1,"title":"Bug"}}`
+		signature, timestamp := GenerateSignature("secret", body, nil)
+		headers := Headers{
+			"X-Kiket-Signature": signature,
+			"X-Kiket-Timestamp": timestamp,
+			"Content-Type":      "application/json",
+			"ce-specversion":    "1.0",
+			"ce-id":             "evt-1",
+			"ce-source":         "/workspaces/acme",
+			"ce-type":           "dev.kiket.issue.created",
+		}
+		if _, err := sdk.HandleWebhook(context.Background(), []byte(body), headers); err != nil {
+			t.Fatalf("HandleWebhook failed: %v", err)
+		}
+		if got.Event != "issue.created" || got.EventVersion != "v1" || got.DeliveryID != "evt-1" {
+			t.Errorf("Unexpected context %s %s %s", got.Event, got.EventVersion, got.DeliveryID)
+		}
+		if title != "Bug" {
+			t.Errorf("Expected Bug, got %v", title)
+		}
+	})
+
+	t.Run("structured", func(t *testing.T) {
+		body := `{"specversion":"1.0","id":"evt-2","source":"/workspaces/acme","type":"dev.kiket.issue.created.v2","data":{"issue":{"id":2,"title":"Crash"}}}`
+		signature, timestamp := GenerateSignature("secret", body, nil)
+		headers := Headers{
+			"X-Kiket-Signature": signature,
+			"X-Kiket-Timestamp": timestamp,
+			"Content-Type":      "application/cloudevents+json; charset=utf-8",
+		}
+		if _, err := sdk.HandleWebhook(context.Background(), []byte(body), headers); err != nil {
+			t.Fatalf("HandleWebhook failed: %v", err)
+		}
+		if got.EventVersion != "v2" || got.CloudEvent == nil || got.CloudEvent.Source != "/workspaces/acme" {
+			t.Errorf("Unexpected context %s %+v", got.EventVersion, got.CloudEvent)
+		}
+		if title != "Crash" {
+			t.Errorf("Expected Crash, got %v", title)
+		}
+	})
+
+	t.Run("missing attributes", func(t *testing.T) {
+		body := `{"issue":{"id":1}}`
+		signature, timestamp := GenerateSignature("secret", body, nil)
+		headers := Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp, "Ce-Specversion": "1.0"}
+		if _, err := sdk.HandleWebhook(context.Background(), []byte(body), headers); err == nil {
+			t.Error("Expected error for CloudEvent without id, type, and source")
+		}
+	})
+}
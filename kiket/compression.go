@@ -0,0 +1,134 @@
+package kiket
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// minCompressSize is the smallest request body worth compressing.
+const minCompressSize = 1024
+
+// ContentCodec implements one HTTP content encoding. The SDK ships gzip
+// here and zstd in package kiketzstd; other encodings plug in through this
+// interface.
+type ContentCodec interface {
+	// Encoding is the Content-Encoding token, e.g. "gzip" or "zstd".
+	Encoding() string
+	// NewWriter compresses into w.
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+	// NewReader decompresses r.
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+type gzipCodec struct{}
+
+// GzipCodec returns the gzip content codec.
+func GzipCodec() ContentCodec {
+	return gzipCodec{}
+}
+
+func (gzipCodec) Encoding() string { return "gzip" }
+
+func (gzipCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+}
+
+func (gzipCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// WithContentEncoding enables compression with codecs, most preferred
+// first. Responses may use any of them (they are listed in
+// Accept-Encoding). Request bodies of 1 KiB or more are compressed with
+// the first codec the server has advertised in an Accept-Encoding response
+// header; until then requests are sent uncompressed.
+func WithContentEncoding(codecs ...ContentCodec) ClientOption {
+	return func(c *HTTPClient) {
+		c.codecs = append(c.codecs, codecs...)
+	}
+}
+
+// acceptEncoding returns the Accept-Encoding header value for the
+// configured codecs.
+func (c *HTTPClient) acceptEncoding() string {
+	names := make([]string, len(c.codecs))
+	for i, codec := range c.codecs {
+		names[i] = codec.Encoding()
+	}
+	return strings.Join(names, ", ")
+}
+
+func (c *HTTPClient) codec(encoding string) ContentCodec {
+	for _, codec := range c.codecs {
+		if strings.EqualFold(codec.Encoding(), encoding) {
+			return codec
+		}
+	}
+	return nil
+}
+
+// requestCodec returns the codec to compress a body of size bytes with, or
+// nil to send it as is.
+func (c *HTTPClient) requestCodec(size int) ContentCodec {
+	if size < minCompressSize || len(c.codecs) == 0 {
+		return nil
+	}
+
+	c.encodingMu.RLock()
+	defer c.encodingMu.RUnlock()
+
+	for _, codec := range c.codecs {
+		if c.serverEncodings[strings.ToLower(codec.Encoding())] {
+			return codec
+		}
+	}
+	return nil
+}
+
+// learnEncodings records the encodings a server advertises for request
+// bodies.
+func (c *HTTPClient) learnEncodings(header http.Header) {
+	values := header.Values("Accept-Encoding")
+	if len(values) == 0 || len(c.codecs) == 0 {
+		return
+	}
+
+	advertised := make(map[string]bool)
+	for _, value := range values {
+		for _, token := range strings.Split(value, ",") {
+			token = strings.TrimSpace(strings.SplitN(token, ";", 2)[0])
+			if token != "" {
+				advertised[strings.ToLower(token)] = true
+			}
+		}
+	}
+
+	c.encodingMu.Lock()
+	c.serverEncodings = advertised
+	c.encodingMu.Unlock()
+}
+
+// forgetEncoding stops compressing requests with encoding, e.g. after the
+// server rejected it.
+func (c *HTTPClient) forgetEncoding(encoding string) {
+	c.encodingMu.Lock()
+	delete(c.serverEncodings, strings.ToLower(encoding))
+	c.encodingMu.Unlock()
+}
+
+// decodeResponse wraps body according to its Content-Encoding.
+func (c *HTTPClient) decodeResponse(resp *http.Response) (io.ReadCloser, error) {
+	encoding := strings.TrimSpace(resp.Header.Get("Content-Encoding"))
+	if encoding == "" || strings.EqualFold(encoding, "identity") {
+		return resp.Body, nil
+	}
+
+	codec := c.codec(encoding)
+	if codec == nil {
+		return nil, fmt.Errorf("unsupported response encoding %q", encoding)
+	}
+	return codec.NewReader(resp.Body)
+}
@@ -0,0 +1,300 @@
+package kiket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read by ApplyEnv.
+const (
+	EnvWebhookSecret     = "KIKET_WEBHOOK_SECRET"
+	EnvWorkspaceToken    = "KIKET_WORKSPACE_TOKEN"
+	EnvExtensionAPIKey   = "KIKET_EXTENSION_API_KEY"
+	EnvBaseURL           = "KIKET_BASE_URL"
+	EnvExtensionID       = "KIKET_EXTENSION_ID"
+	EnvExtensionVersion  = "KIKET_EXTENSION_VERSION"
+	EnvManifestPath      = "KIKET_MANIFEST_PATH"
+	EnvAutoEnvSecrets    = "KIKET_AUTO_ENV_SECRETS"
+	EnvStrictManifest    = "KIKET_STRICT_MANIFEST"
+	EnvTelemetryEnabled  = "KIKET_TELEMETRY_ENABLED"
+	EnvTelemetryURL      = "KIKET_TELEMETRY_URL"
+	EnvHeartbeatInterval = "KIKET_HEARTBEAT_INTERVAL"
+	EnvSettings          = "KIKET_SETTINGS"
+	EnvProfile           = "KIKET_ENV"
+	EnvConfigPath        = "KIKET_CONFIG_PATH"
+
+	EnvRemoteSettings     = "KIKET_REMOTE_SETTINGS"
+	EnvScopePreflight     = "KIKET_SCOPE_PREFLIGHT"
+	EnvSignResponses      = "KIKET_SIGN_RESPONSES"
+	EnvHealthInterval     = "KIKET_HEALTH_INTERVAL"
+	EnvSignatureTolerance = "KIKET_SIGNATURE_TOLERANCE"
+	EnvAsyncQueueSize     = "KIKET_ASYNC_QUEUE_SIZE"
+	EnvAsyncQueueBytes    = "KIKET_ASYNC_QUEUE_BYTES"
+	EnvAsyncWorkers       = "KIKET_ASYNC_WORKERS"
+	EnvAsyncRetryAfter    = "KIKET_ASYNC_RETRY_AFTER"
+	EnvEventBatchSize     = "KIKET_EVENT_BATCH_SIZE"
+	EnvEventFlushInterval = "KIKET_EVENT_FLUSH_INTERVAL"
+	EnvProcessingBudget   = "KIKET_PROCESSING_BUDGET"
+	EnvBudgetHeadroom     = "KIKET_BUDGET_HEADROOM"
+	EnvRateLimitThreshold = "KIKET_RATE_LIMIT_THRESHOLD"
+	EnvRateLimitHandling  = "KIKET_RATE_LIMIT_HANDLING"
+)
+
+// NewFromEnv creates an SDK configured entirely from KIKET_* environment
+// variables. See ApplyEnv for the variables consulted.
+func NewFromEnv() (*SDK, error) {
+	config, err := ApplyEnv(Config{})
+	if err != nil {
+		return nil, err
+	}
+	return New(config)
+}
+
+// ApplyEnv fills zero-valued Config fields from KIKET_* environment
+// variables, leaving fields that are already set untouched:
+//
+//	KIKET_WEBHOOK_SECRET        WebhookSecret
+//	KIKET_WORKSPACE_TOKEN       WorkspaceToken
+//	KIKET_EXTENSION_API_KEY     ExtensionAPIKey
+//	KIKET_BASE_URL              BaseURL
+//	KIKET_EXTENSION_ID          ExtensionID
+//	KIKET_EXTENSION_VERSION     ExtensionVersion
+//	KIKET_MANIFEST_PATH         ManifestPath
+//	KIKET_AUTO_ENV_SECRETS      AutoEnvSecrets (true/false)
+//	KIKET_STRICT_MANIFEST       StrictManifest (true/false)
+//	KIKET_REMOTE_SETTINGS       RemoteSettings (true/false)
+//	KIKET_TELEMETRY_ENABLED     TelemetryEnabled (true/false)
+//	KIKET_TELEMETRY_URL         TelemetryURL
+//	KIKET_HEARTBEAT_INTERVAL    HeartbeatInterval (e.g. "30s")
+//	KIKET_HEALTH_INTERVAL       HealthInterval
+//	KIKET_SIGNATURE_TOLERANCE   SignatureTolerance
+//	KIKET_ASYNC_QUEUE_SIZE      AsyncQueueSize
+//	KIKET_ASYNC_QUEUE_BYTES     AsyncQueueBytes
+//	KIKET_ASYNC_WORKERS         AsyncWorkers
+//	KIKET_ASYNC_RETRY_AFTER     AsyncRetryAfter
+//	KIKET_EVENT_BATCH_SIZE      EventBatchSize
+//	KIKET_EVENT_FLUSH_INTERVAL  EventFlushInterval
+//	KIKET_PROCESSING_BUDGET     ProcessingBudget
+//	KIKET_BUDGET_HEADROOM       BudgetHeadroom
+//	KIKET_SCOPE_PREFLIGHT       ScopePreflight (true/false)
+//	KIKET_RATE_LIMIT_THRESHOLD  RateLimitThreshold
+//	KIKET_RATE_LIMIT_HANDLING   RateLimitHandling (error, wait, or callback)
+//	KIKET_SIGN_RESPONSES        SignResponses (true/false)
+//	KIKET_SETTINGS              Settings (JSON object)
+//
+// Remaining fields are then filled from the profile named by KIKET_ENV (or
+// the file's default_profile) in kiket.config.yaml, or in the file named by
+// KIKET_CONFIG_PATH. Explicit fields win over environment variables, which
+// win over the profile.
+//
+// Boolean variables accept the values of strconv.ParseBool. A false value
+// keeps the profile from turning the flag on; a flag set to true in code
+// stays on.
+func ApplyEnv(config Config) (Config, error) {
+	setString := func(field *string, name string) {
+		if *field == "" {
+			*field = os.Getenv(name)
+		}
+	}
+
+	setString(&config.WebhookSecret, EnvWebhookSecret)
+	setString(&config.WorkspaceToken, EnvWorkspaceToken)
+	setString(&config.ExtensionAPIKey, EnvExtensionAPIKey)
+	setString(&config.BaseURL, EnvBaseURL)
+	setString(&config.ExtensionID, EnvExtensionID)
+	setString(&config.ExtensionVersion, EnvExtensionVersion)
+	setString(&config.ManifestPath, EnvManifestPath)
+	setString(&config.TelemetryURL, EnvTelemetryURL)
+
+	// Flags from the environment, reapplied after the profile
+	envFlags := make(map[*bool]bool)
+	for _, flag := range []struct {
+		name  string
+		field *bool
+	}{
+		{EnvAutoEnvSecrets, &config.AutoEnvSecrets},
+		{EnvStrictManifest, &config.StrictManifest},
+		{EnvRemoteSettings, &config.RemoteSettings},
+		{EnvTelemetryEnabled, &config.TelemetryEnabled},
+		{EnvScopePreflight, &config.ScopePreflight},
+		{EnvSignResponses, &config.SignResponses},
+	} {
+		value := os.Getenv(flag.name)
+		if value == "" || *flag.field {
+			continue
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return config, fmt.Errorf("invalid %s: %q is not a boolean", flag.name, value)
+		}
+		*flag.field = enabled
+		envFlags[flag.field] = enabled
+	}
+
+	for _, duration := range []struct {
+		name  string
+		field *time.Duration
+	}{
+		{EnvHeartbeatInterval, &config.HeartbeatInterval},
+		{EnvHealthInterval, &config.HealthInterval},
+		{EnvSignatureTolerance, &config.SignatureTolerance},
+		{EnvAsyncRetryAfter, &config.AsyncRetryAfter},
+		{EnvEventFlushInterval, &config.EventFlushInterval},
+		{EnvProcessingBudget, &config.ProcessingBudget},
+		{EnvBudgetHeadroom, &config.BudgetHeadroom},
+	} {
+		value := os.Getenv(duration.name)
+		if value == "" || *duration.field != 0 {
+			continue
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return config, fmt.Errorf("invalid %s: %w", duration.name, err)
+		}
+		*duration.field = d
+	}
+
+	for _, number := range []struct {
+		name  string
+		field *int
+	}{
+		{EnvAsyncQueueSize, &config.AsyncQueueSize},
+		{EnvAsyncWorkers, &config.AsyncWorkers},
+		{EnvEventBatchSize, &config.EventBatchSize},
+		{EnvRateLimitThreshold, &config.RateLimitThreshold},
+	} {
+		value := os.Getenv(number.name)
+		if value == "" || *number.field != 0 {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return config, fmt.Errorf("invalid %s: %q is not an integer", number.name, value)
+		}
+		*number.field = n
+	}
+
+	if value := os.Getenv(EnvAsyncQueueBytes); value != "" && config.AsyncQueueBytes == 0 {
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return config, fmt.Errorf("invalid %s: %q is not an integer", EnvAsyncQueueBytes, value)
+		}
+		config.AsyncQueueBytes = n
+	}
+
+	if value := os.Getenv(EnvRateLimitHandling); value != "" && config.RateLimitHandling == RateLimitModeError {
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "error":
+		case "wait":
+			config.RateLimitHandling = RateLimitModeWait
+		case "callback":
+			config.RateLimitHandling = RateLimitModeCallback
+		default:
+			return config, fmt.Errorf("invalid %s: %q (want error, wait, or callback)", EnvRateLimitHandling, value)
+		}
+	}
+
+	if value := os.Getenv(EnvSettings); value != "" && config.Settings == nil {
+		var settings Settings
+		if err := json.Unmarshal([]byte(value), &settings); err != nil {
+			return config, fmt.Errorf("invalid %s: %w", EnvSettings, err)
+		}
+		config.Settings = settings
+	}
+
+	config, err := applyEnvProfile(config)
+	if err != nil {
+		return config, err
+	}
+
+	// The profile can only turn flags on, so a false from the environment
+	// is restored over it
+	for field, enabled := range envFlags {
+		*field = enabled
+	}
+	return config, nil
+}
+
+// applyEnvProfile applies the profile selected by KIKET_ENV.
+func applyEnvProfile(config Config) (Config, error) {
+	name := os.Getenv(EnvProfile)
+
+	file, err := LoadConfigFile(os.Getenv(EnvConfigPath))
+	if err != nil {
+		return config, fmt.Errorf("failed to load config file: %w", err)
+	}
+	if file == nil {
+		if name != "" {
+			return config, fmt.Errorf("%s=%s but no %s was found", EnvProfile, name, DefaultConfigFile)
+		}
+		return config, nil
+	}
+
+	profile, err := file.Profile(name)
+	if err != nil {
+		return config, err
+	}
+	return ApplyProfile(config, profile)
+}
+
+// validateConfig checks the static configuration rules enforced by New.
+func validateConfig(config Config) []ValidationIssue {
+	var issues []ValidationIssue
+	add := func(severity ValidationSeverity, field, message string) {
+		issues = append(issues, ValidationIssue{Severity: severity, Field: field, Message: message})
+	}
+
+	if config.ExtensionAPIKey == "" && config.WorkspaceToken == "" && config.CredentialResolver == nil {
+		add(SeverityError, "ExtensionAPIKey", "no API credential configured; set ExtensionAPIKey, WorkspaceToken, or CredentialResolver")
+	} else if config.ExtensionAPIKey != "" && config.WorkspaceToken != "" {
+		add(SeverityWarning, "WorkspaceToken", "ignored because ExtensionAPIKey is set")
+	}
+
+	if msg := checkHTTPURL(config.BaseURL); msg != "" {
+		add(SeverityError, "BaseURL", msg)
+	}
+
+	if config.TelemetryURL != "" {
+		if msg := checkHTTPURL(config.TelemetryURL); msg != "" {
+			add(SeverityError, "TelemetryURL", msg)
+		}
+	} else if config.TelemetryEnabled && config.BaseURL != defaultBaseURL {
+		add(SeverityError, "TelemetryURL", "is required when TelemetryEnabled is set against a self-hosted BaseURL")
+	}
+
+	if config.HeartbeatInterval < 0 {
+		add(SeverityError, "HeartbeatInterval", "must not be negative")
+	}
+
+	if config.ProcessingBudget < 0 {
+		add(SeverityError, "ProcessingBudget", "must not be negative")
+	} else if config.ProcessingBudget > 0 && config.BudgetHeadroom >= config.ProcessingBudget {
+		add(SeverityError, "BudgetHeadroom", "must be shorter than ProcessingBudget")
+	}
+
+	if config.SignResponses && config.WebhookSecret == "" && config.CredentialResolver == nil {
+		add(SeverityWarning, "SignResponses", "has no effect without WebhookSecret or CredentialResolver")
+	}
+
+	if config.RateLimitHandling == RateLimitModeCallback && config.OnRateLimited == nil {
+		add(SeverityError, "OnRateLimited", "is required when RateLimitHandling is RateLimitModeCallback")
+	}
+
+	return issues
+}
+
+func checkHTTPURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Sprintf("%q is not a valid URL: %v", raw, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Sprintf("%q must be an absolute http(s) URL", raw)
+	}
+	return ""
+}
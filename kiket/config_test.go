@@ -0,0 +1,212 @@
+package kiket
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestApplyEnv_PopulatesUnsetFields(t *testing.T) {
+	t.Setenv(EnvWebhookSecret, "env-secret")
+	t.Setenv(EnvExtensionID, "com.example.env")
+	t.Setenv(EnvBaseURL, "https://kiket.internal")
+	t.Setenv(EnvTelemetryEnabled, "true")
+	t.Setenv(EnvHeartbeatInterval, "30s")
+	t.Setenv(EnvSettings, `{"limit": 10}`)
+
+	config, err := ApplyEnv(Config{ExtensionID: "com.example.code"})
+	if err != nil {
+		t.Fatalf("ApplyEnv failed: %v", err)
+	}
+
+	if config.WebhookSecret != "env-secret" {
+		t.Errorf("Expected env-secret, got %s", config.WebhookSecret)
+	}
+	if config.ExtensionID != "com.example.code" {
+		t.Errorf("Expected explicit ExtensionID to win, got %s", config.ExtensionID)
+	}
+	if config.BaseURL != "https://kiket.internal" {
+		t.Errorf("Expected base URL from env, got %s", config.BaseURL)
+	}
+	if !config.TelemetryEnabled {
+		t.Error("Expected telemetry to be enabled")
+	}
+	if config.HeartbeatInterval != 30*time.Second {
+		t.Errorf("Expected 30s heartbeat, got %v", config.HeartbeatInterval)
+	}
+	if config.Settings.GetInt("limit", 0) != 10 {
+		t.Errorf("Expected settings from env, got %v", config.Settings)
+	}
+}
+
+func TestApplyEnv_RejectsMalformedValues(t *testing.T) {
+	t.Setenv(EnvTelemetryEnabled, "sometimes")
+
+	if _, err := ApplyEnv(Config{}); err == nil {
+		t.Error("Expected error for malformed boolean")
+	}
+
+	t.Setenv(EnvTelemetryEnabled, "")
+	t.Setenv(EnvAsyncWorkers, "many")
+	if _, err := ApplyEnv(Config{}); err == nil {
+		t.Error("Expected error for malformed integer")
+	}
+
+	t.Setenv(EnvAsyncWorkers, "")
+	t.Setenv(EnvRateLimitHandling, "panic")
+	if _, err := ApplyEnv(Config{}); err == nil {
+		t.Error("Expected error for unknown rate limit handling")
+	}
+}
+
+func TestApplyEnv_PopulatesTuningFields(t *testing.T) {
+	t.Setenv(EnvSignResponses, "1")
+	t.Setenv(EnvScopePreflight, "true")
+	t.Setenv(EnvSignatureTolerance, "2m")
+	t.Setenv(EnvAsyncQueueSize, "500")
+	t.Setenv(EnvAsyncQueueBytes, "1048576")
+	t.Setenv(EnvAsyncWorkers, "8")
+	t.Setenv(EnvEventFlushInterval, "250ms")
+	t.Setenv(EnvProcessingBudget, "9s")
+	t.Setenv(EnvRateLimitThreshold, "20")
+	t.Setenv(EnvRateLimitHandling, "wait")
+
+	config, err := ApplyEnv(Config{AsyncWorkers: 2})
+	if err != nil {
+		t.Fatalf("ApplyEnv failed: %v", err)
+	}
+
+	if !config.SignResponses || !config.ScopePreflight {
+		t.Errorf("Expected flags from env, got %+v", config)
+	}
+	if config.SignatureTolerance != 2*time.Minute || config.EventFlushInterval != 250*time.Millisecond || config.ProcessingBudget != 9*time.Second {
+		t.Errorf("Expected durations from env, got %+v", config)
+	}
+	if config.AsyncQueueSize != 500 || config.AsyncQueueBytes != 1<<20 || config.RateLimitThreshold != 20 {
+		t.Errorf("Expected sizes from env, got %+v", config)
+	}
+	if config.AsyncWorkers != 2 {
+		t.Errorf("Expected explicit AsyncWorkers to win, got %d", config.AsyncWorkers)
+	}
+	if config.RateLimitHandling != RateLimitModeWait {
+		t.Errorf("Expected RateLimitModeWait, got %v", config.RateLimitHandling)
+	}
+}
+
+func TestApplyEnv_FalseFlagWinsOverProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	content := `
+profiles:
+  prod:
+    secrets: api
+    telemetry_enabled: true
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Setenv(EnvConfigPath, path)
+	t.Setenv(EnvProfile, "prod")
+	t.Setenv(EnvTelemetryEnabled, "false")
+	t.Setenv(EnvRemoteSettings, "0")
+
+	config, err := ApplyEnv(Config{})
+	if err != nil {
+		t.Fatalf("ApplyEnv failed: %v", err)
+	}
+	if config.TelemetryEnabled || config.RemoteSettings {
+		t.Errorf("Expected false env flags to win over the profile, got %+v", config)
+	}
+}
+
+func TestNew_FailsFastOnInvalidConfig(t *testing.T) {
+	_, err := New(Config{
+		ExtensionID:      "com.example.ext",
+		WebhookSecret:    "secret",
+		BaseURL:          "https://kiket.example.com",
+		TelemetryEnabled: true,
+	})
+
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+
+	fields := make(map[string]bool)
+	for _, issue := range validationErr.Issues {
+		fields[issue.Field] = true
+	}
+	if !fields["ExtensionAPIKey"] || !fields["TelemetryURL"] {
+		t.Errorf("Expected credential and telemetry URL issues, got %v", validationErr.Issues)
+	}
+}
+
+func TestSDK_ValidateRequiresSecretWhenHandlersRegistered(t *testing.T) {
+	sdk, err := New(Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	if err := sdk.Validate(); err != nil {
+		t.Errorf("Expected no error without handlers, got %v", err)
+	}
+
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		return nil, nil
+	})
+	if err := sdk.Validate(); err == nil {
+		t.Error("Expected error for handlers without a webhook secret")
+	}
+}
+
+func TestApplyEnv_SelectsProfileFromKiketEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	content := `
+default_profile: dev
+profiles:
+  dev:
+    base_url: http://localhost:3000
+    secrets: env
+  prod:
+    base_url: https://kiket.dev
+    secrets: api
+    telemetry_enabled: true
+    heartbeat_interval: 1m
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Setenv(EnvConfigPath, path)
+	t.Setenv(EnvProfile, "prod")
+
+	config, err := ApplyEnv(Config{})
+	if err != nil {
+		t.Fatalf("ApplyEnv failed: %v", err)
+	}
+	if config.BaseURL != "https://kiket.dev" || !config.RemoteSettings || !config.TelemetryEnabled {
+		t.Errorf("Expected prod profile to apply, got %+v", config)
+	}
+	if config.HeartbeatInterval != time.Minute {
+		t.Errorf("Expected 1m heartbeat, got %v", config.HeartbeatInterval)
+	}
+
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvBaseURL, "https://kiket.internal")
+	config, err = ApplyEnv(Config{})
+	if err != nil {
+		t.Fatalf("ApplyEnv failed: %v", err)
+	}
+	if config.BaseURL != "https://kiket.internal" || !config.AutoEnvSecrets {
+		t.Errorf("Expected env var over default profile, got %+v", config)
+	}
+
+	t.Setenv(EnvProfile, "qa")
+	if _, err := ApplyEnv(Config{}); err == nil {
+		t.Error("Expected error for unknown profile")
+	}
+}
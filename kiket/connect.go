@@ -0,0 +1,201 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultConnectRetry = time.Second
+	maxConnectRetry     = time.Minute
+	// connectDrainTimeout bounds how long in-flight deliveries may take to
+	// reply once ctx is done.
+	connectDrainTimeout = 5 * time.Second
+)
+
+// socketMessage is the envelope exchanged over the extension WebSocket.
+// Kiket sends "delivery" messages carrying a signed webhook; the extension
+// answers each with a "response" carrying the same ID.
+type socketMessage struct {
+	Type    string          `json:"type"`
+	ID      string          `json:"id,omitempty"`
+	Headers Headers         `json:"headers,omitempty"`
+	Body    json.RawMessage `json:"body,omitempty"`
+	Status  int             `json:"status,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// Connect dials out to Kiket over a WebSocket and serves webhook deliveries
+// received on it, replying inline. Extensions behind a firewall can use it
+// instead of exposing a public webhook URL. Deliveries are signature-checked
+// exactly like HTTP ones.
+//
+// Connect reconnects with backoff when the connection drops and returns
+// when ctx is done, after in-flight handlers have replied (waiting at most
+// five seconds). It returns an error without retrying if Kiket rejects the
+// credentials.
+func (s *SDK) Connect(ctx context.Context) error {
+	if s.config.ExtensionID == "" {
+		return errors.New("extension ID required for connect mode")
+	}
+
+	socketURL := strings.TrimSuffix(s.config.BaseURL, "/") + extensionPath(s.config.ExtensionID) + "/connect"
+	return s.serveSocketURL(ctx, socketURL, s.handleSocketDelivery)
+}
+
+// socketHandler answers one delivery received over a WebSocket.
+type socketHandler func(ctx context.Context, msg socketMessage) socketMessage
+
+// serveSocketURL dials socketURL and serves deliveries with handle,
+// reconnecting with backoff until ctx is done or the credentials are
+// rejected.
+func (s *SDK) serveSocketURL(ctx context.Context, socketURL string, handle socketHandler) error {
+	backoff := defaultConnectRetry
+	for {
+		header, err := s.socketHeaders(ctx)
+		if err != nil {
+			return err
+		}
+		conn, err := dialWebsocket(ctx, socketURL, header)
+		if err == nil {
+			backoff = defaultConnectRetry
+			err = s.serveSocket(ctx, conn, handle)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+			return err
+		}
+		s.config.Logger.Printf("kiket: connection lost, reconnecting in %s: %v", backoff, err)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+		if backoff *= 2; backoff > maxConnectRetry {
+			backoff = maxConnectRetry
+		}
+	}
+}
+
+// socketHeaders returns the headers to dial with: the same authentication
+// the HTTP client sends, resolved afresh for each connection attempt.
+func (s *SDK) socketHeaders(ctx context.Context) (http.Header, error) {
+	header := http.Header{}
+	if client, ok := s.client.(*HTTPClient); ok {
+		if err := client.setHeaders(ctx, header, nil); err != nil {
+			return nil, err
+		}
+	} else if s.config.ExtensionAPIKey != "" {
+		header.Set(apiKeyHeader, s.config.ExtensionAPIKey)
+	}
+	return header, nil
+}
+
+// serveSocket handles deliveries on conn until it fails or ctx is done.
+// Once ctx is done no new deliveries are started, and the connection stays
+// open for up to connectDrainTimeout so in-flight handlers can reply.
+func (s *SDK) serveSocket(ctx context.Context, conn *wsConn, handle socketHandler) error {
+	var inflight sync.WaitGroup
+	defer inflight.Wait()
+
+	// Handlers keep running while draining; they are cancelled when the
+	// connection closes.
+	handlerCtx, cancelHandlers := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelHandlers()
+
+	var mu sync.Mutex
+	draining := false
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			mu.Lock()
+			draining = true
+			mu.Unlock()
+
+			drained := make(chan struct{})
+			go func() {
+				inflight.Wait()
+				close(drained)
+			}()
+			timer := time.NewTimer(connectDrainTimeout)
+			select {
+			case <-drained:
+			case <-timer.C:
+			}
+			timer.Stop()
+		case <-done:
+		}
+		cancelHandlers()
+		conn.Close()
+	}()
+
+	for {
+		data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		var msg socketMessage
+		if err := json.Unmarshal(data, &msg); err != nil || msg.Type != "delivery" {
+			continue
+		}
+
+		mu.Lock()
+		if draining {
+			// Left unanswered; Kiket redelivers it.
+			mu.Unlock()
+			continue
+		}
+		inflight.Add(1)
+		mu.Unlock()
+
+		go func() {
+			defer inflight.Done()
+			reply := handle(handlerCtx, msg)
+			if out, err := json.Marshal(reply); err == nil {
+				conn.WriteMessage(out)
+			}
+		}()
+	}
+}
+
+// handleSocketDelivery runs one delivery and builds the reply, using the
+// status codes ServeHTTP would have answered with.
+func (s *SDK) handleSocketDelivery(ctx context.Context, msg socketMessage) socketMessage {
+	reply := socketMessage{Type: "response", ID: msg.ID, Status: http.StatusOK}
+
+	result, err := s.HandleWebhook(ctx, msg.Body, msg.Headers)
+	if err != nil {
+		reply.Status = s.errorStatus(err)
+		reply.Error = err.Error()
+		return reply
+	}
+
+	reply.Body = json.RawMessage("{}")
+	if result != nil {
+		body, err := json.Marshal(result)
+		if err != nil {
+			reply.Status = http.StatusInternalServerError
+			reply.Error = err.Error()
+			reply.Body = nil
+			return reply
+		}
+		reply.Body = body
+	}
+	return reply
+}
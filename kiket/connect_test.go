@@ -0,0 +1,190 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// acceptWebsocket upgrades r on the server side of a test.
+func acceptWebsocket(t *testing.T, w http.ResponseWriter, r *http.Request) *wsConn {
+	t.Helper()
+	conn, rw, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		t.Fatalf("Hijack failed: %v", err)
+	}
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	rw.WriteString("Sec-WebSocket-Accept: " + websocketAccept(r.Header.Get("Sec-WebSocket-Key")) + "\r\n\r\n")
+	rw.Flush()
+	return newWSConn(conn, rw.Reader, false)
+}
+
+func TestSDK_ConnectServesDeliveriesOverWebsocket(t *testing.T) {
+	replies := make(chan socketMessage, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/extensions/com.example.ext/connect" || r.Header.Get(apiKeyHeader) != "key" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		conn := acceptWebsocket(t, w, r)
+		defer conn.Close()
+
+		body := `{"event":"issue.created","issue":{"id":1,"title":"Bug"}}`
+		signature, timestamp := GenerateSignature("secret", body, nil)
+		for _, msg := range []socketMessage{
+			{Type: "delivery", ID: "d1", Body: json.RawMessage(body), Headers: Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp}},
+			{Type: "delivery", ID: "d2", Body: json.RawMessage(body), Headers: Headers{"X-Kiket-Signature": "bad", "X-Kiket-Timestamp": timestamp}},
+		} {
+			out, _ := json.Marshal(msg)
+			conn.WriteMessage(out)
+		}
+		for i := 0; i < 2; i++ {
+			data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var reply socketMessage
+			json.Unmarshal(data, &reply)
+			replies <- reply
+		}
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	sdk, err := New(Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+		BaseURL:         server.URL,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		return map[string]string{"status": "triaged"}, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- sdk.Connect(ctx) }()
+
+	got := map[string]socketMessage{}
+	for i := 0; i < 2; i++ {
+		reply := <-replies
+		got[reply.ID] = reply
+	}
+	if got["d1"].Status != http.StatusOK || string(got["d1"].Body) != `{"status":"triaged"}` {
+		t.Errorf("Unexpected reply for d1: %+v", got["d1"])
+	}
+	if got["d2"].Status != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for bad signature, got %+v", got["d2"])
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Expected Connect to return nil after cancel, got %v", err)
+	}
+}
+
+func TestSDK_ConnectStopsOnRejectedCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	sdk, err := New(Config{ExtensionID: "com.example.ext", ExtensionAPIKey: "wrong", WebhookSecret: "secret", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := sdk.Connect(ctx); err == nil {
+		t.Error("Expected error for rejected credentials")
+	}
+}
+
+func TestSDK_ConnectAuthenticatesWithWorkspaceToken(t *testing.T) {
+	var auth, version string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, version = r.Header.Get("Authorization"), r.Header.Get(sdkVersionHeader)
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	sdk, err := New(Config{ExtensionID: "com.example.ext", WorkspaceToken: "ws-token", WebhookSecret: "secret", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	sdk.Connect(ctx)
+	if auth != "Bearer ws-token" || version == "" {
+		t.Errorf("Expected the workspace token and SDK version when dialing, got %q and %q", auth, version)
+	}
+}
+
+func TestSDK_ConnectRepliesToInFlightDeliveriesOnShutdown(t *testing.T) {
+	replies := make(chan socketMessage, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn := acceptWebsocket(t, w, r)
+		defer conn.Close()
+
+		body := `{"event":"issue.created","issue":{"id":1}}`
+		signature, timestamp := GenerateSignature("secret", body, nil)
+		out, _ := json.Marshal(socketMessage{Type: "delivery", ID: "d1", Body: json.RawMessage(body), Headers: Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp}})
+		conn.WriteMessage(out)
+
+		data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var reply socketMessage
+		json.Unmarshal(data, &reply)
+		replies <- reply
+	}))
+	defer server.Close()
+
+	sdk, err := New(Config{ExtensionID: "com.example.ext", ExtensionAPIKey: "key", WebhookSecret: "secret", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		close(started)
+		<-release
+		return map[string]string{"status": "done"}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- sdk.Connect(ctx) }()
+
+	<-started
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	select {
+	case reply := <-replies:
+		if reply.ID != "d1" || reply.Status != http.StatusOK {
+			t.Errorf("Unexpected reply %+v", reply)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the in-flight delivery to be acknowledged")
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Expected Connect to return nil after cancel, got %v", err)
+	}
+}
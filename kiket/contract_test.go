@@ -0,0 +1,129 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Contract tests run read-only calls against a real sandbox workspace and
+// check that responses still decode into the SDK types. They are skipped
+// unless KIKET_CONTRACT_TESTS=1; credentials come from the usual KIKET_*
+// variables, plus KIKET_CONTRACT_PROJECT_ID for project-scoped calls:
+//
+//	KIKET_CONTRACT_TESTS=1 KIKET_BASE_URL=... KIKET_EXTENSION_API_KEY=... \
+//	KIKET_EXTENSION_ID=... KIKET_CONTRACT_PROJECT_ID=... go test -run Contract ./kiket
+const (
+	envContractTests     = "KIKET_CONTRACT_TESTS"
+	envContractProjectID = "KIKET_CONTRACT_PROJECT_ID"
+)
+
+type contractCase struct {
+	name   string
+	path   string
+	params map[string]string
+	key    string       // top-level key holding the resource, e.g. "data"
+	model  reflect.Type // element type for lists
+	list   bool
+}
+
+func TestContract_ReadOnlyEndpoints(t *testing.T) {
+	if os.Getenv(envContractTests) != "1" {
+		t.Skipf("set %s=1 to run contract tests against a live workspace", envContractTests)
+	}
+
+	config, err := ApplyEnv(Config{})
+	if err != nil {
+		t.Fatalf("ApplyEnv failed: %v", err)
+	}
+	projectID := os.Getenv(envContractProjectID)
+	if config.BaseURL == "" || config.ExtensionAPIKey == "" || config.ExtensionID == "" || projectID == "" {
+		t.Fatalf("contract tests need KIKET_BASE_URL, KIKET_EXTENSION_API_KEY, KIKET_EXTENSION_ID, and %s", envContractProjectID)
+	}
+
+	client := NewHTTPClient(WithBaseURL(config.BaseURL), WithAPIKey(config.ExtensionAPIKey))
+	defer client.Close()
+
+	project := map[string]string{"project_id": projectID, "limit": "5"}
+	cases := []contractCase{
+		{name: "workspace", path: apiPrefix + "/ext/workspace", key: "data", model: reflect.TypeOf(Workspace{})},
+		{name: "rate limit", path: apiPrefix + "/ext/rate_limit", key: "rate_limit", model: reflect.TypeOf(RateLimitInfo{})},
+		{name: "issues", path: issuesPath, params: project, key: "data", model: reflect.TypeOf(Issue{}), list: true},
+		{name: "saved filters", path: filtersPath, params: map[string]string{"project_id": projectID}, key: "data", model: reflect.TypeOf(SavedFilter{}), list: true},
+		{name: "sla events", path: slaPath, params: project, key: "data", model: reflect.TypeOf(SLAEventRecord{}), list: true},
+		{name: "secrets", path: fmt.Sprintf("%s/extensions/%s/secrets", apiPrefix, config.ExtensionID), key: "keys", model: reflect.TypeOf(""), list: true},
+		{name: "settings", path: fmt.Sprintf("%s/extensions/%s/settings", apiPrefix, config.ExtensionID), key: "settings", model: reflect.TypeOf(Settings{})},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+
+			resp, err := client.Get(ctx, tc.path, &RequestOptions{Params: tc.params})
+			if err != nil {
+				t.Fatalf("GET %s failed: %v", tc.path, err)
+			}
+
+			var envelope map[string]json.RawMessage
+			if err := json.Unmarshal(resp, &envelope); err != nil {
+				t.Fatalf("response is not a JSON object: %v", err)
+			}
+			raw, ok := envelope[tc.key]
+			if !ok {
+				t.Fatalf("response has no %q key: %s", tc.key, resp)
+			}
+
+			if !tc.list {
+				checkContractShape(t, tc.key, raw, tc.model)
+				return
+			}
+			var items []json.RawMessage
+			if err := json.Unmarshal(raw, &items); err != nil {
+				t.Fatalf("%s is not an array: %v", tc.key, err)
+			}
+			if len(items) == 0 {
+				t.Logf("%s is empty; element shape not checked", tc.key)
+			}
+			for i, item := range items {
+				checkContractShape(t, fmt.Sprintf("%s[%d]", tc.key, i), item, tc.model)
+			}
+		})
+	}
+}
+
+// checkContractShape decodes raw into model and, for structs, requires every
+// field whose JSON tag lacks omitempty to be present.
+func checkContractShape(t *testing.T, name string, raw json.RawMessage, model reflect.Type) {
+	t.Helper()
+
+	if err := json.Unmarshal(raw, reflect.New(model).Interface()); err != nil {
+		t.Errorf("%s does not decode into %s: %v", name, model, err)
+		return
+	}
+	if model.Kind() != reflect.Struct {
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		t.Errorf("%s is not an object: %v", name, err)
+		return
+	}
+	for i := 0; i < model.NumField(); i++ {
+		tag := model.Field(i).Tag.Get("json")
+		if tag == "" || tag == "-" || strings.Contains(tag, ",omitempty") {
+			continue
+		}
+		key := strings.Split(tag, ",")[0]
+		if _, ok := fields[key]; !ok {
+			t.Errorf("%s is missing required field %q of %s", name, key, model)
+		}
+	}
+}
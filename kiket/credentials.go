@@ -0,0 +1,113 @@
+package kiket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Credentials are the secrets of one workspace's installation.
+type Credentials struct {
+	// Extension API key for /api/v1/ext endpoints
+	ExtensionAPIKey string
+	// Workspace token, used when ExtensionAPIKey is empty
+	WorkspaceToken string
+	// Webhook HMAC secret for the workspace's deliveries
+	WebhookSecret string
+}
+
+// CredentialResolver returns the credentials of a workspace, for example
+// from a database or vault. Empty fields fall back to the static Config
+// values.
+type CredentialResolver func(ctx context.Context, workspaceID string) (Credentials, error)
+
+type workspaceContextKey struct{}
+
+// ContextWithWorkspace returns a context whose API calls are authenticated
+// with the workspace's resolved credentials (see Config.CredentialResolver).
+// Handler contexts carry the delivering workspace already.
+func ContextWithWorkspace(ctx context.Context, workspaceID string) context.Context {
+	return context.WithValue(ctx, workspaceContextKey{}, workspaceID)
+}
+
+// WorkspaceFromContext returns the workspace set by ContextWithWorkspace, or
+// "" when there is none.
+func WorkspaceFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(workspaceContextKey{}).(string)
+	return id
+}
+
+// WithCredentialResolver authenticates requests whose context carries a
+// workspace (see ContextWithWorkspace) with the credentials resolver returns
+// for it. Other requests use the static API key or token.
+func WithCredentialResolver(resolver CredentialResolver) ClientOption {
+	return func(c *HTTPClient) {
+		c.credentials = resolver
+	}
+}
+
+// CacheCredentials wraps resolver so each workspace's credentials are
+// resolved at most once per ttl. Errors are not cached.
+func CacheCredentials(resolver CredentialResolver, ttl time.Duration) CredentialResolver {
+	type entry struct {
+		credentials Credentials
+		expires     time.Time
+	}
+	var (
+		mu    sync.Mutex
+		cache = make(map[string]entry)
+	)
+	return func(ctx context.Context, workspaceID string) (Credentials, error) {
+		mu.Lock()
+		cached, ok := cache[workspaceID]
+		mu.Unlock()
+		if ok && time.Now().Before(cached.expires) {
+			return cached.credentials, nil
+		}
+
+		credentials, err := resolver(ctx, workspaceID)
+		if err != nil {
+			return Credentials{}, err
+		}
+		mu.Lock()
+		cache[workspaceID] = entry{credentials: credentials, expires: time.Now().Add(ttl)}
+		mu.Unlock()
+		return credentials, nil
+	}
+}
+
+// resolveCredentials returns the credentials for workspaceID, or zero
+// Credentials when there is no resolver or workspace.
+func resolveCredentials(ctx context.Context, resolver CredentialResolver, workspaceID string) (Credentials, error) {
+	if resolver == nil || workspaceID == "" {
+		return Credentials{}, nil
+	}
+	credentials, err := resolver(ctx, workspaceID)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to resolve credentials for workspace %s: %w", workspaceID, err)
+	}
+	return credentials, nil
+}
+
+// setAuthHeaders applies the resolved credentials for the request's
+// workspace, falling back to the client's static ones.
+func (c *HTTPClient) setAuthHeaders(ctx context.Context, header http.Header) error {
+	token, apiKey := c.token, c.apiKey
+	credentials, err := resolveCredentials(ctx, c.credentials, WorkspaceFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	if credentials.ExtensionAPIKey != "" || credentials.WorkspaceToken != "" {
+		token, apiKey = credentials.WorkspaceToken, credentials.ExtensionAPIKey
+	}
+
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	if apiKey != "" {
+		header.Set(apiKeyHeader, apiKey)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSDK_CredentialResolver(t *testing.T) {
+	var gotKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get(apiKeyHeader)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	lookups := 0
+	resolver := func(ctx context.Context, workspaceID string) (Credentials, error) {
+		lookups++
+		if workspaceID != "acme" {
+			return Credentials{}, errors.New("not installed")
+		}
+		return Credentials{ExtensionAPIKey: "acme-key", WebhookSecret: "acme-secret"}, nil
+	}
+	sdk, err := New(Config{
+		ExtensionID:        "com.example.ext",
+		BaseURL:            server.URL,
+		CredentialResolver: CacheCredentials(resolver, time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		_, err := hctx.Client.Get(ctx, "/api/v1/ext/ping", nil)
+		return nil, err
+	})
+
+	deliver := func(workspace, secret string) int {
+		body := `{"event":"issue.created","issue":{"id":1}}`
+		signature, timestamp := GenerateSignature(secret, body, nil)
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+		req.Header.Set("X-Kiket-Signature", signature)
+		req.Header.Set("X-Kiket-Timestamp", timestamp)
+		req.Header.Set(WorkspaceIDHeader, workspace)
+		rec := httptest.NewRecorder()
+		sdk.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := deliver("acme", "acme-secret"); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if gotKey != "acme-key" {
+		t.Errorf("Expected resolved API key, got %q", gotKey)
+	}
+	if lookups != 1 {
+		t.Errorf("Expected 1 cached lookup, got %d", lookups)
+	}
+	if code := deliver("acme", "other-secret"); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for wrong secret, got %d", code)
+	}
+	if code := deliver("globex", "acme-secret"); code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 when resolution fails, got %d", code)
+	}
+
+	ctx := ContextWithWorkspace(context.Background(), "globex")
+	if _, err := sdk.Client().Get(ctx, "/api/v1/ext/ping", nil); err == nil || !strings.Contains(err.Error(), "workspace globex") {
+		t.Errorf("Expected resolution error, got %v", err)
+	}
+}
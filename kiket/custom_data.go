@@ -5,10 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/url"
 	"strconv"
 )
 
+const customDataPath = apiPrefix + "/ext/custom_data"
+
 // customDataClient implements the CustomDataClient interface.
 type customDataClient struct {
 	client    Client
@@ -24,18 +25,13 @@ func NewCustomDataClient(client Client, projectID interface{}) CustomDataClient
 }
 
 func (c *customDataClient) buildPath(moduleKey, table string, recordID interface{}) string {
-	base := fmt.Sprintf("%s/ext/custom_data/%s/%s",
-		apiPrefix,
-		url.PathEscape(moduleKey),
-		url.PathEscape(table))
-
 	if recordID != nil {
-		return fmt.Sprintf("%s/%v", base, recordID)
+		return joinPath(customDataPath, moduleKey, table, recordID)
 	}
-	return base
+	return joinPath(customDataPath, moduleKey, table)
 }
 
-func (c *customDataClient) buildParams(limit int, filters map[string]interface{}) map[string]string {
+func (c *customDataClient) buildParams(limit, page int, filters map[string]interface{}) map[string]string {
 	params := map[string]string{
 		"project_id": fmt.Sprintf("%v", c.projectID),
 	}
@@ -43,6 +39,9 @@ func (c *customDataClient) buildParams(limit int, filters map[string]interface{}
 	if limit > 0 {
 		params["limit"] = strconv.Itoa(limit)
 	}
+	if page > 0 {
+		params["page"] = strconv.Itoa(page)
+	}
 
 	if filters != nil && len(filters) > 0 {
 		filtersJSON, _ := json.Marshal(filters)
@@ -57,16 +56,17 @@ func (c *customDataClient) List(ctx context.Context, moduleKey, table string, op
 		return nil, errors.New("project_id is required for custom data operations")
 	}
 
-	var limit int
+	var limit, page int
 	var filters map[string]interface{}
 	if opts != nil {
 		limit = opts.Limit
+		page = opts.Page
 		filters = opts.Filters
 	}
 
 	path := c.buildPath(moduleKey, table, nil)
 	resp, err := c.client.Get(ctx, path, &RequestOptions{
-		Params: c.buildParams(limit, filters),
+		Params: c.buildParams(limit, page, filters),
 	})
 	if err != nil {
 		return nil, err
@@ -87,7 +87,7 @@ func (c *customDataClient) Get(ctx context.Context, moduleKey, table string, rec
 
 	path := c.buildPath(moduleKey, table, recordID)
 	resp, err := c.client.Get(ctx, path, &RequestOptions{
-		Params: c.buildParams(0, nil),
+		Params: c.buildParams(0, 0, nil),
 	})
 	if err != nil {
 		return nil, err
@@ -108,7 +108,7 @@ func (c *customDataClient) Create(ctx context.Context, moduleKey, table string,
 
 	path := c.buildPath(moduleKey, table, nil)
 	resp, err := c.client.Post(ctx, path, map[string]interface{}{"record": record}, &RequestOptions{
-		Params: c.buildParams(0, nil),
+		Params: c.buildParams(0, 0, nil),
 	})
 	if err != nil {
 		return nil, err
@@ -129,7 +129,7 @@ func (c *customDataClient) Update(ctx context.Context, moduleKey, table string,
 
 	path := c.buildPath(moduleKey, table, recordID)
 	resp, err := c.client.Patch(ctx, path, map[string]interface{}{"record": record}, &RequestOptions{
-		Params: c.buildParams(0, nil),
+		Params: c.buildParams(0, 0, nil),
 	})
 	if err != nil {
 		return nil, err
@@ -150,7 +150,142 @@ func (c *customDataClient) Delete(ctx context.Context, moduleKey, table string,
 
 	path := c.buildPath(moduleKey, table, recordID)
 	_, err := c.client.Delete(ctx, path, &RequestOptions{
-		Params: c.buildParams(0, nil),
+		Params: c.buildParams(0, 0, nil),
 	})
 	return err
 }
+
+func (c *customDataClient) CreateWithPermissions(ctx context.Context, moduleKey, table string, record map[string]interface{}, permissions CustomDataPermissions) (*CustomDataRecordResponse, error) {
+	if c.projectID == nil || c.projectID == "" {
+		return nil, errors.New("project_id is required for custom data operations")
+	}
+	if err := ValidateCustomDataPermissions(permissions); err != nil {
+		return nil, err
+	}
+
+	path := c.buildPath(moduleKey, table, nil)
+	body := map[string]interface{}{"record": record, "permissions": permissions}
+	resp, err := c.client.Post(ctx, path, body, &RequestOptions{
+		Params: c.buildParams(0, 0, nil),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result CustomDataRecordResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
+
+func (c *customDataClient) TablePermissions(ctx context.Context, moduleKey, table string) (*CustomDataPermissions, error) {
+	return c.getPermissions(ctx, joinPath(c.buildPath(moduleKey, table, nil), "permissions"))
+}
+
+func (c *customDataClient) RecordPermissions(ctx context.Context, moduleKey, table string, recordID interface{}) (*CustomDataPermissions, error) {
+	if recordID == nil || recordID == "" {
+		return nil, errors.New("recordID is required for record permissions")
+	}
+	return c.getPermissions(ctx, joinPath(c.buildPath(moduleKey, table, recordID), "permissions"))
+}
+
+func (c *customDataClient) SetRecordPermissions(ctx context.Context, moduleKey, table string, recordID interface{}, permissions CustomDataPermissions) (*CustomDataPermissions, error) {
+	if c.projectID == nil || c.projectID == "" {
+		return nil, errors.New("project_id is required for custom data operations")
+	}
+	if recordID == nil || recordID == "" {
+		return nil, errors.New("recordID is required for record permissions")
+	}
+	if err := ValidateCustomDataPermissions(permissions); err != nil {
+		return nil, err
+	}
+
+	path := joinPath(c.buildPath(moduleKey, table, recordID), "permissions")
+	resp, err := c.client.Put(ctx, path, map[string]interface{}{"permissions": permissions}, &RequestOptions{
+		Params: c.buildParams(0, 0, nil),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseCustomDataPermissions(resp)
+}
+
+func (c *customDataClient) getPermissions(ctx context.Context, path string) (*CustomDataPermissions, error) {
+	if c.projectID == nil || c.projectID == "" {
+		return nil, errors.New("project_id is required for custom data operations")
+	}
+
+	resp, err := c.client.Get(ctx, path, &RequestOptions{
+		Params: c.buildParams(0, 0, nil),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseCustomDataPermissions(resp)
+}
+
+func parseCustomDataPermissions(resp []byte) (*CustomDataPermissions, error) {
+	result, err := decodeData[CustomDataPermissions](resp)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ValidateCustomDataPermissions checks that permissions name a known
+// visibility and that restricted visibility lists who can read.
+func ValidateCustomDataPermissions(permissions CustomDataPermissions) error {
+	switch permissions.Visibility {
+	case CustomDataVisibilityExtension, CustomDataVisibilityProject, CustomDataVisibilityWorkspace:
+		return nil
+	case CustomDataVisibilityRestricted:
+		if len(permissions.ReadRoles) == 0 && len(permissions.ReadUserIDs) == 0 {
+			return errors.New("restricted custom data permissions need read roles or users")
+		}
+		return nil
+	case "":
+		return errors.New("custom data permissions need a visibility")
+	default:
+		return fmt.Errorf("unknown custom data visibility %q", permissions.Visibility)
+	}
+}
+
+// defaultCustomDataPageSize is the page size IterateCustomData uses when
+// opts.Limit is unset.
+const defaultCustomDataPageSize = 100
+
+// IterateCustomData iterates over every record of a table matching
+// opts.Filters, fetching opts.Limit records per page (default 100). A page
+// shorter than the page size ends the iteration.
+func IterateCustomData(ctx context.Context, client CustomDataClient, moduleKey, table string, opts *CustomDataListOptions, iterOpts ...IteratorOption) *Iterator[map[string]interface{}] {
+	pageOpts := CustomDataListOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	if pageOpts.Limit <= 0 {
+		pageOpts.Limit = defaultCustomDataPageSize
+	}
+
+	fetch := func(ctx context.Context, token string) ([]map[string]interface{}, string, error) {
+		page := pageOpts
+		if token != "" {
+			page.Page = pageNumber(token)
+		} else if page.Page <= 0 {
+			page.Page = 1
+		}
+
+		result, err := client.List(ctx, moduleKey, table, &page)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var next string
+		if len(result.Data) >= page.Limit {
+			next = strconv.Itoa(page.Page + 1)
+		}
+		return result.Data, next, nil
+	}
+	return NewIterator(ctx, fetch, iterOpts...)
+}
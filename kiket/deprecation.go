@@ -0,0 +1,121 @@
+package kiket
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Deprecation describes an API endpoint the server marked as deprecated via
+// the Deprecation and Sunset response headers (RFC 9745, RFC 8594).
+type Deprecation struct {
+	// Request method and path that returned the headers
+	Method string
+	Path   string
+	// When the endpoint was deprecated (zero for "Deprecation: true")
+	Since time.Time
+	// When the endpoint will stop working (zero when not announced)
+	Sunset time.Time
+	// Documentation link from a rel="deprecation" or rel="sunset" Link header
+	Link string
+}
+
+// DeprecationHandler is called the first time a client sees each deprecated
+// endpoint.
+type DeprecationHandler func(Deprecation)
+
+// WithDeprecationHandler reports deprecated endpoints to handler, once per
+// method and path.
+func WithDeprecationHandler(handler DeprecationHandler) ClientOption {
+	return func(c *HTTPClient) {
+		c.onDeprecation = handler
+	}
+}
+
+// noteDeprecation reports the response's deprecation headers, if any.
+func (c *HTTPClient) noteDeprecation(req *http.Request, header http.Header) {
+	if c.onDeprecation == nil {
+		return
+	}
+	deprecation, ok := parseDeprecation(header)
+	if !ok {
+		return
+	}
+	deprecation.Method = req.Method
+	deprecation.Path = req.URL.Path
+	if _, seen := c.deprecations.LoadOrStore(deprecation.Method+" "+deprecation.Path, true); seen {
+		return
+	}
+	c.onDeprecation(deprecation)
+}
+
+// parseDeprecation reads the Deprecation, Sunset, and Link headers. It
+// reports false when the endpoint is not deprecated.
+func parseDeprecation(header http.Header) (Deprecation, bool) {
+	value := strings.TrimSpace(header.Get("Deprecation"))
+	sunset := strings.TrimSpace(header.Get("Sunset"))
+	if (value == "" || value == "false") && sunset == "" {
+		return Deprecation{}, false
+	}
+
+	var deprecation Deprecation
+	switch {
+	case strings.HasPrefix(value, "@"):
+		// Structured-field date (RFC 9745): seconds since the epoch.
+		if seconds, err := strconv.ParseInt(value[1:], 10, 64); err == nil {
+			deprecation.Since = time.Unix(seconds, 0).UTC()
+		}
+	case value != "" && value != "true":
+		// HTTP-date, as sent by implementations of earlier drafts.
+		if t, err := http.ParseTime(value); err == nil {
+			deprecation.Since = t
+		}
+	}
+	if t, err := http.ParseTime(sunset); err == nil {
+		deprecation.Sunset = t
+	}
+	deprecation.Link = deprecationLink(header.Values("Link"))
+	return deprecation, true
+}
+
+// deprecationLink returns the target of the first rel="deprecation" or
+// rel="sunset" link.
+func deprecationLink(values []string) string {
+	for _, value := range values {
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			for _, param := range parts[1:] {
+				param = strings.ReplaceAll(strings.TrimSpace(param), `"`, "")
+				if param == "rel=deprecation" || param == "rel=sunset" {
+					return target
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// deprecated logs a deprecated endpoint, records it in telemetry, and
+// passes it to Config.OnDeprecation.
+func (s *SDK) deprecated(d Deprecation) {
+	message := "kiket: API endpoint " + d.Method + " " + d.Path + " is deprecated"
+	metadata := map[string]interface{}{"method": d.Method, "path": d.Path}
+	if !d.Sunset.IsZero() {
+		message += " and will be removed on " + d.Sunset.Format("2006-01-02")
+		metadata["sunset"] = d.Sunset.Format(time.RFC3339)
+	}
+	if d.Link != "" {
+		message += " (see " + d.Link + ")"
+		metadata["link"] = d.Link
+	}
+	s.config.Logger.Print(message)
+
+	_ = s.telemetry.Record(context.Background(), "deprecation", "", "deprecated", 0, map[string]interface{}{"metadata": metadata})
+
+	if s.config.OnDeprecation != nil {
+		s.config.OnDeprecation(d)
+	}
+}
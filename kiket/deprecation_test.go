@@ -0,0 +1,57 @@
+package kiket
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSDK_DeprecationWarnings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/ext/old" {
+			w.Header().Set("Deprecation", "@1714521600")
+			w.Header().Set("Sunset", "Wed, 01 Jan 2025 00:00:00 GMT")
+			w.Header().Add("Link", `<https://docs.kiket.dev/migrate>; rel="deprecation"`)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	var got []Deprecation
+	sdk, err := New(Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+		BaseURL:         server.URL,
+		Logger:          log.New(&logs, "", 0),
+		OnDeprecation:   func(d Deprecation) { got = append(got, d) },
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	ctx := context.Background()
+	sdk.Client().Get(ctx, "/api/v1/ext/old", nil)
+	sdk.Client().Get(ctx, "/api/v1/ext/old", nil)
+	sdk.Client().Get(ctx, "/api/v1/ext/current", nil)
+
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 deprecation, got %d", len(got))
+	}
+	d := got[0]
+	if d.Method != "GET" || d.Path != "/api/v1/ext/old" || d.Link != "https://docs.kiket.dev/migrate" {
+		t.Errorf("Unexpected deprecation %+v", d)
+	}
+	if !d.Since.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) || !d.Sunset.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected dates %v %v", d.Since, d.Sunset)
+	}
+	if !strings.Contains(logs.String(), "GET /api/v1/ext/old is deprecated and will be removed on 2025-01-01") {
+		t.Errorf("Expected deprecation log, got %q", logs.String())
+	}
+}
@@ -0,0 +1,97 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const emailPath = "/api/v1/ext/email"
+
+// emailClient implements the EmailClient interface.
+type emailClient struct {
+	client Client
+}
+
+// NewEmailClient creates a new email client.
+func NewEmailClient(client Client) EmailClient {
+	return &emailClient{client: client}
+}
+
+// EmailToUser addresses a Kiket user; the platform resolves their address
+// and locale.
+func EmailToUser(userID interface{}) EmailRecipient {
+	return EmailRecipient{UserID: userID}
+}
+
+// EmailToAddress addresses a raw email address.
+func EmailToAddress(address, name string) EmailRecipient {
+	return EmailRecipient{Address: address, Name: name}
+}
+
+func (c *emailClient) Send(ctx context.Context, message EmailMessage) (*EmailReceipt, error) {
+	if message.Template == "" {
+		return nil, errors.New("email template is required")
+	}
+	if len(message.To) == 0 {
+		return nil, errors.New("at least one recipient is required")
+	}
+	for i, to := range message.To {
+		if (to.UserID == nil || to.UserID == "") && to.Address == "" {
+			return nil, fmt.Errorf("recipient %d needs a user ID or an address", i)
+		}
+	}
+
+	resp, err := c.client.Post(ctx, emailPath, map[string]interface{}{"email": message}, nil)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests {
+			return nil, parseEmailRateLimit(apiErr)
+		}
+		return nil, err
+	}
+
+	receipt, err := decodeData[EmailReceipt](resp)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.RateLimit, err = decodeEnvelope[*RateLimitInfo](resp, "rate_limit"); err != nil {
+		return nil, err
+	}
+
+	return &receipt, nil
+}
+
+func parseEmailRateLimit(apiErr *APIError) error {
+	var body struct {
+		RateLimit RateLimitInfo `json:"rate_limit"`
+	}
+	if err := json.Unmarshal([]byte(apiErr.Body), &body); err != nil {
+		return apiErr
+	}
+	return &EmailRateLimitError{RateLimit: body.RateLimit, Err: apiErr}
+}
+
+// EmailRateLimitError is returned when the workspace's email quota is
+// exhausted.
+type EmailRateLimitError struct {
+	RateLimit RateLimitInfo
+	Err       *APIError
+}
+
+func (e *EmailRateLimitError) Error() string {
+	return fmt.Sprintf("email rate limit reached (%d per %ds), resets in %ds",
+		e.RateLimit.Limit, e.RateLimit.WindowSeconds, e.RateLimit.ResetIn)
+}
+
+func (e *EmailRateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfter returns how long to wait before sending again.
+func (e *EmailRateLimitError) RetryAfter() time.Duration {
+	return time.Duration(e.RateLimit.ResetIn) * time.Second
+}
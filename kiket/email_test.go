@@ -0,0 +1,97 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEmailClient_Send(t *testing.T) {
+	var requests []string
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+		w.Write([]byte(`{"data": {"id": "em_1", "status": "queued", "accepted": 1, "rejected": ["bounced@example.com"]}, "rate_limit": {"limit": 100, "remaining": 99, "window_seconds": 3600, "reset_in": 1200}}`))
+	}))
+	defer server.Close()
+
+	email := NewEmailClient(NewHTTPClient(WithBaseURL(server.URL)))
+	receipt, err := email.Send(context.Background(), EmailMessage{
+		Template:  "digest.weekly",
+		To:        []EmailRecipient{EmailToUser(42), EmailToAddress("bounced@example.com", "Ops")},
+		Variables: map[string]interface{}{"count": 3},
+		ReplyTo:   "support@example.com",
+	})
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if receipt.ID != "em_1" || receipt.Status != "queued" || receipt.Accepted != 1 || !reflect.DeepEqual(receipt.Rejected, []string{"bounced@example.com"}) {
+		t.Errorf("Unexpected receipt %+v", receipt)
+	}
+	if receipt.RateLimit == nil || receipt.RateLimit.Remaining != 99 || receipt.RateLimit.ResetIn != 1200 {
+		t.Errorf("Expected the rate limit from the envelope, got %+v", receipt.RateLimit)
+	}
+
+	if len(requests) != 1 || requests[0] != "POST /api/v1/ext/email" {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+	expected := map[string]interface{}{"email": map[string]interface{}{
+		"template": "digest.weekly",
+		"to": []interface{}{
+			map[string]interface{}{"user_id": float64(42)},
+			map[string]interface{}{"address": "bounced@example.com", "name": "Ops"},
+		},
+		"variables": map[string]interface{}{"count": float64(3)},
+		"reply_to":  "support@example.com",
+	}}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("Expected body %v, got %v", expected, body)
+	}
+}
+
+func TestEmailClient_SendRateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error": "rate limited", "rate_limit": {"limit": 100, "remaining": 0, "window_seconds": 3600, "reset_in": 90}}`))
+	}))
+	defer server.Close()
+
+	email := NewEmailClient(NewHTTPClient(WithBaseURL(server.URL)))
+	_, err := email.Send(context.Background(), EmailMessage{Template: "digest.weekly", To: []EmailRecipient{EmailToUser(42)}})
+
+	var limitErr *EmailRateLimitError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("Expected EmailRateLimitError, got %v", err)
+	}
+	if limitErr.RetryAfter() != 90*time.Second || limitErr.RateLimit.Limit != 100 {
+		t.Errorf("Unexpected rate limit %+v", limitErr.RateLimit)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected the underlying API error, got %v", err)
+	}
+}
+
+func TestEmailClient_SendValidatesMessage(t *testing.T) {
+	email := NewEmailClient(NewHTTPClient(WithBaseURL("http://127.0.0.1:0")))
+	ctx := context.Background()
+
+	invalid := []EmailMessage{
+		{To: []EmailRecipient{EmailToUser(42)}},
+		{Template: "digest.weekly"},
+		{Template: "digest.weekly", To: []EmailRecipient{{Name: "Nobody"}}},
+	}
+	for _, message := range invalid {
+		if _, err := email.Send(ctx, message); err == nil {
+			t.Errorf("Expected error for %+v", message)
+		}
+	}
+}
@@ -15,6 +15,10 @@ type Endpoints struct {
 	client       Client
 	extensionID  string
 	eventVersion string
+	basePath     string
+	projectID    interface{} // set by ForProject
+	capabilities *capabilityCache
+	events       *EventBatcher // set by Config.EventBatchSize
 }
 
 // NewEndpoints creates a new endpoints instance.
@@ -24,16 +28,22 @@ func NewEndpoints(client Client, extensionID, eventVersion string) *Endpoints {
 		client:       client,
 		extensionID:  extensionID,
 		eventVersion: eventVersion,
+		basePath:     extensionPath(extensionID),
+		capabilities: &capabilityCache{},
 	}
 }
 
-// LogEvent logs an event for the extension.
+// LogEvent logs an event for the extension. With Config.EventBatchSize set,
+// the event is buffered and posted in a batch (see EventBatcher).
 func (e *Endpoints) LogEvent(ctx context.Context, event string, data map[string]interface{}) error {
 	if e.extensionID == "" {
 		return errors.New("extension ID required for logging events")
 	}
+	if e.events != nil {
+		return e.events.LogEvent(ctx, event, data)
+	}
 
-	path := fmt.Sprintf("%s/extensions/%s/events", apiPrefix, e.extensionID)
+	path := e.basePath + "/events"
 	_, err := e.client.Post(ctx, path, map[string]interface{}{
 		"event":     event,
 		"version":   e.eventVersion,
@@ -50,7 +60,7 @@ func (e *Endpoints) GetMetadata(ctx context.Context) (map[string]interface{}, er
 		return nil, errors.New("extension ID required for getting metadata")
 	}
 
-	path := fmt.Sprintf("%s/extensions/%s", apiPrefix, e.extensionID)
+	path := e.basePath
 	resp, err := e.client.Get(ctx, path, nil)
 	if err != nil {
 		return nil, err
@@ -64,40 +74,150 @@ func (e *Endpoints) GetMetadata(ctx context.Context) (map[string]interface{}, er
 	return result, nil
 }
 
-// CustomData returns a custom data client for the given project.
+// GetSettings retrieves the setting values configured for this extension in
+// the workspace. Settings without a configured value are omitted.
+func (e *Endpoints) GetSettings(ctx context.Context) (Settings, error) {
+	if e.extensionID == "" {
+		return nil, errors.New("extension ID required for getting settings")
+	}
+
+	path := e.basePath + "/settings"
+	resp, err := e.client.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeEnvelope[Settings](resp, "settings")
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(Settings, len(result))
+	for key, value := range result {
+		if value != nil {
+			settings[key] = value
+		}
+	}
+	return settings, nil
+}
+
+// UI returns a client for the extension's UI contributions.
+func (e *Endpoints) UI() UIClient {
+	return NewUIClient(e.client, e.extensionID)
+}
+
+// Email returns a client for sending templated email.
+func (e *Endpoints) Email() EmailClient {
+	return NewEmailClient(e.client)
+}
+
+// Reports returns a report exports client.
+func (e *Endpoints) Reports() ReportsClient {
+	return NewReportsClient(e.client)
+}
+
+// Messages returns a client for extension-to-extension messaging.
+func (e *Endpoints) Messages() MessagesClient {
+	return NewMessagesClient(e.client)
+}
+
+// Jobs returns a client for scheduling jobs on the platform.
+func (e *Endpoints) Jobs() JobsClient {
+	return NewJobsClient(e.client, e.extensionID)
+}
+
+// KV returns the extension's key-value store.
+func (e *Endpoints) KV() KVStore {
+	return NewKVStore(e.client, e.extensionID)
+}
+
+// Issues returns an issues client.
+func (e *Endpoints) Issues() IssuesClient {
+	return NewIssuesClient(e.client)
+}
+
+// Filters returns a saved filters client.
+func (e *Endpoints) Filters() FiltersClient {
+	return NewFiltersClient(e.client)
+}
+
+// Activity returns an activity stream client.
+func (e *Endpoints) Activity() ActivityClient {
+	return NewActivityClient(e.client)
+}
+
+// Worklogs returns a worklogs client.
+func (e *Endpoints) Worklogs() WorklogsClient {
+	return NewWorklogsClient(e.client)
+}
+
+// Search returns a search client.
+func (e *Endpoints) Search() SearchClient {
+	return NewSearchClient(e.client)
+}
+
+// Workflow returns a workflow client.
+func (e *Endpoints) Workflow() WorkflowClient {
+	return NewWorkflowClient(e.client)
+}
+
+// Labels returns a labels client for the given project, or the ForProject
+// project when projectID is nil.
+func (e *Endpoints) Labels(projectID interface{}) LabelsClient {
+	return NewLabelsClient(e.client, e.project(projectID))
+}
+
+// CustomData returns a custom data client for the given project, or the
+// ForProject project when projectID is nil.
 func (e *Endpoints) CustomData(projectID interface{}) CustomDataClient {
-	return NewCustomDataClient(e.client, projectID)
+	return NewCustomDataClient(e.client, e.project(projectID))
 }
 
-// SLAEvents returns an SLA events client for the given project.
+// SLAEvents returns an SLA events client for the given project, or the
+// ForProject project when projectID is nil.
 func (e *Endpoints) SLAEvents(projectID interface{}) SLAEventsClient {
-	return NewSLAEventsClient(e.client, projectID)
+	return NewSLAEventsClient(e.client, e.project(projectID))
+}
+
+// Events returns a client for the real-time Server-Sent Events stream.
+func (e *Endpoints) Events() EventsClient {
+	return NewEventsClient(e.client)
+}
+
+// Audit returns a client for blockchain audit anchors and proofs.
+func (e *Endpoints) Audit() *AuditClient {
+	return NewAuditClient(e.client)
+}
+
+// Workspace returns metadata about the installing workspace: plan, feature
+// flags, locale, and timezone.
+func (e *Endpoints) Workspace(ctx context.Context) (*Workspace, error) {
+	path := apiPrefix + "/ext/workspace"
+	resp, err := e.client.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeData[Workspace](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
 }
 
 // RateLimit returns the current rate limit status.
 func (e *Endpoints) RateLimit(ctx context.Context) (*RateLimitInfo, error) {
-	path := fmt.Sprintf("%s/ext/rate_limit", apiPrefix)
+	path := apiPrefix + "/ext/rate_limit"
 	resp, err := e.client.Get(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var result struct {
-		RateLimit struct {
-			Limit         int `json:"limit"`
-			Remaining     int `json:"remaining"`
-			WindowSeconds int `json:"window_seconds"`
-			ResetIn       int `json:"reset_in"`
-		} `json:"rate_limit"`
-	}
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	result, err := decodeEnvelope[RateLimitInfo](resp, "rate_limit")
+	if err != nil {
+		return nil, err
 	}
 
-	return &RateLimitInfo{
-		Limit:         result.RateLimit.Limit,
-		Remaining:     result.RateLimit.Remaining,
-		WindowSeconds: result.RateLimit.WindowSeconds,
-		ResetIn:       result.RateLimit.ResetIn,
-	}, nil
+	return &result, nil
 }
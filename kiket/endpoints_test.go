@@ -0,0 +1,40 @@
+package kiket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEndpoints_WorkspaceParsesMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/ext/workspace" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"data": {"id": 3, "name": "Acme", "slug": "acme", "plan": "team", "features": {"automation": true}, "locale": "de-DE", "timezone": "Europe/Berlin"}}`))
+	}))
+	defer server.Close()
+
+	endpoints := NewEndpoints(NewHTTPClient(WithBaseURL(server.URL)), "com.example.ext", "v1")
+	workspace, err := endpoints.Workspace(context.Background())
+	if err != nil {
+		t.Fatalf("Workspace failed: %v", err)
+	}
+
+	if workspace.Name != "Acme" || workspace.Slug != "acme" || workspace.Plan != "team" || workspace.Locale != "de-DE" {
+		t.Errorf("Unexpected workspace %+v", workspace)
+	}
+	if !workspace.HasFeature("automation") || workspace.HasFeature("sso") {
+		t.Errorf("Expected only the automation feature, got %v", workspace.Features)
+	}
+	if workspace.Location().String() != "Europe/Berlin" {
+		t.Errorf("Expected Europe/Berlin, got %s", workspace.Location())
+	}
+
+	workspace.Timezone = "Mars/Olympus"
+	if workspace.Location() != time.UTC {
+		t.Errorf("Expected UTC for an unknown timezone, got %s", workspace.Location())
+	}
+}
@@ -0,0 +1,68 @@
+package kiket
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Envelope keys passed to decodeEnvelope. Most endpoints wrap their
+// payload in {"data": ...}; older ones use a resource-specific key such as
+// {"keys": [...]}.
+const (
+	dataEnvelope = "data"
+	// noEnvelope opts out of unwrapping, for bodies whose own fields
+	// include the envelope key, such as GraphQL responses.
+	noEnvelope = ""
+)
+
+// decodeEnvelope parses an API response and returns the payload under key.
+// A response without key holds no payload and yields the zero value,
+// except that a body without the "data" wrapper is decoded as the payload
+// itself, so endpoints that drop the wrapper keep working.
+func decodeEnvelope[T any](resp []byte, key string) (T, error) {
+	var payload T
+	body, ok := unwrap(resp, key)
+	if !ok {
+		if key != dataEnvelope {
+			return payload, nil
+		}
+		body = resp
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return payload, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return payload, nil
+}
+
+// decodeData returns the payload of a {"data": ...} response.
+func decodeData[T any](resp []byte) (T, error) {
+	return decodeEnvelope[T](resp, dataEnvelope)
+}
+
+// UnwrapData decodes a response from Client into v, unwrapping the
+// {"data": ...} envelope most endpoints use. Bodies without the envelope
+// are decoded as is. Use json.Unmarshal to read the envelope itself.
+func UnwrapData(resp []byte, v interface{}) error {
+	body, ok := unwrap(resp, dataEnvelope)
+	if !ok {
+		body = resp
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// unwrap returns the value under key when resp is an object holding it.
+// With noEnvelope it returns resp itself.
+func unwrap(resp []byte, key string) ([]byte, bool) {
+	if key == noEnvelope {
+		return resp, true
+	}
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(resp, &envelope); err != nil {
+		return nil, false
+	}
+	inner, ok := envelope[key]
+	return inner, ok
+}
@@ -0,0 +1,39 @@
+package kiket
+
+import "testing"
+
+func TestDecodeEnvelope(t *testing.T) {
+	issue, err := decodeData[Issue]([]byte(`{"data":{"id":1,"title":"Bug"}}`))
+	if err != nil || issue.Title != "Bug" {
+		t.Errorf("Expected wrapped issue, got %+v (%v)", issue, err)
+	}
+
+	issue, err = decodeData[Issue]([]byte(`{"id":2,"title":"Bare"}`))
+	if err != nil || issue.Title != "Bare" {
+		t.Errorf("Expected unwrapped issue, got %+v (%v)", issue, err)
+	}
+
+	keys, err := decodeEnvelope[[]string]([]byte(`{"keys":["a","b"]}`), "keys")
+	if err != nil || len(keys) != 2 {
+		t.Errorf("Expected 2 keys, got %v (%v)", keys, err)
+	}
+
+	value, err := decodeEnvelope[string]([]byte(`{}`), "value")
+	if err != nil || value != "" {
+		t.Errorf("Expected empty value for missing key, got %q (%v)", value, err)
+	}
+
+	raw, err := decodeEnvelope[map[string]interface{}]([]byte(`{"data":[1],"total":1}`), noEnvelope)
+	if err != nil || raw["total"] != float64(1) {
+		t.Errorf("Expected envelope kept with noEnvelope, got %v (%v)", raw, err)
+	}
+
+	if _, err := decodeData[Issue]([]byte(`{"data":"oops"}`)); err == nil {
+		t.Error("Expected parse error for mismatched payload")
+	}
+
+	var label Label
+	if err := UnwrapData([]byte(`{"data":{"name":"bug"}}`), &label); err != nil || label.Name != "bug" {
+		t.Errorf("Expected unwrapped label, got %+v (%v)", label, err)
+	}
+}
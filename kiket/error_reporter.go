@@ -0,0 +1,152 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// redacted replaces scrubbed values in error reports.
+const redacted = "[redacted]"
+
+// ErrorReport describes a delivery whose handler failed or panicked.
+type ErrorReport struct {
+	// The handler's error, or a *PanicError
+	Err error
+	// Delivery details
+	Event            string
+	EventVersion     string
+	DeliveryID       string
+	WorkspaceID      string
+	ExtensionID      string
+	ExtensionVersion string
+	// The delivery's payload with secrets removed: the secrets bundle is
+	// dropped, and values of secret settings and of keys that look like
+	// credentials (token, password, ...) are replaced with "[redacted]".
+	Payload map[string]interface{}
+}
+
+// ErrorReporter receives handler errors and panics, for example to forward
+// them to Sentry (see the kiketsentry package).
+type ErrorReporter interface {
+	ReportError(ctx context.Context, report ErrorReport)
+}
+
+// ErrorReporterFunc adapts a function to ErrorReporter.
+type ErrorReporterFunc func(ctx context.Context, report ErrorReport)
+
+// ReportError calls f.
+func (f ErrorReporterFunc) ReportError(ctx context.Context, report ErrorReport) {
+	f(ctx, report)
+}
+
+// PanicError is returned for a delivery whose handler panicked.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("handler panicked: %v", e.Value)
+}
+
+// runHandler calls the handler, turning a panic into a *PanicError.
+func runHandler(ctx context.Context, handler *HandlerMetadata, raw *RawPayload, handlerCtx *HandlerContext) (result interface{}, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			result, err = nil, &PanicError{Value: v, Stack: debug.Stack()}
+		}
+	}()
+
+	if handler.RawHandler != nil {
+		return handler.RawHandler(ctx, raw, handlerCtx)
+	}
+	payload, err := raw.Map()
+	if err != nil {
+		return nil, err
+	}
+	return handler.Handler(ctx, payload, handlerCtx)
+}
+
+// reportError passes a failed delivery to Config.ErrorReporter.
+func (s *SDK) reportError(ctx context.Context, err error, raw *RawPayload, handlerCtx *HandlerContext) {
+	if s.config.ErrorReporter == nil {
+		return
+	}
+
+	var payload map[string]interface{}
+	if json.Unmarshal(raw.Bytes(), &payload) == nil {
+		delete(payload, "secrets")
+		s.settingsMu.RLock()
+		secretKeys := SecretKeys(s.manifest)
+		s.settingsMu.RUnlock()
+		scrub(payload, secretKeys, raw.secrets())
+	}
+
+	s.config.ErrorReporter.ReportError(ctx, ErrorReport{
+		Err:              err,
+		Event:            handlerCtx.Event,
+		EventVersion:     handlerCtx.EventVersion,
+		DeliveryID:       handlerCtx.DeliveryID,
+		WorkspaceID:      handlerCtx.WorkspaceID,
+		ExtensionID:      handlerCtx.ExtensionID,
+		ExtensionVersion: handlerCtx.ExtensionVersion,
+		Payload:          payload,
+	})
+}
+
+// sensitiveKeyParts mark keys whose values are redacted from error reports.
+var sensitiveKeyParts = []string{"secret", "token", "password", "api_key", "apikey", "authorization", "credential", "private_key"}
+
+// scrub redacts, in place, values under secret or credential-like keys and
+// string values equal to one of the payload secrets.
+func scrub(v interface{}, secretKeys []string, secrets map[string]string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if sensitiveKey(key, secretKeys) {
+				v[key] = redacted
+				continue
+			}
+			if s, ok := value.(string); ok && isSecretValue(s, secrets) {
+				v[key] = redacted
+				continue
+			}
+			scrub(value, secretKeys, secrets)
+		}
+	case []interface{}:
+		for i, value := range v {
+			if s, ok := value.(string); ok && isSecretValue(s, secrets) {
+				v[i] = redacted
+				continue
+			}
+			scrub(value, secretKeys, secrets)
+		}
+	}
+}
+
+func sensitiveKey(key string, secretKeys []string) bool {
+	for _, secretKey := range secretKeys {
+		if key == secretKey {
+			return true
+		}
+	}
+	lower := strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSecretValue(s string, secrets map[string]string) bool {
+	for _, secret := range secrets {
+		if secret != "" && s == secret {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSDK_ErrorReporter(t *testing.T) {
+	var reports []ErrorReport
+	sdk, err := New(Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+		ErrorReporter: ErrorReporterFunc(func(ctx context.Context, report ErrorReport) {
+			reports = append(reports, report)
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		panic("nil map")
+	})
+	sdk.On("issue.updated", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		return nil, errors.New("upstream down")
+	})
+
+	deliver := func(body string) error {
+		signature, timestamp := GenerateSignature("secret", body, nil)
+		_, err := sdk.HandleWebhook(context.Background(), []byte(body), Headers{
+			"X-Kiket-Signature":     signature,
+			"X-Kiket-Timestamp":     timestamp,
+			WorkspaceIDHeader:       "acme",
+			"X-Kiket-Event-Version": "v1",
+		})
+		return err
+	}
+
+	err = deliver(`{"event":"issue.created","secrets":{"SLACK_TOKEN":"xoxb-1"},"issue":{"id":1,"note":"xoxb-1","access_token":"abc"}}`)
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != "nil map" || len(panicErr.Stack) == 0 {
+		t.Fatalf("Expected PanicError, got %v", err)
+	}
+	if err := deliver(`{"event":"issue.updated"}`); err == nil || err.Error() != "upstream down" {
+		t.Fatalf("Expected handler error, got %v", err)
+	}
+
+	if len(reports) != 2 {
+		t.Fatalf("Expected 2 reports, got %d", len(reports))
+	}
+	report := reports[0]
+	if report.Event != "issue.created" || report.WorkspaceID != "acme" || report.ExtensionID != "com.example.ext" {
+		t.Errorf("Unexpected report %+v", report)
+	}
+	if _, ok := report.Payload["secrets"]; ok {
+		t.Error("Expected secrets to be removed")
+	}
+	issue := report.Payload["issue"].(map[string]interface{})
+	if issue["note"] != redacted || issue["access_token"] != redacted || issue["id"] != float64(1) {
+		t.Errorf("Expected scrubbed issue, got %v", issue)
+	}
+	if reports[1].Err.Error() != "upstream down" {
+		t.Errorf("Unexpected error %v", reports[1].Err)
+	}
+}
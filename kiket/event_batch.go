@@ -0,0 +1,327 @@
+package kiket
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultEventBatchSize     = 50
+	defaultEventFlushInterval = 5 * time.Second
+	defaultEventMaxRetries    = 3
+	eventRetryBackoff         = 200 * time.Millisecond
+	eventFlushTimeout         = 30 * time.Second
+	maxBufferedEvents         = 10000
+
+	idempotencyKeyHeader = "Idempotency-Key"
+)
+
+// ErrEventBatcherClosed is returned by EventBatcher.LogEvent after Close.
+var ErrEventBatcherClosed = errors.New("event batcher closed")
+
+// batchedEvent is one buffered LogEvent call. The ID lets Kiket drop
+// duplicates when a retried batch had already been stored.
+type batchedEvent struct {
+	ID        string                 `json:"id"`
+	Event     string                 `json:"event"`
+	Version   string                 `json:"version"`
+	Data      map[string]interface{} `json:"data"`
+	Timestamp string                 `json:"timestamp"`
+
+	workspaceID string
+}
+
+// EventBatcher buffers LogEvent calls and posts them in batches, so
+// handlers that log many fine-grained events (e.g. per-record sync results)
+// make one API call per batch instead of one per event.
+//
+// Batches are posted by a background loop every flush interval, or sooner
+// once the batch size is reached. Transient failures are retried with
+// backoff; batches that still fail stay buffered for the next flush. Every
+// event and batch carries an ID, so retries never log an event twice. Call
+// Flush or Close before the process exits so buffered events are not lost.
+type EventBatcher struct {
+	endpoints     *Endpoints
+	batchSize     int
+	flushInterval time.Duration
+	maxRetries    int
+	onError       func(error)
+
+	idPrefix string
+	seq      atomic.Uint64
+	dropped  atomic.Int64
+
+	flushMu sync.Mutex
+
+	mu        sync.Mutex
+	buffer    []batchedEvent
+	closed    bool
+	flushCh   chan struct{}
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// EventBatchOption configures an EventBatcher.
+type EventBatchOption func(*EventBatcher)
+
+// WithEventBatchSize sets how many events are posted per request (default
+// 50). A full batch triggers an early flush.
+func WithEventBatchSize(size int) EventBatchOption {
+	return func(b *EventBatcher) {
+		if size > 0 {
+			b.batchSize = size
+		}
+	}
+}
+
+// WithEventFlushInterval sets how often buffered events are posted (default
+// 5s).
+func WithEventFlushInterval(interval time.Duration) EventBatchOption {
+	return func(b *EventBatcher) {
+		if interval > 0 {
+			b.flushInterval = interval
+		}
+	}
+}
+
+// WithEventRetries sets how many times a batch is retried after a transient
+// failure within one flush (default 3).
+func WithEventRetries(retries int) EventBatchOption {
+	return func(b *EventBatcher) {
+		if retries >= 0 {
+			b.maxRetries = retries
+		}
+	}
+}
+
+// WithEventErrorHandler receives errors from background flushes, which have
+// no caller to return them to.
+func WithEventErrorHandler(handler func(error)) EventBatchOption {
+	return func(b *EventBatcher) {
+		b.onError = handler
+	}
+}
+
+// NewEventBatcher creates a batcher posting events through endpoints and
+// starts its flush loop.
+func NewEventBatcher(endpoints *Endpoints, opts ...EventBatchOption) *EventBatcher {
+	b := &EventBatcher{
+		endpoints:     endpoints,
+		batchSize:     defaultEventBatchSize,
+		flushInterval: defaultEventFlushInterval,
+		maxRetries:    defaultEventMaxRetries,
+		idPrefix:      randomHex(6),
+		flushCh:       make(chan struct{}, 1),
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	go b.loop()
+	return b
+}
+
+// LogEvent buffers an event. The workspace in ctx (see
+// ContextWithWorkspace) is kept, so the event is posted with that
+// workspace's credentials.
+func (b *EventBatcher) LogEvent(ctx context.Context, event string, data map[string]interface{}) error {
+	if b.endpoints.extensionID == "" {
+		return errors.New("extension ID required for logging events")
+	}
+
+	entry := batchedEvent{
+		ID:        b.idPrefix + "-" + strconv.FormatUint(b.seq.Add(1), 10),
+		Event:     event,
+		Version:   b.endpoints.eventVersion,
+		Data:      data,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+
+		workspaceID: WorkspaceFromContext(ctx),
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrEventBatcherClosed
+	}
+	b.buffer = append(b.buffer, entry)
+	if overflow := len(b.buffer) - maxBufferedEvents; overflow > 0 {
+		b.buffer = b.buffer[overflow:]
+		b.dropped.Add(int64(overflow))
+	}
+	full := len(b.buffer) >= b.batchSize
+	b.mu.Unlock()
+
+	if full {
+		select {
+		case b.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Pending returns the number of buffered events.
+func (b *EventBatcher) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.buffer)
+}
+
+// Dropped returns how many events were discarded because the buffer was
+// full while Kiket was unreachable.
+func (b *EventBatcher) Dropped() int64 {
+	return b.dropped.Load()
+}
+
+// Flush posts all buffered events. Batches that fail transiently, or are
+// not attempted before ctx is done, stay buffered for the next flush;
+// batches Kiket rejects are dropped and reported in the returned error.
+func (b *EventBatcher) Flush(ctx context.Context) error {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	pending := b.buffer
+	b.buffer = nil
+	b.mu.Unlock()
+
+	var retry []batchedEvent
+	var errs []error
+	for _, batch := range b.batches(pending) {
+		if err := ctx.Err(); err != nil {
+			retry = append(retry, batch...)
+			continue
+		}
+		if err := b.send(ctx, batch); err != nil {
+			if isRetryableAPIError(err) {
+				retry = append(retry, batch...)
+			}
+			errs = append(errs, fmt.Errorf("failed to log %d events: %w", len(batch), err))
+		}
+	}
+
+	if len(retry) > 0 {
+		b.requeue(retry)
+	}
+	if len(errs) == 0 {
+		return ctx.Err()
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops the flush loop and posts any buffered events. Events passed
+// to LogEvent after Close are rejected with ErrEventBatcherClosed.
+func (b *EventBatcher) Close(ctx context.Context) error {
+	b.closeOnce.Do(func() {
+		b.mu.Lock()
+		b.closed = true
+		b.mu.Unlock()
+		close(b.done)
+	})
+
+	select {
+	case <-b.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return b.Flush(ctx)
+}
+
+// batches splits events into request-sized batches, each for a single
+// workspace, keeping their order within a workspace.
+func (b *EventBatcher) batches(events []batchedEvent) [][]batchedEvent {
+	var batches [][]batchedEvent
+	open := make(map[string]int) // workspace -> index of its last batch
+	for _, event := range events {
+		i, ok := open[event.workspaceID]
+		if !ok || len(batches[i]) >= b.batchSize {
+			batches = append(batches, nil)
+			i = len(batches) - 1
+			open[event.workspaceID] = i
+		}
+		batches[i] = append(batches[i], event)
+	}
+	return batches
+}
+
+// send posts one batch, retrying transient failures with the same
+// idempotency key.
+func (b *EventBatcher) send(ctx context.Context, batch []batchedEvent) error {
+	if workspaceID := batch[0].workspaceID; workspaceID != "" {
+		ctx = ContextWithWorkspace(ctx, workspaceID)
+	}
+	opts := &RequestOptions{Headers: Headers{
+		idempotencyKeyHeader: batch[0].ID + ".." + batch[len(batch)-1].ID,
+	}}
+	body := map[string]interface{}{"events": batch}
+
+	backoff := eventRetryBackoff
+	for attempt := 0; ; attempt++ {
+		_, err := b.endpoints.client.Post(ctx, b.endpoints.basePath+"/events/batch", body, opts)
+		if err == nil || attempt >= b.maxRetries || !isRetryableAPIError(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// requeue puts events back in front of those logged since the flush
+// started, dropping the oldest beyond the buffer limit.
+func (b *EventBatcher) requeue(events []batchedEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.buffer = append(events, b.buffer...)
+	if overflow := len(b.buffer) - maxBufferedEvents; overflow > 0 {
+		b.buffer = b.buffer[overflow:]
+		b.dropped.Add(int64(overflow))
+	}
+}
+
+func (b *EventBatcher) loop() {
+	defer close(b.stopped)
+
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-ticker.C:
+		case <-b.flushCh:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), eventFlushTimeout)
+		if err := b.Flush(ctx); err != nil && b.onError != nil {
+			b.onError(err)
+		}
+		cancel()
+	}
+}
+
+// randomHex returns n random bytes, hex encoded.
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,76 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventBatcher_BatchesAndRetriesWithSameKey(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	var batches [][]batchedEvent
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/extensions/com.example.ext/events/batch" {
+			http.NotFound(w, r)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		if attempts == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		var body struct {
+			Events []batchedEvent `json:"events"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		batches = append(batches, body.Events)
+		w.Write([]byte(`{"data":{"accepted":true}}`))
+	}))
+	defer server.Close()
+
+	sdk, err := New(Config{
+		ExtensionID:        "com.example.ext",
+		ExtensionVersion:   "1.0.0",
+		ExtensionAPIKey:    "key",
+		BaseURL:            server.URL,
+		EventBatchSize:     2,
+		EventFlushInterval: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, record := range []string{"a", "b", "c"} {
+		if err := sdk.Endpoints().LogEvent(ctx, "sync.record", map[string]interface{}{"record": record}); err != nil {
+			t.Fatalf("LogEvent failed: %v", err)
+		}
+	}
+	if err := sdk.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(batches) != 2 || len(batches[0]) != 2 || len(batches[1]) != 1 {
+		t.Fatalf("Expected batches of 2 and 1 events, got %v", batches)
+	}
+	if batches[0][0].Data["record"] != "a" || batches[1][0].Data["record"] != "c" || batches[0][0].Version != "1.0.0" {
+		t.Errorf("Expected events in order, got %v", batches)
+	}
+	if keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("Expected retry with the same idempotency key, got %v", keys)
+	}
+	if err := sdk.Endpoints().LogEvent(ctx, "late", nil); err != ErrEventBatcherClosed {
+		t.Errorf("Expected ErrEventBatcherClosed after Close, got %v", err)
+	}
+}
@@ -0,0 +1,291 @@
+package eventpb
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// typedFields are the top-level payload fields mapped onto Event fields;
+// everything else is kept in Event.Extra.
+var typedFields = map[string]bool{
+	"event": true, "event_id": true, "occurred_at": true, "issue": true, "project": true,
+	"comment": true, "actor": true, "changes": true, "context": true,
+}
+
+// FromJSON converts a JSON webhook body to an Event. version is the
+// delivery's event version (HandlerContext.EventVersion); it is stored as
+// given.
+func FromJSON(body []byte, version string) (*Event, error) {
+	var payload struct {
+		Event      string                     `json:"event"`
+		EventID    string                     `json:"event_id"`
+		OccurredAt string                     `json:"occurred_at"`
+		Issue      *jsonIssue                 `json:"issue"`
+		Project    *jsonProject               `json:"project"`
+		Comment    *jsonComment               `json:"comment"`
+		Actor      *jsonUser                  `json:"actor"`
+		Changes    map[string]jsonChange      `json:"changes"`
+		Context    *jsonContext               `json:"context"`
+		All        map[string]json.RawMessage `json:"-"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook payload: %w", err)
+	}
+	if err := json.Unmarshal(body, &payload.All); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook payload: %w", err)
+	}
+
+	c := converter{}
+	event := &Event{
+		Event:      payload.Event,
+		Version:    version,
+		EventId:    payload.EventID,
+		OccurredAt: c.timestamp("occurred_at", payload.OccurredAt),
+		Issue:      c.issue(payload.Issue),
+		Project:    c.project(payload.Project),
+		Comment:    c.comment(payload.Comment),
+		Actor:      c.user(payload.Actor),
+		Changes:    c.changes(payload.Changes),
+	}
+	if payload.Context != nil {
+		event.Context = &EventContext{
+			Actor:     c.user(payload.Context.Actor),
+			Source:    payload.Context.Source,
+			RequestId: payload.Context.RequestID,
+		}
+	}
+
+	extra := map[string]interface{}{}
+	for key, raw := range payload.All {
+		if typedFields[key] {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", key, err)
+		}
+		extra[key] = value
+	}
+	if len(extra) > 0 {
+		event.Extra = c.structValue("extra", extra)
+	}
+
+	if c.err != nil {
+		return nil, c.err
+	}
+	return event, nil
+}
+
+type jsonIssue struct {
+	ID           interface{}            `json:"id"`
+	Key          string                 `json:"key"`
+	ProjectID    interface{}            `json:"project_id"`
+	Title        string                 `json:"title"`
+	Description  string                 `json:"description"`
+	State        string                 `json:"state"`
+	Priority     string                 `json:"priority"`
+	IssueType    string                 `json:"issue_type"`
+	AssigneeID   interface{}            `json:"assignee_id"`
+	ReporterID   interface{}            `json:"reporter_id"`
+	Labels       []string               `json:"labels"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	DueDate      *string                `json:"due_date"`
+	CreatedAt    string                 `json:"created_at"`
+	UpdatedAt    string                 `json:"updated_at"`
+}
+
+type jsonProject struct {
+	ID          interface{} `json:"id"`
+	Key         string      `json:"key"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	LeadID      interface{} `json:"lead_id"`
+	WorkflowID  interface{} `json:"workflow_id"`
+	Archived    bool        `json:"archived"`
+	CreatedAt   string      `json:"created_at"`
+	UpdatedAt   string      `json:"updated_at"`
+}
+
+type jsonUser struct {
+	ID        interface{} `json:"id"`
+	Name      string      `json:"name"`
+	Email     string      `json:"email"`
+	AvatarURL string      `json:"avatar_url"`
+	Locale    string      `json:"locale"`
+	Timezone  string      `json:"timezone"`
+	Role      string      `json:"role"`
+	Active    bool        `json:"active"`
+}
+
+type jsonComment struct {
+	ID        interface{} `json:"id"`
+	IssueID   interface{} `json:"issue_id"`
+	AuthorID  interface{} `json:"author_id"`
+	Body      string      `json:"body"`
+	Internal  bool        `json:"internal"`
+	CreatedAt string      `json:"created_at"`
+	UpdatedAt string      `json:"updated_at"`
+}
+
+type jsonChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+type jsonContext struct {
+	Actor     *jsonUser `json:"actor"`
+	Source    string    `json:"source"`
+	RequestID string    `json:"request_id"`
+}
+
+// converter records the first conversion error so the mapping code stays
+// linear.
+type converter struct {
+	err error
+}
+
+func (c *converter) fail(field string, err error) {
+	if c.err == nil {
+		c.err = fmt.Errorf("failed to convert %s: %w", field, err)
+	}
+}
+
+func (c *converter) timestamp(field, value string) *timestamppb.Timestamp {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		c.fail(field, err)
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func (c *converter) structValue(field string, m map[string]interface{}) *structpb.Struct {
+	if m == nil {
+		return nil
+	}
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		c.fail(field, err)
+		return nil
+	}
+	return s
+}
+
+func (c *converter) value(field string, v interface{}) *structpb.Value {
+	value, err := structpb.NewValue(v)
+	if err != nil {
+		c.fail(field, err)
+		return nil
+	}
+	return value
+}
+
+func (c *converter) issue(in *jsonIssue) *Issue {
+	if in == nil {
+		return nil
+	}
+	out := &Issue{
+		Id:           id(in.ID),
+		Key:          in.Key,
+		ProjectId:    id(in.ProjectID),
+		Title:        in.Title,
+		Description:  in.Description,
+		State:        in.State,
+		Priority:     in.Priority,
+		IssueType:    in.IssueType,
+		AssigneeId:   id(in.AssigneeID),
+		ReporterId:   id(in.ReporterID),
+		Labels:       in.Labels,
+		CustomFields: c.structValue("issue.custom_fields", in.CustomFields),
+		CreatedAt:    c.timestamp("issue.created_at", in.CreatedAt),
+		UpdatedAt:    c.timestamp("issue.updated_at", in.UpdatedAt),
+	}
+	if in.DueDate != nil {
+		out.DueDate = *in.DueDate
+	}
+	return out
+}
+
+func (c *converter) project(in *jsonProject) *Project {
+	if in == nil {
+		return nil
+	}
+	return &Project{
+		Id:          id(in.ID),
+		Key:         in.Key,
+		Name:        in.Name,
+		Description: in.Description,
+		LeadId:      id(in.LeadID),
+		WorkflowId:  id(in.WorkflowID),
+		Archived:    in.Archived,
+		CreatedAt:   c.timestamp("project.created_at", in.CreatedAt),
+		UpdatedAt:   c.timestamp("project.updated_at", in.UpdatedAt),
+	}
+}
+
+func (c *converter) user(in *jsonUser) *User {
+	if in == nil {
+		return nil
+	}
+	return &User{
+		Id:        id(in.ID),
+		Name:      in.Name,
+		Email:     in.Email,
+		AvatarUrl: in.AvatarURL,
+		Locale:    in.Locale,
+		Timezone:  in.Timezone,
+		Role:      in.Role,
+		Active:    in.Active,
+	}
+}
+
+func (c *converter) comment(in *jsonComment) *Comment {
+	if in == nil {
+		return nil
+	}
+	return &Comment{
+		Id:        id(in.ID),
+		IssueId:   id(in.IssueID),
+		AuthorId:  id(in.AuthorID),
+		Body:      in.Body,
+		Internal:  in.Internal,
+		CreatedAt: c.timestamp("comment.created_at", in.CreatedAt),
+		UpdatedAt: c.timestamp("comment.updated_at", in.UpdatedAt),
+	}
+}
+
+func (c *converter) changes(in map[string]jsonChange) map[string]*Change {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]*Change, len(in))
+	for field, change := range in {
+		out[field] = &Change{
+			From: c.value("changes."+field+".from", change.From),
+			To:   c.value("changes."+field+".to", change.To),
+		}
+	}
+	return out
+}
+
+// id formats a numeric or string identifier; null becomes "".
+func id(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,77 @@
+package eventpb
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const issueUpdated = `{
+  "event": "issue.updated",
+  "event_id": "evt-42",
+  "occurred_at": "2024-05-01T12:00:00Z",
+  "issue": {
+    "id": 101,
+    "key": "OPS-7",
+    "project_id": 9,
+    "title": "Disk full",
+    "state": "in_progress",
+    "assignee_id": null,
+    "labels": ["infra"],
+    "custom_fields": {"severity": "high", "points": 3},
+    "due_date": "2024-05-10",
+    "created_at": "2024-04-30T08:00:00Z",
+    "updated_at": "2024-05-01T12:00:00Z"
+  },
+  "actor": {"id": "u-1", "name": "Ada", "active": true},
+  "changes": {"state": {"from": "open", "to": "in_progress"}},
+  "workspace": {"slug": "acme"}
+}`
+
+func TestFromJSON(t *testing.T) {
+	event, err := FromJSON([]byte(issueUpdated), "v2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if event.Event != "issue.updated" || event.Version != "v2" || event.EventId != "evt-42" {
+		t.Errorf("Unexpected envelope %s %s %s", event.Event, event.Version, event.EventId)
+	}
+	if event.OccurredAt.AsTime().Unix() != 1714564800 {
+		t.Errorf("Expected occurred_at 1714564800, got %d", event.OccurredAt.AsTime().Unix())
+	}
+	issue := event.Issue
+	if issue.Id != "101" || issue.ProjectId != "9" || issue.AssigneeId != "" || issue.DueDate != "2024-05-10" {
+		t.Errorf("Unexpected issue %v", issue)
+	}
+	if issue.CustomFields.Fields["severity"].GetStringValue() != "high" || issue.CustomFields.Fields["points"].GetNumberValue() != 3 {
+		t.Errorf("Unexpected custom fields %v", issue.CustomFields)
+	}
+	if event.Actor.Id != "u-1" || !event.Actor.Active {
+		t.Errorf("Unexpected actor %v", event.Actor)
+	}
+	if change := event.Changes["state"]; change.From.GetStringValue() != "open" || change.To.GetStringValue() != "in_progress" {
+		t.Errorf("Unexpected change %v", change)
+	}
+	if event.Extra.Fields["workspace"].GetStructValue().Fields["slug"].GetStringValue() != "acme" {
+		t.Errorf("Expected workspace in extra, got %v", event.Extra)
+	}
+
+	data, err := proto.Marshal(event)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var decoded Event
+	if err := proto.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !proto.Equal(event, &decoded) {
+		t.Errorf("Expected round trip to preserve event")
+	}
+}
+
+func TestFromJSONInvalidTimestamp(t *testing.T) {
+	if _, err := FromJSON([]byte(`{"event":"issue.created","occurred_at":"yesterday"}`), "v1"); err == nil {
+		t.Error("Expected error for invalid occurred_at")
+	}
+}
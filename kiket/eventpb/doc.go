@@ -0,0 +1,18 @@
+// Package eventpb provides Protocol Buffers types for Kiket's core webhook
+// event payloads, for extensions that persist events to columnar or stream
+// systems and want a fixed schema rather than ad-hoc JSON.
+//
+// events.proto is the source of truth; events.pb.go is generated from it.
+// FromJSON converts a webhook body:
+//
+//	sdk.OnRaw("issue.updated", func(ctx context.Context, payload *kiket.RawPayload, hctx *kiket.HandlerContext) (interface{}, error) {
+//		event, err := eventpb.FromJSON(payload.Bytes(), hctx.EventVersion)
+//		if err != nil {
+//			return nil, err
+//		}
+//		data, err := proto.Marshal(event)
+//		// write data to the stream ...
+//	})
+package eventpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative events.proto
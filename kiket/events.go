@@ -0,0 +1,183 @@
+package kiket
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const eventsStreamPath = "/api/v1/ext/events/stream"
+
+const (
+	defaultStreamRetry = time.Second
+	maxStreamRetry     = 30 * time.Second
+	eventBufferSize    = 64
+)
+
+// Event is one message received from the real-time event stream.
+type Event struct {
+	// ID is the resume token; reconnects continue after the last ID seen.
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Decode unmarshals the event data into v.
+func (e Event) Decode(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// eventsClient implements the EventsClient interface.
+type eventsClient struct {
+	client Client
+}
+
+// NewEventsClient creates a new real-time events client. The client must
+// implement StreamingClient.
+func NewEventsClient(client Client) EventsClient {
+	return &eventsClient{client: client}
+}
+
+func (c *eventsClient) Subscribe(ctx context.Context, topics []string) (<-chan Event, error) {
+	if len(topics) == 0 {
+		return nil, errors.New("at least one topic is required")
+	}
+	streamer, ok := c.client.(StreamingClient)
+	if !ok {
+		return nil, errors.New("client does not support streaming")
+	}
+
+	sub := &subscription{
+		streamer: streamer,
+		topics:   strings.Join(topics, ","),
+		retry:    defaultStreamRetry,
+		events:   make(chan Event, eventBufferSize),
+	}
+
+	body, err := sub.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	go sub.run(ctx, body)
+	return sub.events, nil
+}
+
+// subscription tracks the resume position of one Subscribe call.
+type subscription struct {
+	streamer StreamingClient
+	topics   string
+	lastID   string
+	retry    time.Duration
+	events   chan Event
+}
+
+func (s *subscription) connect(ctx context.Context) (io.ReadCloser, error) {
+	opts := &RequestOptions{Params: map[string]string{"topics": s.topics}}
+	if s.lastID != "" {
+		opts.Headers = Headers{"Last-Event-ID": s.lastID}
+	}
+	return s.streamer.Stream(ctx, eventsStreamPath, opts)
+}
+
+// run reads the stream and reconnects with exponential backoff until ctx is
+// done or the server rejects the subscription.
+func (s *subscription) run(ctx context.Context, body io.ReadCloser) {
+	defer close(s.events)
+
+	backoff := s.retry
+	for {
+		if body != nil {
+			received := s.read(ctx, body)
+			body.Close()
+			if received {
+				backoff = s.retry
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if backoff *= 2; backoff > maxStreamRetry {
+			backoff = maxStreamRetry
+		}
+
+		var err error
+		body, err = s.connect(ctx)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 && apiErr.StatusCode != 429 {
+			return
+		}
+	}
+}
+
+// read dispatches events from one connection until it ends and reports
+// whether any event arrived.
+func (s *subscription) read(ctx context.Context, body io.Reader) bool {
+	reader := bufio.NewReader(body)
+	received := false
+
+	var event Event
+	var data strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return received
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if data.Len() > 0 {
+				event.Data = json.RawMessage(data.String())
+				if event.Type == "" {
+					event.Type = "message"
+				}
+				if event.ID != "" {
+					s.lastID = event.ID
+				}
+				select {
+				case s.events <- event:
+					received = true
+				case <-ctx.Done():
+					return received
+				}
+			}
+			event = Event{}
+			data.Reset()
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // heartbeat comment
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			event.ID = value
+		case "event":
+			event.Type = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms > 0 {
+				s.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package kiket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventsClient_SubscribeResumesAfterReconnect(t *testing.T) {
+	var mu sync.Mutex
+	var resumeIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/ext/events/stream" || r.URL.Query().Get("topics") != "issue.created,issue.updated" {
+			http.NotFound(w, r)
+			return
+		}
+		mu.Lock()
+		resumeIDs = append(resumeIDs, r.Header.Get("Last-Event-ID"))
+		attempt := len(resumeIDs)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		if attempt == 1 {
+			// Deliver one event, then drop the connection.
+			fmt.Fprint(w, "retry: 10\n: keepalive\n\nid: 1\nevent: issue.created\ndata: {\"id\":1}\n\n")
+			return
+		}
+		fmt.Fprint(w, "id: 2\nevent: issue.updated\ndata: {\"id\":\ndata: 2}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events := NewEventsClient(NewHTTPClient(WithBaseURL(server.URL)))
+	ch, err := events.Subscribe(ctx, []string{"issue.created", "issue.updated"})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	first := <-ch
+	if first.ID != "1" || first.Type != "issue.created" {
+		t.Errorf("Unexpected first event %+v", first)
+	}
+	second := <-ch
+	var data struct{ ID int }
+	if err := second.Decode(&data); err != nil || data.ID != 2 {
+		t.Errorf("Expected multi-line data to decode to id 2, got %s (%v)", second.Data, err)
+	}
+
+	mu.Lock()
+	if len(resumeIDs) != 2 || resumeIDs[1] != "1" {
+		t.Errorf("Expected reconnect to resume after event 1, got %v", resumeIDs)
+	}
+	mu.Unlock()
+
+	cancel()
+	for range ch {
+	}
+}
+
+func TestEventsClient_SubscribeReturnsConnectError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	events := NewEventsClient(NewHTTPClient(WithBaseURL(server.URL)))
+	if _, err := events.Subscribe(context.Background(), []string{"issue.created"}); err == nil {
+		t.Error("Expected error for rejected subscription")
+	}
+}
@@ -0,0 +1,84 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const filtersPath = "/api/v1/ext/filters"
+
+// filtersClient implements the FiltersClient interface.
+type filtersClient struct {
+	client Client
+}
+
+// NewFiltersClient creates a new saved filters client.
+func NewFiltersClient(client Client) FiltersClient {
+	return &filtersClient{client: client}
+}
+
+func (c *filtersClient) List(ctx context.Context, projectID interface{}) ([]SavedFilter, error) {
+	params := map[string]string{}
+	if projectID != nil && projectID != "" {
+		params["project_id"] = fmt.Sprintf("%v", projectID)
+	}
+
+	resp, err := c.client.Get(ctx, filtersPath, &RequestOptions{Params: params})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeData[[]SavedFilter](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *filtersClient) Get(ctx context.Context, filterID interface{}) (*SavedFilter, error) {
+	if filterID == nil || filterID == "" {
+		return nil, errors.New("filter ID is required")
+	}
+
+	resp, err := c.client.Get(ctx, joinPath(filtersPath, filterID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeData[SavedFilter](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *filtersClient) Execute(ctx context.Context, filterID interface{}, limit, page int) (*IssueListResponse, error) {
+	if filterID == nil || filterID == "" {
+		return nil, errors.New("filter ID is required")
+	}
+
+	params := map[string]string{}
+	if limit > 0 {
+		params["limit"] = strconv.Itoa(limit)
+	}
+	if page > 0 {
+		params["page"] = strconv.Itoa(page)
+	}
+
+	resp, err := c.client.Get(ctx, joinPath(filtersPath, filterID, "issues"), &RequestOptions{Params: params})
+	if err != nil {
+		return nil, err
+	}
+
+	var result IssueListResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,56 @@
+package kiket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFiltersClient_BuildsRequests(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/issues"):
+			w.Write([]byte(`{"data": [{"id": 1, "key": "OPS-1"}, {"id": 2, "key": "OPS-2"}]}`))
+		case r.URL.Path == "/api/v1/ext/filters":
+			w.Write([]byte(`{"data": [{"id": 3, "name": "My open bugs", "shared": true}]}`))
+		default:
+			w.Write([]byte(`{"data": {"id": 3, "name": "My open bugs", "query": "type:bug state:open", "criteria": {"state": "open"}, "sort": "-priority"}}`))
+		}
+	}))
+	defer server.Close()
+
+	filters := NewFiltersClient(NewHTTPClient(WithBaseURL(server.URL)))
+	ctx := context.Background()
+
+	list, err := filters.List(ctx, 7)
+	if err != nil || len(list) != 1 || !list[0].Shared {
+		t.Fatalf("Unexpected list result %+v (%v)", list, err)
+	}
+	filter, err := filters.Get(ctx, 3)
+	if err != nil || filter.Query != "type:bug state:open" || filter.Criteria["state"] != "open" || filter.Sort != "-priority" {
+		t.Fatalf("Unexpected filter %+v (%v)", filter, err)
+	}
+	issues, err := filters.Execute(ctx, 3, 25, 2)
+	if err != nil || len(issues.Data) != 2 || issues.Data[1].Key != "OPS-2" {
+		t.Fatalf("Unexpected issues %+v (%v)", issues, err)
+	}
+
+	expected := []string{
+		"/api/v1/ext/filters?project_id=7",
+		"/api/v1/ext/filters/3",
+		"/api/v1/ext/filters/3/issues?limit=25&page=2",
+	}
+	for i, want := range expected {
+		if requests[i] != want {
+			t.Errorf("Expected request %q, got %q", want, requests[i])
+		}
+	}
+
+	if _, err := filters.Execute(ctx, nil, 0, 0); err == nil {
+		t.Error("Expected error when filter ID is missing")
+	}
+}
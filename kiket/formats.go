@@ -0,0 +1,116 @@
+package kiket
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"unicode/utf8"
+)
+
+// PayloadFormat is a binary serialization the SDK accepts in place of JSON
+// for webhook bodies and API responses. Payloads are transcoded to JSON on
+// arrival, so handlers and API clients see the same data either way; the
+// gain is in transfer size and in skipping a JSON encode on Kiket's side.
+type PayloadFormat interface {
+	// ContentType is the media type, e.g. "application/msgpack".
+	ContentType() string
+	// AppendJSON transcodes data to JSON and appends it to dst.
+	AppendJSON(dst, data []byte) ([]byte, error)
+}
+
+// maxFormatDepth bounds nesting when transcoding, so hostile input cannot
+// exhaust the stack.
+const maxFormatDepth = 512
+
+var errFormatTruncated = errors.New("truncated payload")
+
+// WithPayloadFormats accepts API responses in formats, most preferred
+// first, with JSON as the fallback. Requests are always sent as JSON.
+func WithPayloadFormats(formats ...PayloadFormat) ClientOption {
+	return func(c *HTTPClient) {
+		c.formats = append(c.formats, formats...)
+	}
+}
+
+// acceptHeader lists formats by preference, followed by JSON.
+func acceptHeader(formats []PayloadFormat) string {
+	if len(formats) == 0 {
+		return "application/json"
+	}
+
+	var b strings.Builder
+	for i, format := range formats {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(format.ContentType())
+		if i > 0 && i < 9 {
+			fmt.Fprintf(&b, ";q=0.%d", 10-i)
+		}
+	}
+	b.WriteString(", application/json;q=0.1")
+	return b.String()
+}
+
+// findFormat returns the format for a Content-Type header value, or nil for
+// JSON and unknown types.
+func findFormat(formats []PayloadFormat, contentType string) PayloadFormat {
+	if len(formats) == 0 || contentType == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil
+	}
+	for _, format := range formats {
+		if strings.EqualFold(format.ContentType(), mediaType) {
+			return format
+		}
+	}
+	return nil
+}
+
+// transcode converts data in format to JSON.
+func transcode(format PayloadFormat, data []byte) ([]byte, error) {
+	out, err := format.AppendJSON(make([]byte, 0, len(data)*2), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s payload: %w", format.ContentType(), err)
+	}
+	return out, nil
+}
+
+// appendJSONString appends s as a quoted JSON string.
+func appendJSONString(dst []byte, s []byte) []byte {
+	const hex = "0123456789abcdef"
+	dst = append(dst, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRune(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				dst = append(dst, "\\ufffd"...)
+			} else {
+				dst = append(dst, s[i:i+size]...)
+			}
+			i += size
+			continue
+		}
+		switch {
+		case c == '"' || c == '\\':
+			dst = append(dst, '\\', c)
+		case c == '\n':
+			dst = append(dst, '\\', 'n')
+		case c == '\r':
+			dst = append(dst, '\\', 'r')
+		case c == '\t':
+			dst = append(dst, '\\', 't')
+		case c < 0x20:
+			dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+		default:
+			dst = append(dst, c)
+		}
+		i++
+	}
+	return append(dst, '"')
+}
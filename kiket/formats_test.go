@@ -0,0 +1,119 @@
+package kiket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func cat(parts ...interface{}) []byte {
+	var out []byte
+	for _, p := range parts {
+		switch p := p.(type) {
+		case int:
+			out = append(out, byte(p))
+		case string:
+			out = append(out, p...)
+		}
+	}
+	return out
+}
+
+func TestMsgPackFormat_AppendJSON(t *testing.T) {
+	data := cat(0x82,
+		0xa5, "event", 0xad, "issue.created",
+		0xa5, "issue", 0x87,
+		0xa2, "id", 0x01,
+		0xa4, "tags", 0x91, 0xa1, "a",
+		0xa5, "score", 0xfe,
+		0xa2, "ok", 0xc3,
+		0xa3, "bin", 0xc4, 0x02, 0x01, 0x02,
+		0xa3, "big", 0xcd, 0x01, 0x00,
+		0xa1, "f", 0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0,
+	)
+	got, err := MsgPackFormat().AppendJSON(nil, data)
+	if err != nil {
+		t.Fatalf("AppendJSON failed: %v", err)
+	}
+	want := `{"event":"issue.created","issue":{"id":1,"tags":["a"],"score":-2,"ok":true,"bin":"AQI=","big":256,"f":1.5}}`
+	if string(got) != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	if _, err := MsgPackFormat().AppendJSON(nil, data[:len(data)-3]); err == nil {
+		t.Error("Expected error for truncated input")
+	}
+}
+
+func TestCBORFormat_AppendJSON(t *testing.T) {
+	data := cat(0xa2,
+		0x65, "event", 0x6d, "issue.created",
+		0x65, "issue", 0xa6,
+		0x62, "id", 0x01,
+		0x64, "tags", 0x9f, 0x61, "a", 0xff,
+		0x65, "score", 0x21,
+		0x61, "n", 0xf6,
+		0x61, "f", 0xf9, 0x3e, 0x00,
+		0x63, "big", 0xc1, 0x19, 0x01, 0x00,
+	)
+	got, err := CBORFormat().AppendJSON(nil, data)
+	if err != nil {
+		t.Fatalf("AppendJSON failed: %v", err)
+	}
+	want := `{"event":"issue.created","issue":{"id":1,"tags":["a"],"score":-2,"n":null,"f":1.5,"big":256}}`
+	if string(got) != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestSDK_HandleWebhookMsgPack(t *testing.T) {
+	sdk, err := New(Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+		PayloadFormats:  []PayloadFormat{MsgPackFormat()},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	var id interface{}
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		id = payload["issue"].(map[string]interface{})["id"]
+		return nil, nil
+	})
+
+	body := cat(0x82, 0xa5, "event", 0xad, "issue.created", 0xa5, "issue", 0x81, 0xa2, "id", 0x07)
+	signature, timestamp := GenerateSignature("secret", string(body), nil)
+	headers := Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp, "Content-Type": "application/msgpack"}
+	if _, err := sdk.HandleWebhook(context.Background(), body, headers); err != nil {
+		t.Fatalf("HandleWebhook failed: %v", err)
+	}
+	if id != float64(7) {
+		t.Errorf("Expected issue id 7, got %v", id)
+	}
+}
+
+func TestHTTPClient_NegotiatesPayloadFormat(t *testing.T) {
+	var accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		w.Header().Set("Content-Type", "application/cbor")
+		w.Write(cat(0xa1, 0x64, "data", 0xa2, 0x62, "id", 0x18, 42, 0x63, "key", 0x66, "OPS-42"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(WithBaseURL(server.URL), WithPayloadFormats(CBORFormat()))
+	issue, err := NewIssuesClient(client).Get(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if issue.Key != "OPS-42" {
+		t.Errorf("Expected OPS-42, got %s", issue.Key)
+	}
+	if accept != "application/cbor, application/json;q=0.1" {
+		t.Errorf("Unexpected Accept header %q", accept)
+	}
+}
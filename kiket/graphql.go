@@ -0,0 +1,132 @@
+package kiket
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const graphqlPath = "/api/v1/graphql"
+
+// persistedQueryNotFound is the error code returned when the server does not
+// know a persisted query hash yet.
+const persistedQueryNotFound = "PERSISTED_QUERY_NOT_FOUND"
+
+// GraphQLError is a single error from a GraphQL response.
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Code returns the error code from the extensions, e.g. "NOT_FOUND" or
+// "FORBIDDEN".
+func (e GraphQLError) Code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+// GraphQLErrors is returned when a GraphQL response contains errors. Any
+// partial data is still decoded into out.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+// HasCode reports whether any error carries the given code.
+func (e GraphQLErrors) HasCode(code string) bool {
+	for _, err := range e {
+		if err.Code() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// WithPersistedQueries sends GraphQL queries by SHA-256 hash first and only
+// includes the full query text when the server does not know the hash.
+func WithPersistedQueries() ClientOption {
+	return func(c *HTTPClient) {
+		c.persistedQueries = true
+	}
+}
+
+// GraphQL runs a query against the workspace GraphQL endpoint and decodes
+// the data into out.
+func (c *HTTPClient) GraphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	return doGraphQL(ctx, c, c.persistedQueries, query, variables, out)
+}
+
+// GraphQL runs a query against the workspace GraphQL endpoint and decodes
+// the data into out.
+func (e *Endpoints) GraphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	if c, ok := e.client.(*HTTPClient); ok {
+		return c.GraphQL(ctx, query, variables, out)
+	}
+	return doGraphQL(ctx, e.client, false, query, variables, out)
+}
+
+func doGraphQL(ctx context.Context, client Client, persisted bool, query string, variables map[string]interface{}, out interface{}) error {
+	if query == "" {
+		return errors.New("graphql query is required")
+	}
+
+	body := map[string]interface{}{}
+	if len(variables) > 0 {
+		body["variables"] = variables
+	}
+
+	if persisted {
+		sum := sha256.Sum256([]byte(query))
+		body["extensions"] = map[string]interface{}{
+			"persistedQuery": map[string]interface{}{
+				"version":    1,
+				"sha256Hash": hex.EncodeToString(sum[:]),
+			},
+		}
+
+		err := postGraphQL(ctx, client, body, out)
+		var gqlErrs GraphQLErrors
+		if !errors.As(err, &gqlErrs) || !gqlErrs.HasCode(persistedQueryNotFound) {
+			return err
+		}
+		// Register the query by sending it along with the hash
+	}
+
+	body["query"] = query
+	return postGraphQL(ctx, client, body, out)
+}
+
+func postGraphQL(ctx context.Context, client Client, body map[string]interface{}, out interface{}) error {
+	resp, err := client.Post(ctx, graphqlPath, body, nil)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if out != nil && len(result.Data) > 0 && string(result.Data) != "null" {
+		if err := json.Unmarshal(result.Data, out); err != nil {
+			return fmt.Errorf("failed to decode graphql data: %w", err)
+		}
+	}
+	if len(result.Errors) > 0 {
+		return result.Errors
+	}
+	return nil
+}
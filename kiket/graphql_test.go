@@ -0,0 +1,118 @@
+package kiket
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const testGraphQLQuery = "query($key: String!) { issue(key: $key) { key title } }"
+
+type testGraphQLIssue struct {
+	Issue struct {
+		Key   string `json:"key"`
+		Title string `json:"title"`
+	} `json:"issue"`
+}
+
+func TestGraphQL_PostsQueryAndDecodesData(t *testing.T) {
+	var requests []string
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+		w.Write([]byte(`{"data": {"issue": {"key": "OPS-1", "title": "Fix login"}}}`))
+	}))
+	defer server.Close()
+
+	endpoints := NewEndpoints(NewHTTPClient(WithBaseURL(server.URL)), "com.example.ext", "v1")
+	var out testGraphQLIssue
+	if err := endpoints.GraphQL(context.Background(), testGraphQLQuery, map[string]interface{}{"key": "OPS-1"}, &out); err != nil {
+		t.Fatalf("GraphQL failed: %v", err)
+	}
+	if out.Issue.Key != "OPS-1" || out.Issue.Title != "Fix login" {
+		t.Errorf("Unexpected data %+v", out)
+	}
+
+	if len(requests) != 1 || requests[0] != "POST /api/v1/graphql" {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+	expected := map[string]interface{}{"query": testGraphQLQuery, "variables": map[string]interface{}{"key": "OPS-1"}}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("Expected body %v, got %v", expected, body)
+	}
+
+	if err := endpoints.GraphQL(context.Background(), "", nil, &out); err == nil {
+		t.Error("Expected error for an empty query")
+	}
+}
+
+func TestGraphQL_ReturnsErrorsWithPartialData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"issue": {"key": "OPS-1"}}, "errors": [{"message": "title is hidden", "path": ["issue", "title"], "extensions": {"code": "FORBIDDEN"}}]}`))
+	}))
+	defer server.Close()
+
+	var out testGraphQLIssue
+	err := NewHTTPClient(WithBaseURL(server.URL)).GraphQL(context.Background(), testGraphQLQuery, nil, &out)
+
+	var gqlErrs GraphQLErrors
+	if !errors.As(err, &gqlErrs) || len(gqlErrs) != 1 || !gqlErrs.HasCode("FORBIDDEN") || gqlErrs.HasCode("NOT_FOUND") {
+		t.Fatalf("Expected a FORBIDDEN GraphQL error, got %v", err)
+	}
+	if err.Error() != "graphql: title is hidden" {
+		t.Errorf("Unexpected message %q", err.Error())
+	}
+	if out.Issue.Key != "OPS-1" {
+		t.Errorf("Expected partial data to be decoded, got %+v", out)
+	}
+}
+
+func TestGraphQL_PersistedQueriesRegisterUnknownHash(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+		bodies = append(bodies, body)
+
+		if _, ok := body["query"]; !ok {
+			w.Write([]byte(`{"errors": [{"message": "PersistedQueryNotFound", "extensions": {"code": "PERSISTED_QUERY_NOT_FOUND"}}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"issue": {"key": "OPS-1", "title": "Fix login"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(WithBaseURL(server.URL), WithPersistedQueries())
+	var out testGraphQLIssue
+	if err := client.GraphQL(context.Background(), testGraphQLQuery, nil, &out); err != nil {
+		t.Fatalf("GraphQL failed: %v", err)
+	}
+	if out.Issue.Title != "Fix login" {
+		t.Errorf("Unexpected data %+v", out)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("Expected a hash-only request and a registering retry, got %d requests", len(bodies))
+	}
+	sum := sha256.Sum256([]byte(testGraphQLQuery))
+	extensions := map[string]interface{}{"persistedQuery": map[string]interface{}{
+		"version":    float64(1),
+		"sha256Hash": hex.EncodeToString(sum[:]),
+	}}
+	if !reflect.DeepEqual(bodies[0], map[string]interface{}{"extensions": extensions}) {
+		t.Errorf("Expected only the hash in the first request, got %v", bodies[0])
+	}
+	if !reflect.DeepEqual(bodies[1], map[string]interface{}{"extensions": extensions, "query": testGraphQLQuery}) {
+		t.Errorf("Expected the hash and query in the retry, got %v", bodies[1])
+	}
+}
@@ -0,0 +1,200 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GRPCInvoker makes unary calls to a self-hosted Kiket gRPC gateway using
+// its JSON codec. The SDK does not depend on a gRPC library; wrap a
+// grpc.ClientConn dialled with the deployment's target and credentials, as
+// shown in the README.
+type GRPCInvoker interface {
+	// Invoke calls the full method name (e.g. "/kiket.v1.Secrets/GetSecret")
+	// with a JSON request message and returns the JSON response message.
+	// md holds the headers the HTTP transport would send (authentication,
+	// act-as, idempotency keys, ...) with lower-case keys, ready for
+	// metadata.New.
+	Invoke(ctx context.Context, method string, md map[string]string, req json.RawMessage) (json.RawMessage, error)
+}
+
+// GRPCInvokerFunc adapts a function to the GRPCInvoker interface.
+type GRPCInvokerFunc func(ctx context.Context, method string, md map[string]string, req json.RawMessage) (json.RawMessage, error)
+
+// Invoke calls f.
+func (f GRPCInvokerFunc) Invoke(ctx context.Context, method string, md map[string]string, req json.RawMessage) (json.RawMessage, error) {
+	return f(ctx, method, md, req)
+}
+
+// GRPCError carries a gRPC status from an invoker. HTTPClient converts it
+// to an *APIError with the equivalent HTTP status, so callers handle both
+// transports alike.
+type GRPCError struct {
+	Code    int // google.golang.org/grpc/codes value
+	Message string
+}
+
+func (e *GRPCError) Error() string {
+	return fmt.Sprintf("gRPC error (code %d): %s", e.Code, e.Message)
+}
+
+// httpStatus maps a gRPC status code to its HTTP equivalent.
+func (e *GRPCError) httpStatus() int {
+	switch e.Code {
+	case 3, 9, 11: // InvalidArgument, FailedPrecondition, OutOfRange
+		return http.StatusBadRequest
+	case 5: // NotFound
+		return http.StatusNotFound
+	case 6, 10: // AlreadyExists, Aborted
+		return http.StatusConflict
+	case 7: // PermissionDenied
+		return http.StatusForbidden
+	case 8: // ResourceExhausted
+		return http.StatusTooManyRequests
+	case 12: // Unimplemented
+		return http.StatusNotImplemented
+	case 14: // Unavailable
+		return http.StatusServiceUnavailable
+	case 4: // DeadlineExceeded
+		return http.StatusGatewayTimeout
+	case 16: // Unauthenticated
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// WithGRPC routes the operations the gRPC gateway supports (secrets, custom
+// data, and extension events) through invoker. Everything else keeps
+// using HTTP, and the Client interface is unchanged. Calls carry the same
+// credentials and headers as over HTTP.
+//
+// WithGRPC takes an invoker rather than a target and transport credentials
+// so that the SDK does not pull grpc-go into every extension's build; the
+// caller dials the connection and adapts it, as shown in the README.
+func WithGRPC(invoker GRPCInvoker) ClientOption {
+	return func(c *HTTPClient) {
+		c.grpc = invoker
+	}
+}
+
+// grpcRoute maps a REST operation onto a gateway method. Pattern segments
+// in braces become request fields.
+type grpcRoute struct {
+	method  string
+	pattern []string
+	rpc     string
+}
+
+var grpcRoutes = compileGRPCRoutes([][3]string{
+	{http.MethodGet, "/api/v1/extensions/{extension_id}/secrets", "/kiket.v1.Secrets/ListSecrets"},
+	{http.MethodGet, "/api/v1/extensions/{extension_id}/secrets/{key}", "/kiket.v1.Secrets/GetSecret"},
+	{http.MethodPost, "/api/v1/extensions/{extension_id}/secrets/{key}", "/kiket.v1.Secrets/SetSecret"},
+	{http.MethodDelete, "/api/v1/extensions/{extension_id}/secrets/{key}", "/kiket.v1.Secrets/DeleteSecret"},
+	{http.MethodGet, "/api/v1/ext/custom_data/{module}/{table}", "/kiket.v1.CustomData/ListRecords"},
+	{http.MethodPost, "/api/v1/ext/custom_data/{module}/{table}", "/kiket.v1.CustomData/CreateRecord"},
+	{http.MethodGet, "/api/v1/ext/custom_data/{module}/{table}/{id}", "/kiket.v1.CustomData/GetRecord"},
+	{http.MethodPatch, "/api/v1/ext/custom_data/{module}/{table}/{id}", "/kiket.v1.CustomData/UpdateRecord"},
+	{http.MethodDelete, "/api/v1/ext/custom_data/{module}/{table}/{id}", "/kiket.v1.CustomData/DeleteRecord"},
+	{http.MethodPost, "/api/v1/extensions/{extension_id}/events", "/kiket.v1.Events/LogEvent"},
+})
+
+func compileGRPCRoutes(defs [][3]string) []grpcRoute {
+	routes := make([]grpcRoute, len(defs))
+	for i, def := range defs {
+		routes[i] = grpcRoute{
+			method:  def[0],
+			pattern: strings.Split(strings.TrimPrefix(def[1], "/"), "/"),
+			rpc:     def[2],
+		}
+	}
+	return routes
+}
+
+// matchGRPCRoute returns the gateway method for a REST call and the values
+// of its path fields.
+func matchGRPCRoute(method, path string) (string, map[string]interface{}, bool) {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for _, route := range grpcRoutes {
+		if route.method != method || len(route.pattern) != len(segments) {
+			continue
+		}
+		fields := map[string]interface{}{}
+		matched := true
+		for i, part := range route.pattern {
+			if strings.HasPrefix(part, "{") {
+				value, err := url.PathUnescape(segments[i])
+				if err != nil {
+					matched = false
+					break
+				}
+				fields[strings.Trim(part, "{}")] = value
+			} else if part != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return route.rpc, fields, true
+		}
+	}
+	return "", nil, false
+}
+
+// invokeGRPC sends a REST-shaped call through the gateway. The request
+// message combines path fields, query parameters, and the body's fields;
+// the response message has the same shape as the REST response.
+func (c *HTTPClient) invokeGRPC(ctx context.Context, rpc string, fields map[string]interface{}, body interface{}, opts *RequestOptions) ([]byte, error) {
+	if opts != nil {
+		for k, v := range opts.Params {
+			fields[k] = v
+		}
+		if opts.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+			defer cancel()
+		}
+	}
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		var bodyFields map[string]interface{}
+		if err := json.Unmarshal(encoded, &bodyFields); err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		for k, v := range bodyFields {
+			fields[k] = v
+		}
+	}
+
+	req, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	header := http.Header{}
+	if err := c.setHeaders(ctx, header, opts); err != nil {
+		return nil, err
+	}
+	md := make(map[string]string, len(header))
+	for k := range header {
+		md[strings.ToLower(k)] = header.Get(k)
+	}
+
+	resp, err := c.grpc.Invoke(ctx, rpc, md, req)
+	if err != nil {
+		var grpcErr *GRPCError
+		if errors.As(err, &grpcErr) {
+			return nil, &APIError{StatusCode: grpcErr.httpStatus(), Body: grpcErr.Message, Message: grpcErr.Message}
+		}
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	return resp, nil
+}
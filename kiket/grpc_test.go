@@ -0,0 +1,102 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPClient_RoutesSupportedOperationsOverGRPC(t *testing.T) {
+	type call struct {
+		Method string
+		Req    map[string]interface{}
+	}
+	var calls []call
+	invoker := GRPCInvokerFunc(func(ctx context.Context, method string, md map[string]string, req json.RawMessage) (json.RawMessage, error) {
+		var fields map[string]interface{}
+		json.Unmarshal(req, &fields)
+		calls = append(calls, call{method, fields})
+		switch method {
+		case "/kiket.v1.Secrets/GetSecret":
+			if fields["key"] == "missing" {
+				return nil, &GRPCError{Code: 5, Message: "not found"}
+			}
+			return json.RawMessage(`{"value":"s3cret"}`), nil
+		case "/kiket.v1.CustomData/CreateRecord":
+			return json.RawMessage(`{"data":{"id":9}}`), nil
+		}
+		return json.RawMessage(`{}`), nil
+	})
+
+	var httpPaths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpPaths = append(httpPaths, r.URL.Path)
+		w.Write([]byte(`{"data":{"id":1,"key":"OPS-1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(WithBaseURL(server.URL), WithGRPC(invoker))
+	ctx := context.Background()
+
+	secrets := NewSecretManager(client, "com.example.ext")
+	if value, err := secrets.Get(ctx, "team/token"); err != nil || value != "s3cret" {
+		t.Errorf("Expected s3cret, got %q (%v)", value, err)
+	}
+	if value, err := secrets.Get(ctx, "missing"); err != nil || value != "" {
+		t.Errorf("Expected NotFound to read as an empty secret, got %q (%v)", value, err)
+	}
+
+	record, err := NewCustomDataClient(client, 7).Create(ctx, "crm", "contacts", map[string]interface{}{"email": "a@b.c"})
+	if err != nil || record.Data["id"] != float64(9) {
+		t.Errorf("Unexpected record %+v (%v)", record, err)
+	}
+
+	if _, err := NewIssuesClient(client).Get(ctx, 1); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	if len(calls) != 3 {
+		t.Fatalf("Expected 3 gRPC calls, got %d", len(calls))
+	}
+	if calls[0].Req["extension_id"] != "com.example.ext" || calls[0].Req["key"] != "team/token" {
+		t.Errorf("Unexpected GetSecret request %v", calls[0].Req)
+	}
+	create := calls[2].Req
+	if create["module"] != "crm" || create["table"] != "contacts" || create["project_id"] != "7" || create["record"] == nil {
+		t.Errorf("Unexpected CreateRecord request %v", create)
+	}
+	if len(httpPaths) != 1 || httpPaths[0] != "/api/v1/ext/issues/1" {
+		t.Errorf("Expected only the issues call over HTTP, got %v", httpPaths)
+	}
+}
+
+func TestHTTPClient_GRPCCallsCarryHeadersAsMetadata(t *testing.T) {
+	var got map[string]string
+	invoker := GRPCInvokerFunc(func(ctx context.Context, method string, md map[string]string, req json.RawMessage) (json.RawMessage, error) {
+		got = md
+		return json.RawMessage(`{}`), nil
+	})
+
+	client := NewHTTPClient(WithAPIKey("ext-key"), WithRuntimeToken("rt-token"), WithGRPC(invoker))
+	opts := &RequestOptions{Headers: map[string]string{"Idempotency-Key": "evt-1"}}
+	if _, err := client.Post(context.Background(), "/api/v1/extensions/com.example.ext/events", map[string]interface{}{"event": "synced"}, opts); err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"x-kiket-api-key":       "ext-key",
+		"x-kiket-runtime-token": "rt-token",
+		"idempotency-key":       "evt-1",
+	}
+	for k, want := range expected {
+		if got[k] != want {
+			t.Errorf("Expected metadata %s=%q, got %q", k, want, got[k])
+		}
+	}
+	if got[strings.ToLower(sdkVersionHeader)] == "" {
+		t.Errorf("Expected the SDK version in metadata, got %v", got)
+	}
+}
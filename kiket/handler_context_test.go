@@ -1,7 +1,9 @@
 package kiket
 
 import (
+	"context"
 	"os"
+	"reflect"
 	"testing"
 )
 
@@ -77,16 +79,13 @@ func TestHandlerContext_Secret_NilPayloadSecrets(t *testing.T) {
 	}
 }
 
-func TestExtractPayloadSecrets(t *testing.T) {
-	payload := WebhookPayload{
-		"event_type": "test",
-		"secrets": map[string]interface{}{
-			"API_KEY":    "secret-key",
-			"API_SECRET": "secret-value",
-		},
+func TestRawPayload_Secrets(t *testing.T) {
+	payload, err := parseRawPayload([]byte(`{"event_type":"test","secrets":{"API_KEY":"secret-key","API_SECRET":"secret-value"}}`))
+	if err != nil {
+		t.Fatalf("parseRawPayload failed: %v", err)
 	}
 
-	secrets := extractPayloadSecrets(payload)
+	secrets := payload.secrets()
 
 	if secrets["API_KEY"] != "secret-key" {
 		t.Errorf("Expected secret-key, got %s", secrets["API_KEY"])
@@ -96,27 +95,125 @@ func TestExtractPayloadSecrets(t *testing.T) {
 	}
 }
 
-func TestExtractPayloadSecrets_NoSecrets(t *testing.T) {
-	payload := WebhookPayload{
-		"event_type": "test",
+func TestRawPayload_SecretsMissing(t *testing.T) {
+	payload, err := parseRawPayload([]byte(`{"event_type":"test"}`))
+	if err != nil {
+		t.Fatalf("parseRawPayload failed: %v", err)
 	}
 
-	secrets := extractPayloadSecrets(payload)
+	secrets := payload.secrets()
 
 	if secrets != nil {
 		t.Errorf("Expected nil, got %v", secrets)
 	}
 }
 
-func TestExtractPayloadSecrets_InvalidSecretsType(t *testing.T) {
-	payload := WebhookPayload{
-		"event_type": "test",
-		"secrets":    "not-a-map",
+func TestRawPayload_SecretsInvalidType(t *testing.T) {
+	payload, err := parseRawPayload([]byte(`{"event_type":"test","secrets":"not-a-map"}`))
+	if err != nil {
+		t.Fatalf("parseRawPayload failed: %v", err)
 	}
 
-	secrets := extractPayloadSecrets(payload)
+	secrets := payload.secrets()
 
 	if secrets != nil {
 		t.Errorf("Expected nil for invalid type, got %v", secrets)
 	}
 }
+
+func TestSDK_OnRawDecodesOnDemand(t *testing.T) {
+	sdk, err := New(Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	var title, token string
+	sdk.OnRaw("issue.created", func(ctx context.Context, payload *RawPayload, hctx *HandlerContext) (interface{}, error) {
+		issue, err := payload.Issue()
+		if err != nil {
+			return nil, err
+		}
+		title = issue.Title
+		token = hctx.Secret("API_TOKEN")
+		return nil, nil
+	})
+
+	body := `{"event":"issue.created","issue":{"id":1,"title":"Bug"},"secrets":{"API_TOKEN":"t"}}`
+	signature, timestamp := GenerateSignature("secret", body, nil)
+	headers := Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp}
+
+	if _, err := sdk.HandleWebhook(context.Background(), []byte(body), headers); err != nil {
+		t.Fatalf("HandleWebhook failed: %v", err)
+	}
+	if title != "Bug" {
+		t.Errorf("Expected Bug, got %s", title)
+	}
+	if token != "t" {
+		t.Errorf("Expected payload secret t, got %s", token)
+	}
+}
+
+func TestRawPayload_DecodesOnce(t *testing.T) {
+	raw, err := parseRawPayload([]byte(`{"event":"issue.created","issue":{"id":1,"title":"Bug","labels":["a"]}}`))
+	if err != nil {
+		t.Fatalf("parseRawPayload failed: %v", err)
+	}
+
+	first, err := raw.Issue()
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	second, _ := raw.Issue()
+	if &first.Labels[0] != &second.Labels[0] {
+		t.Error("Expected the cached decode to be reused")
+	}
+
+	m1, _ := raw.Map()
+	m2, _ := raw.Map()
+	if reflect.ValueOf(m1).Pointer() != reflect.ValueOf(m2).Pointer() {
+		t.Error("Expected Map to return the cached map")
+	}
+}
+
+func TestSDK_OnVersionRanges(t *testing.T) {
+	sdk, err := New(Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	handled := map[string]string{}
+	handler := func(name string) WebhookHandler {
+		return func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+			handled[hctx.EventVersion] = name
+			return nil, nil
+		}
+	}
+	sdk.On("issue.created", handler("legacy"), "v1", "v1.5")
+	sdk.On("issue.created", handler("current"), ">=v2,<v4")
+	sdk.On("issue.created", handler("v3 override"), "v3")
+
+	body := `{"event":"issue.created"}`
+	for _, version := range []string{"v1", "v1.5", "v2", "v2.1", "v3", "v4"} {
+		signature, timestamp := GenerateSignature("secret", body, nil)
+		headers := Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp, "X-Kiket-Event-Version": version}
+		_, err := sdk.HandleWebhook(context.Background(), []byte(body), headers)
+		if (err != nil) != (version == "v4") {
+			t.Errorf("Version %s: unexpected error %v", version, err)
+		}
+	}
+
+	expected := map[string]string{"v1": "legacy", "v1.5": "legacy", "v2": "current", "v2.1": "current", "v3": "v3 override"}
+	if !reflect.DeepEqual(handled, expected) {
+		t.Errorf("Expected %v, got %v", expected, handled)
+	}
+}
@@ -0,0 +1,140 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// Health states reported to Kiket's extension health dashboard.
+const (
+	HealthHealthy   = "healthy"
+	HealthDegraded  = "degraded"
+	HealthUnhealthy = "unhealthy"
+)
+
+const healthReportTimeout = 10 * time.Second
+
+// HealthStatus is an extension's self-reported health, shown to workspace
+// admins on Kiket's extension health dashboard.
+type HealthStatus struct {
+	// HealthHealthy, HealthDegraded, or HealthUnhealthy
+	Status string `json:"status"`
+	// Optional explanation shown with the status
+	Message string `json:"message,omitempty"`
+	// Deliveries accepted but not yet processed
+	QueueDepth int `json:"queue_depth"`
+	// Deliveries handled in the reporting window
+	Deliveries int64 `json:"deliveries"`
+	// Share of the window's deliveries whose handler failed (0-1)
+	ErrorRate float64 `json:"error_rate"`
+	// When a handler last succeeded (nil if none has yet)
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	// Extension-specific details, e.g. upstream service reachability
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// ReportHealth reports the extension's health to Kiket. Set
+// Config.HealthInterval to report automatically.
+func (e *Endpoints) ReportHealth(ctx context.Context, status HealthStatus) error {
+	if e.extensionID == "" {
+		return errors.New("extension ID required for reporting health")
+	}
+	if status.Status == "" {
+		status.Status = HealthHealthy
+	}
+
+	_, err := e.client.Post(ctx, e.basePath+"/health", status, nil)
+	return err
+}
+
+// deliveryStats counts handler outcomes for health reports.
+type deliveryStats struct {
+	total       atomic.Int64
+	failures    atomic.Int64
+	lastSuccess atomic.Int64 // unix nanoseconds, 0 before the first success
+
+	// totals at the previous automatic report
+	reportedTotal    atomic.Int64
+	reportedFailures atomic.Int64
+}
+
+func (d *deliveryStats) record(err error, now time.Time) {
+	d.total.Add(1)
+	if err != nil {
+		d.failures.Add(1)
+		return
+	}
+	d.lastSuccess.Store(now.UnixNano())
+}
+
+// HealthStatus returns the SDK's health since the previous automatic
+// report: queue depth, last successful delivery, and handler error rate.
+// A window with half or more failed deliveries is unhealthy; one with more
+// than 10% failures, or a queue over 80% full, is degraded.
+func (s *SDK) HealthStatus() HealthStatus {
+	status, _, _ := s.healthStatus()
+	return status
+}
+
+// healthStatus also returns the delivery and failure totals the status
+// covers, which the reporter stores once the report is accepted.
+func (s *SDK) healthStatus() (HealthStatus, int64, int64) {
+	stats := &s.deliveryStats
+	total, failed := stats.total.Load(), stats.failures.Load()
+	deliveries := total - stats.reportedTotal.Load()
+	failures := failed - stats.reportedFailures.Load()
+
+	status := HealthStatus{Status: HealthHealthy, Deliveries: deliveries}
+	if deliveries > 0 {
+		status.ErrorRate = float64(failures) / float64(deliveries)
+	}
+	if last := stats.lastSuccess.Load(); last != 0 {
+		t := time.Unix(0, last).UTC()
+		status.LastSuccessAt = &t
+	}
+	if s.queue != nil {
+		status.QueueDepth = s.queue.depth()
+	}
+
+	switch {
+	case status.ErrorRate >= 0.5:
+		status.Status = HealthUnhealthy
+		status.Message = "most deliveries are failing"
+	case status.ErrorRate > 0.1:
+		status.Status = HealthDegraded
+		status.Message = "elevated delivery error rate"
+	case s.queue != nil && status.QueueDepth*5 > cap(s.queue.deliveries)*4:
+		status.Status = HealthDegraded
+		status.Message = "delivery queue is nearly full"
+	}
+	return status, total, failed
+}
+
+// healthLoop reports HealthStatus every interval until done is closed.
+func (s *SDK) healthLoop(interval time.Duration, done <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		status, total, failed := s.healthStatus()
+		ctx, cancel := context.WithTimeout(context.Background(), healthReportTimeout)
+		err := s.endpoints.ReportHealth(ctx, status)
+		cancel()
+		if err != nil {
+			s.config.Logger.Printf("kiket: health report failed: %v", err)
+			continue
+		}
+		s.deliveryStats.reportedTotal.Store(total)
+		s.deliveryStats.reportedFailures.Store(failed)
+	}
+}
@@ -0,0 +1,79 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSDK_HealthReporting(t *testing.T) {
+	reports := make(chan HealthStatus, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/extensions/com.example.ext/health" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		var status HealthStatus
+		json.NewDecoder(r.Body).Decode(&status)
+		reports <- status
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	sdk, err := New(Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+		BaseURL:         server.URL,
+		HealthInterval:  20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	fail := true
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		if fail {
+			return nil, errors.New("boom")
+		}
+		return nil, nil
+	})
+	deliver := func() {
+		body := `{"event":"issue.created"}`
+		signature, timestamp := GenerateSignature("secret", body, nil)
+		sdk.HandleWebhook(context.Background(), []byte(body), Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp})
+	}
+
+	deliver()
+	deliver()
+	fail = false
+	deliver()
+
+	status := sdk.HealthStatus()
+	if status.Status != HealthUnhealthy || status.Deliveries != 3 || status.LastSuccessAt == nil {
+		t.Errorf("Unexpected status %+v", status)
+	}
+
+	select {
+	case report := <-reports:
+		if report.Status != HealthUnhealthy || report.Deliveries != 3 {
+			t.Errorf("Unexpected report %+v", report)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a health report")
+	}
+
+	// The next window starts empty.
+	select {
+	case report := <-reports:
+		if report.Status != HealthHealthy || report.Deliveries != 0 || report.LastSuccessAt == nil {
+			t.Errorf("Unexpected report %+v", report)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a second health report")
+	}
+}
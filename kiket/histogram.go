@@ -0,0 +1,125 @@
+package kiket
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxHistogramSamples bounds memory per event; beyond it durations are
+// reservoir-sampled so percentiles stay representative.
+const maxHistogramSamples = 2048
+
+// DurationHistogram summarizes handler durations for one event and version
+// over a reporting period.
+type DurationHistogram struct {
+	Event         string  `json:"event"`
+	Version       string  `json:"version"`
+	Count         int64   `json:"count"`
+	Errors        int64   `json:"errors"`
+	P50Ms         float64 `json:"p50_ms"`
+	P95Ms         float64 `json:"p95_ms"`
+	P99Ms         float64 `json:"p99_ms"`
+	MaxMs         float64 `json:"max_ms"`
+	PeriodSeconds float64 `json:"period_seconds"`
+}
+
+type histogramKey struct {
+	event   string
+	version string
+}
+
+type histogramBucket struct {
+	count   int64
+	errors  int64
+	max     int64
+	samples []int64
+}
+
+// durationAggregator accumulates handler durations between flushes.
+type durationAggregator struct {
+	mu      sync.Mutex
+	since   time.Time
+	buckets map[histogramKey]*histogramBucket
+	order   []histogramKey
+}
+
+func newDurationAggregator() *durationAggregator {
+	return &durationAggregator{
+		since:   time.Now(),
+		buckets: make(map[histogramKey]*histogramBucket),
+	}
+}
+
+func (a *durationAggregator) observe(event, version, status string, durationMs int64) {
+	key := histogramKey{event, version}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	bucket, ok := a.buckets[key]
+	if !ok {
+		bucket = &histogramBucket{}
+		a.buckets[key] = bucket
+		a.order = append(a.order, key)
+	}
+
+	bucket.count++
+	if status == "error" {
+		bucket.errors++
+	}
+	if durationMs > bucket.max {
+		bucket.max = durationMs
+	}
+
+	if len(bucket.samples) < maxHistogramSamples {
+		bucket.samples = append(bucket.samples, durationMs)
+	} else if i := rand.Int63n(bucket.count); i < maxHistogramSamples {
+		bucket.samples[i] = durationMs
+	}
+}
+
+// drain returns the histograms collected since the last drain and resets.
+func (a *durationAggregator) drain(now time.Time) []DurationHistogram {
+	a.mu.Lock()
+	buckets, order, since := a.buckets, a.order, a.since
+	a.buckets = make(map[histogramKey]*histogramBucket)
+	a.order = nil
+	a.since = now
+	a.mu.Unlock()
+
+	period := now.Sub(since).Seconds()
+	result := make([]DurationHistogram, 0, len(order))
+	for _, key := range order {
+		bucket := buckets[key]
+		sort.Slice(bucket.samples, func(i, j int) bool { return bucket.samples[i] < bucket.samples[j] })
+
+		result = append(result, DurationHistogram{
+			Event:         key.event,
+			Version:       key.version,
+			Count:         bucket.count,
+			Errors:        bucket.errors,
+			P50Ms:         percentile(bucket.samples, 0.50),
+			P95Ms:         percentile(bucket.samples, 0.95),
+			P99Ms:         percentile(bucket.samples, 0.99),
+			MaxMs:         float64(bucket.max),
+			PeriodSeconds: period,
+		})
+	}
+
+	return result
+}
+
+// percentile returns the nearest-rank percentile of sorted samples.
+func percentile(sorted []int64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return float64(sorted[rank])
+}
@@ -0,0 +1,279 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const issuesPath = "/api/v1/ext/issues"
+
+// issuesClient implements the IssuesClient interface.
+type issuesClient struct {
+	client Client
+}
+
+// NewIssuesClient creates a new issues client.
+func NewIssuesClient(client Client) IssuesClient {
+	return &issuesClient{client: client}
+}
+
+func (c *issuesClient) buildPath(issueID interface{}, action string) string {
+	if action != "" {
+		return joinPath(issuesPath, issueID) + "/" + action
+	}
+	return joinPath(issuesPath, issueID)
+}
+
+func (c *issuesClient) buildParams(opts *IssueListOptions) map[string]string {
+	params := map[string]string{}
+	if opts == nil {
+		return params
+	}
+
+	if opts.ProjectID != nil {
+		params["project_id"] = fmt.Sprintf("%v", opts.ProjectID)
+	}
+	if opts.State != "" {
+		params["state"] = opts.State
+	}
+	if opts.AssigneeID != nil {
+		params["assignee_id"] = fmt.Sprintf("%v", opts.AssigneeID)
+	}
+	if opts.IssueType != "" {
+		params["issue_type"] = opts.IssueType
+	}
+	if len(opts.Labels) > 0 {
+		params["labels"] = strings.Join(opts.Labels, ",")
+	}
+	if opts.Query != "" {
+		params["q"] = opts.Query
+	}
+	if opts.Limit > 0 {
+		params["limit"] = strconv.Itoa(opts.Limit)
+	}
+	if opts.Page > 0 {
+		params["page"] = strconv.Itoa(opts.Page)
+	}
+
+	return params
+}
+
+// parseIssue decodes a {"data": {...}} issue response.
+func parseIssue(resp []byte) (*Issue, error) {
+	result, err := decodeData[Issue](resp)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *issuesClient) Get(ctx context.Context, issueID interface{}) (*Issue, error) {
+	if issueID == nil || issueID == "" {
+		return nil, errors.New("issue ID is required")
+	}
+
+	resp, err := c.client.Get(ctx, c.buildPath(issueID, ""), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseIssue(resp)
+}
+
+func (c *issuesClient) List(ctx context.Context, opts *IssueListOptions) (*IssueListResponse, error) {
+	resp, err := c.client.Get(ctx, issuesPath, &RequestOptions{
+		Params: c.buildParams(opts),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result IssueListResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
+
+func (c *issuesClient) Create(ctx context.Context, issue IssueInput) (*Issue, error) {
+	if issue.ProjectID == nil || issue.ProjectID == "" {
+		return nil, errors.New("project_id is required to create an issue")
+	}
+	if issue.Title == "" {
+		return nil, errors.New("title is required to create an issue")
+	}
+
+	resp, err := c.client.Post(ctx, issuesPath, map[string]interface{}{"issue": issue}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseIssue(resp)
+}
+
+func (c *issuesClient) Update(ctx context.Context, issueID interface{}, issue IssueInput) (*Issue, error) {
+	if issueID == nil || issueID == "" {
+		return nil, errors.New("issue ID is required")
+	}
+
+	resp, err := c.client.Patch(ctx, c.buildPath(issueID, ""), map[string]interface{}{"issue": issue}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseIssue(resp)
+}
+
+func (c *issuesClient) Transition(ctx context.Context, issueID interface{}, state string) (*Issue, error) {
+	if issueID == nil || issueID == "" {
+		return nil, errors.New("issue ID is required")
+	}
+	if state == "" {
+		return nil, errors.New("target state is required")
+	}
+
+	resp, err := c.client.Post(ctx, c.buildPath(issueID, "transition"), map[string]string{"state": state}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseIssue(resp)
+}
+
+func (c *issuesClient) Assign(ctx context.Context, issueID interface{}, assigneeID interface{}) (*Issue, error) {
+	if issueID == nil || issueID == "" {
+		return nil, errors.New("issue ID is required")
+	}
+
+	// A nil assignee is sent as null to unassign the issue
+	resp, err := c.client.Post(ctx, c.buildPath(issueID, "assign"), map[string]interface{}{"assignee_id": assigneeID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseIssue(resp)
+}
+
+func (c *issuesClient) AddLabel(ctx context.Context, issueID interface{}, label string) (*Issue, error) {
+	if issueID == nil || issueID == "" {
+		return nil, errors.New("issue ID is required")
+	}
+	if label == "" {
+		return nil, errors.New("label is required")
+	}
+
+	resp, err := c.client.Post(ctx, c.buildPath(issueID, "labels"), map[string]string{"label": label}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseIssue(resp)
+}
+
+// bulkChunkSize is the number of issues sent per bulk request.
+const bulkChunkSize = 100
+
+func (c *issuesClient) BulkUpdate(ctx context.Context, issueIDs []interface{}, changes IssueInput) (*BulkResult, error) {
+	return c.bulk(ctx, issuesPath+"/bulk", issueIDs, map[string]interface{}{"changes": changes})
+}
+
+func (c *issuesClient) BulkTransition(ctx context.Context, issueIDs []interface{}, state string) (*BulkResult, error) {
+	if state == "" {
+		return nil, errors.New("target state is required")
+	}
+	return c.bulk(ctx, issuesPath+"/bulk/transition", issueIDs, map[string]interface{}{"state": state})
+}
+
+// bulk sends issueIDs in chunks. A chunk that fails as a whole marks each of
+// its issues as failed and processing continues with the next chunk; only a
+// cancelled context stops early.
+func (c *issuesClient) bulk(ctx context.Context, path string, issueIDs []interface{}, body map[string]interface{}) (*BulkResult, error) {
+	result := &BulkResult{}
+
+	for start := 0; start < len(issueIDs); start += bulkChunkSize {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		end := start + bulkChunkSize
+		if end > len(issueIDs) {
+			end = len(issueIDs)
+		}
+		chunk := issueIDs[start:end]
+
+		payload := map[string]interface{}{"ids": chunk}
+		for k, v := range body {
+			payload[k] = v
+		}
+
+		resp, err := c.client.Post(ctx, path, payload, nil)
+		if err == nil {
+			var chunkResult struct {
+				Data BulkResult `json:"data"`
+			}
+			if err = json.Unmarshal(resp, &chunkResult); err != nil {
+				err = fmt.Errorf("failed to parse response: %w", err)
+			} else {
+				result.Succeeded = append(result.Succeeded, chunkResult.Data.Succeeded...)
+				result.Failed = append(result.Failed, chunkResult.Data.Failed...)
+				continue
+			}
+		}
+
+		for _, id := range chunk {
+			result.Failed = append(result.Failed, BulkFailure{ID: id, Error: err.Error()})
+		}
+	}
+
+	return result, nil
+}
+
+func (c *issuesClient) Links(ctx context.Context, issueID interface{}) ([]IssueLink, error) {
+	if issueID == nil || issueID == "" {
+		return nil, errors.New("issue ID is required")
+	}
+
+	resp, err := c.client.Get(ctx, c.buildPath(issueID, "links"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeData[[]IssueLink](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *issuesClient) Link(ctx context.Context, issueID interface{}, linkType IssueLinkType, targetID interface{}) (*IssueLink, error) {
+	if issueID == nil || issueID == "" || targetID == nil || targetID == "" {
+		return nil, errors.New("source and target issue IDs are required")
+	}
+	if !knownIssueLinkTypes[linkType] {
+		return nil, fmt.Errorf("unknown link type %q", linkType)
+	}
+
+	resp, err := c.client.Post(ctx, c.buildPath(issueID, "links"), map[string]interface{}{
+		"type":      linkType,
+		"target_id": targetID,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeData[IssueLink](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *issuesClient) Unlink(ctx context.Context, issueID interface{}, linkID interface{}) error {
+	if issueID == nil || issueID == "" || linkID == nil || linkID == "" {
+		return errors.New("issue ID and link ID are required")
+	}
+
+	_, err := c.client.Delete(ctx, joinPath(c.buildPath(issueID, "links"), linkID), nil)
+	return err
+}
@@ -0,0 +1,164 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIssuesClient_BuildsRequests(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		var body map[string]interface{}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			json.Unmarshal(data, &body)
+		}
+		bodies = append(bodies, body)
+
+		if r.Method == http.MethodGet && r.URL.Path == "/api/v1/ext/issues" {
+			w.Write([]byte(`{"data": [{"id": 1, "key": "OPS-1", "state": "open"}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"id": 42, "key": "OPS-42", "title": "Broken build", "state": "in_progress", "labels": ["ci"]}}`))
+	}))
+	defer server.Close()
+
+	issues := NewIssuesClient(NewHTTPClient(WithBaseURL(server.URL)))
+	ctx := context.Background()
+
+	list, err := issues.List(ctx, &IssueListOptions{ProjectID: 7, State: "open", Labels: []string{"bug", "p1"}})
+	if err != nil || len(list.Data) != 1 || list.Data[0].Key != "OPS-1" {
+		t.Fatalf("Unexpected list result %+v (%v)", list, err)
+	}
+
+	issue, err := issues.Create(ctx, IssueInput{ProjectID: 7, Title: "Broken build"})
+	if err != nil || issue.Key != "OPS-42" {
+		t.Fatalf("Unexpected create result %+v (%v)", issue, err)
+	}
+	if _, err := issues.Transition(ctx, 42, "in_progress"); err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+	if _, err := issues.Assign(ctx, 42, nil); err != nil {
+		t.Fatalf("Assign failed: %v", err)
+	}
+
+	expected := []string{
+		"GET /api/v1/ext/issues?labels=bug%2Cp1&project_id=7&state=open",
+		"POST /api/v1/ext/issues",
+		"POST /api/v1/ext/issues/42/transition",
+		"POST /api/v1/ext/issues/42/assign",
+	}
+	for i, want := range expected {
+		if requests[i] != want {
+			t.Errorf("Expected request %q, got %q", want, requests[i])
+		}
+	}
+	if created, _ := bodies[1]["issue"].(map[string]interface{}); created["title"] != "Broken build" {
+		t.Errorf("Expected issue body, got %v", bodies[1])
+	}
+	if value, ok := bodies[3]["assignee_id"]; !ok || value != nil {
+		t.Errorf("Expected null assignee to unassign, got %v", bodies[3])
+	}
+
+	if _, err := issues.Create(ctx, IssueInput{Title: "No project"}); err == nil {
+		t.Error("Expected error when project_id is missing")
+	}
+}
+
+func TestIssuesClient_Links(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body map[string]interface{}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			json.Unmarshal(data, &body)
+		}
+		bodies = append(bodies, body)
+
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"data": [{"id": 1, "type": "blocked_by", "source_id": 42, "target_id": 7}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"id": 2, "type": "blocks", "source_id": 42, "target_id": 43}}`))
+	}))
+	defer server.Close()
+
+	issues := NewIssuesClient(NewHTTPClient(WithBaseURL(server.URL)))
+	ctx := context.Background()
+
+	links, err := issues.Links(ctx, 42)
+	if err != nil || len(links) != 1 || links[0].Type != LinkBlockedBy {
+		t.Fatalf("Unexpected links %+v (%v)", links, err)
+	}
+	link, err := issues.Link(ctx, 42, LinkBlocks, 43)
+	if err != nil || link.Type != LinkBlocks || link.TargetID != float64(43) {
+		t.Fatalf("Unexpected link %+v (%v)", link, err)
+	}
+	if err := issues.Unlink(ctx, 42, 2); err != nil {
+		t.Fatalf("Unlink failed: %v", err)
+	}
+
+	expected := []string{
+		"GET /api/v1/ext/issues/42/links",
+		"POST /api/v1/ext/issues/42/links",
+		"DELETE /api/v1/ext/issues/42/links/2",
+	}
+	for i, want := range expected {
+		if requests[i] != want {
+			t.Errorf("Expected request %q, got %q", want, requests[i])
+		}
+	}
+	if bodies[1]["type"] != "blocks" || bodies[1]["target_id"] != float64(43) {
+		t.Errorf("Expected link type and target in the body, got %v", bodies[1])
+	}
+
+	if _, err := issues.Link(ctx, 42, "causes", 43); err == nil {
+		t.Error("Expected error for an unknown link type")
+	}
+}
+
+func TestIssuesClient_BulkChunksAndReportsFailures(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var body struct {
+			IDs []interface{} `json:"ids"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		if calls == 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"updated": body.IDs[1:], "failed": []map[string]interface{}{{"id": body.IDs[0], "error": "locked"}}},
+		})
+	}))
+	defer server.Close()
+
+	ids := make([]interface{}, 250)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	issues := NewIssuesClient(NewHTTPClient(WithBaseURL(server.URL)))
+	result, err := issues.BulkTransition(context.Background(), ids, "closed")
+	if err != nil {
+		t.Fatalf("BulkTransition failed: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 chunked requests, got %d", calls)
+	}
+	if len(result.Succeeded) != 99+49 || len(result.Failed) != 1+100+1 {
+		t.Errorf("Expected 148 succeeded and 102 failed, got %d and %d", len(result.Succeeded), len(result.Failed))
+	}
+	if result.OK() {
+		t.Error("Expected partial failure")
+	}
+}
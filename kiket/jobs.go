@@ -0,0 +1,154 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// JobDueEvent is the webhook event delivered when a scheduled job is due.
+const JobDueEvent = "job.due"
+
+// jobsClient implements the JobsClient interface.
+type jobsClient struct {
+	client      Client
+	extensionID string
+	basePath    string
+}
+
+// NewJobsClient creates a new jobs client.
+func NewJobsClient(client Client, extensionID string) JobsClient {
+	return &jobsClient{
+		client:      client,
+		extensionID: extensionID,
+		basePath:    extensionPath(extensionID) + "/jobs",
+	}
+}
+
+func (c *jobsClient) path(jobID interface{}) string {
+	if jobID == nil {
+		return c.basePath
+	}
+	return joinPath(c.basePath, jobID)
+}
+
+func parseJob(resp []byte) (*Job, error) {
+	result, err := decodeData[Job](resp)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *jobsClient) Enqueue(ctx context.Context, req JobRequest) (*Job, error) {
+	if c.extensionID == "" {
+		return nil, errors.New("extension ID required for job operations")
+	}
+	if req.Name == "" {
+		return nil, errors.New("job name is required")
+	}
+
+	scheduled := 0
+	for _, set := range []bool{!req.RunAt.IsZero(), req.Delay > 0, req.Cron != ""} {
+		if set {
+			scheduled++
+		}
+	}
+	if scheduled > 1 {
+		return nil, errors.New("set at most one of RunAt, Delay, and Cron")
+	}
+
+	body := map[string]interface{}{"name": req.Name}
+	if req.Payload != nil {
+		body["payload"] = req.Payload
+	}
+	if !req.RunAt.IsZero() {
+		body["run_at"] = req.RunAt.UTC().Format(time.RFC3339)
+	}
+	if req.Delay > 0 {
+		body["run_at"] = time.Now().Add(req.Delay).UTC().Format(time.RFC3339)
+	}
+	if req.Cron != "" {
+		body["cron"] = req.Cron
+	}
+	if req.UniqueKey != "" {
+		body["unique_key"] = req.UniqueKey
+	}
+
+	resp, err := c.client.Post(ctx, c.path(nil), map[string]interface{}{"job": body}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseJob(resp)
+}
+
+func (c *jobsClient) Get(ctx context.Context, jobID interface{}) (*Job, error) {
+	if c.extensionID == "" {
+		return nil, errors.New("extension ID required for job operations")
+	}
+	if jobID == nil || jobID == "" {
+		return nil, errors.New("job ID is required")
+	}
+
+	resp, err := c.client.Get(ctx, c.path(jobID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseJob(resp)
+}
+
+func (c *jobsClient) Cancel(ctx context.Context, jobID interface{}) error {
+	if c.extensionID == "" {
+		return errors.New("extension ID required for job operations")
+	}
+	if jobID == nil || jobID == "" {
+		return errors.New("job ID is required")
+	}
+
+	_, err := c.client.Delete(ctx, c.path(jobID), nil)
+	return err
+}
+
+// JobFromPayload extracts the job from a job.due webhook payload.
+func JobFromPayload(payload WebhookPayload) (*Job, error) {
+	var job Job
+	if err := payload.Decode("job", &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// OnJob registers a handler for job.due deliveries of the named job. Use
+// JobFromPayload to read the job inside the handler.
+func (s *SDK) OnJob(name string, handler WebhookHandler) {
+	s.handlersMu.Lock()
+	first := s.jobHandlers == nil
+	if first {
+		s.jobHandlers = make(map[string]WebhookHandler)
+	}
+	s.jobHandlers[name] = handler
+	s.handlersMu.Unlock()
+
+	if first {
+		s.On(JobDueEvent, s.dispatchJob)
+	}
+}
+
+// dispatchJob routes a job.due delivery to the handler registered for the
+// job's name.
+func (s *SDK) dispatchJob(ctx context.Context, payload WebhookPayload, handlerCtx *HandlerContext) (interface{}, error) {
+	job, err := JobFromPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	s.handlersMu.RLock()
+	handler := s.jobHandlers[job.Name]
+	s.handlersMu.RUnlock()
+
+	if handler == nil {
+		return nil, fmt.Errorf("no handler registered for job %s", job.Name)
+	}
+	return handler(ctx, payload, handlerCtx)
+}
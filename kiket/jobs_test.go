@@ -0,0 +1,111 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJobsClient_BuildsRequests(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body map[string]interface{}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			json.Unmarshal(data, &body)
+		}
+		bodies = append(bodies, body)
+		w.Write([]byte(`{"data": {"id": "job_1", "name": "digest", "status": "scheduled", "cron": "0 9 * * MON", "attempts": 1}}`))
+	}))
+	defer server.Close()
+
+	jobs := NewJobsClient(NewHTTPClient(WithBaseURL(server.URL)), "com.example.ext")
+	ctx := context.Background()
+
+	job, err := jobs.Enqueue(ctx, JobRequest{
+		Name:      "digest",
+		Payload:   map[string]interface{}{"team": "ops"},
+		Cron:      "0 9 * * MON",
+		UniqueKey: "digest-ops",
+	})
+	if err != nil || job.ID != "job_1" || job.Status != "scheduled" || job.Attempts != 1 {
+		t.Fatalf("Unexpected job %+v (%v)", job, err)
+	}
+	if _, err := jobs.Enqueue(ctx, JobRequest{Name: "reminder", RunAt: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)}); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if _, err := jobs.Get(ctx, "job_1"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if err := jobs.Cancel(ctx, "job_1"); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+
+	expected := []string{
+		"POST /api/v1/extensions/com.example.ext/jobs",
+		"POST /api/v1/extensions/com.example.ext/jobs",
+		"GET /api/v1/extensions/com.example.ext/jobs/job_1",
+		"DELETE /api/v1/extensions/com.example.ext/jobs/job_1",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+	recurring := map[string]interface{}{"job": map[string]interface{}{
+		"name":       "digest",
+		"payload":    map[string]interface{}{"team": "ops"},
+		"cron":       "0 9 * * MON",
+		"unique_key": "digest-ops",
+	}}
+	if !reflect.DeepEqual(bodies[0], recurring) {
+		t.Errorf("Expected body %v, got %v", recurring, bodies[0])
+	}
+	if once, _ := bodies[1]["job"].(map[string]interface{}); once["run_at"] != "2024-03-01T09:00:00Z" {
+		t.Errorf("Expected run_at in RFC 3339, got %v", bodies[1])
+	}
+
+	if _, err := jobs.Enqueue(ctx, JobRequest{Name: "digest", Delay: time.Minute, Cron: "* * * * *"}); err == nil {
+		t.Error("Expected error for a job with both Delay and Cron")
+	}
+}
+
+func TestSDK_OnJobRoutesByJobName(t *testing.T) {
+	sdk, err := New(Config{ExtensionAPIKey: "key", WebhookSecret: "secret"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	var ran []string
+	for _, name := range []string{"digest", "cleanup"} {
+		name := name
+		sdk.OnJob(name, func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+			job, err := JobFromPayload(payload)
+			if err != nil {
+				return nil, err
+			}
+			ran = append(ran, name+":"+job.Payload["team"].(string))
+			return nil, nil
+		})
+	}
+
+	deliver := func(body string) error {
+		signature, timestamp := GenerateSignature("secret", body, nil)
+		_, err := sdk.HandleWebhook(context.Background(), []byte(body), Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp})
+		return err
+	}
+	if err := deliver(`{"event":"job.due","job":{"id":"job_1","name":"cleanup","payload":{"team":"ops"}}}`); err != nil {
+		t.Fatalf("HandleWebhook failed: %v", err)
+	}
+	if !reflect.DeepEqual(ran, []string{"cleanup:ops"}) {
+		t.Errorf("Expected the cleanup handler to run, got %v", ran)
+	}
+	if err := deliver(`{"event":"job.due","job":{"id":"job_2","name":"unknown"}}`); err == nil {
+		t.Error("Expected error for a job without a handler")
+	}
+}
@@ -0,0 +1,239 @@
+// Package kiketbench replays signed webhook deliveries against a handler
+// to measure throughput, latency percentiles, and error rates.
+//
+//	corpus, err := kiketbench.LoadCorpus("testdata/payloads")
+//	report, err := kiketbench.RunSDK(ctx, sdk, corpus, kiketbench.Options{
+//		Rate:        200,
+//		Duration:    30 * time.Second,
+//		Concurrency: 16,
+//	})
+//	fmt.Println(report)
+package kiketbench
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+const defaultRequests = 1000
+
+// Delivery is one webhook in a replay corpus.
+type Delivery struct {
+	Event   string
+	Version string // defaults to "v1"
+	Body    []byte // JSON payload
+}
+
+// Options controls a run.
+type Options struct {
+	// Rate is the target deliveries per second; 0 sends as fast as the
+	// workers allow.
+	Rate float64
+	// Duration bounds the run by time. When zero, Requests bounds it.
+	Duration time.Duration
+	// Requests bounds the run by count (default 1000 when Duration is zero).
+	Requests int
+	// Concurrency is the number of deliveries in flight (default 1).
+	Concurrency int
+}
+
+// Report summarizes a run.
+type Report struct {
+	Requests    int
+	Errors      int // responses with status >= 400
+	Elapsed     time.Duration
+	StatusCodes map[int]int
+	P50         time.Duration
+	P90         time.Duration
+	P99         time.Duration
+	Max         time.Duration
+}
+
+// Throughput returns completed deliveries per second.
+func (r *Report) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Requests) / r.Elapsed.Seconds()
+}
+
+// ErrorRate returns the fraction of deliveries that failed.
+func (r *Report) ErrorRate() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Requests)
+}
+
+func (r *Report) String() string {
+	return fmt.Sprintf("%d requests in %s (%.1f req/s), errors %.2f%%, latency p50=%s p90=%s p99=%s max=%s",
+		r.Requests, r.Elapsed.Round(time.Millisecond), r.Throughput(), r.ErrorRate()*100,
+		r.P50, r.P90, r.P99, r.Max)
+}
+
+// LoadCorpus reads every *.json file in dir as a delivery. The event is
+// taken from the payload's "event" field.
+func LoadCorpus(dir string) ([]Delivery, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	corpus := make([]Delivery, 0, len(paths))
+	for _, path := range paths {
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var payload struct {
+			Event string `json:"event"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if payload.Event == "" {
+			return nil, fmt.Errorf("%s: payload has no event", path)
+		}
+		corpus = append(corpus, Delivery{Event: payload.Event, Body: body})
+	}
+	return corpus, nil
+}
+
+// RunSDK replays corpus against sdk.ServeHTTP, signing with the SDK's
+// webhook secret.
+func RunSDK(ctx context.Context, sdk *kiket.SDK, corpus []Delivery, opts Options) (*Report, error) {
+	return Run(ctx, sdk, sdk.Config().WebhookSecret, corpus, opts)
+}
+
+// Run replays corpus round-robin against handler, signing each delivery
+// with secret at send time. Signing happens outside the measured latency.
+func Run(ctx context.Context, handler http.Handler, secret string, corpus []Delivery, opts Options) (*Report, error) {
+	if len(corpus) == 0 {
+		return nil, errors.New("corpus is empty")
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.Duration <= 0 && opts.Requests <= 0 {
+		opts.Requests = defaultRequests
+	}
+	if opts.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Duration)
+		defer cancel()
+	}
+
+	jobs := make(chan Delivery)
+	go func() {
+		defer close(jobs)
+
+		var tick <-chan time.Time
+		if opts.Rate > 0 {
+			ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.Rate))
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+
+		for i := 0; opts.Requests <= 0 || i < opts.Requests; i++ {
+			if tick != nil {
+				select {
+				case <-tick:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case jobs <- corpus[i%len(corpus)]:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mu        sync.Mutex
+		latencies []time.Duration
+		report    = &Report{StatusCodes: map[int]int{}}
+		wg        sync.WaitGroup
+	)
+
+	start := time.Now()
+	for w := 0; w < opts.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range jobs {
+				req := signedRequest(secret, delivery)
+				rec := httptest.NewRecorder()
+
+				began := time.Now()
+				handler.ServeHTTP(rec, req)
+				latency := time.Since(began)
+
+				mu.Lock()
+				latencies = append(latencies, latency)
+				report.StatusCodes[rec.Code]++
+				if rec.Code >= 400 {
+					report.Errors++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	report.Elapsed = time.Since(start)
+	report.Requests = len(latencies)
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	report.P50 = percentile(latencies, 0.50)
+	report.P90 = percentile(latencies, 0.90)
+	report.P99 = percentile(latencies, 0.99)
+	if len(latencies) > 0 {
+		report.Max = latencies[len(latencies)-1]
+	}
+	return report, nil
+}
+
+func signedRequest(secret string, delivery Delivery) *http.Request {
+	signature, timestamp := kiket.GenerateSignature(secret, string(delivery.Body), nil)
+
+	version := delivery.Version
+	if version == "" {
+		version = "v1"
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(delivery.Body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Kiket-Signature", signature)
+	req.Header.Set("X-Kiket-Timestamp", timestamp)
+	req.Header.Set("X-Kiket-Event-Version", version)
+	return req
+}
+
+// percentile returns the p-th percentile of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(float64(len(sorted))*p+0.5) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return sorted[i]
+}
@@ -0,0 +1,47 @@
+package kiketbench
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func TestRunSDK_ReportsErrorsAndLatency(t *testing.T) {
+	sdk, err := kiket.New(kiket.Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	sdk.On("issue.created", func(ctx context.Context, payload kiket.WebhookPayload, hctx *kiket.HandlerContext) (interface{}, error) {
+		return nil, nil
+	})
+	sdk.On("issue.closed", func(ctx context.Context, payload kiket.WebhookPayload, hctx *kiket.HandlerContext) (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+
+	corpus := []Delivery{
+		{Event: "issue.created", Body: []byte(`{"event":"issue.created"}`)},
+		{Event: "issue.closed", Body: []byte(`{"event":"issue.closed"}`)},
+	}
+	report, err := RunSDK(context.Background(), sdk, corpus, Options{Requests: 100, Concurrency: 4})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if report.Requests != 100 {
+		t.Errorf("Expected 100 requests, got %d", report.Requests)
+	}
+	if report.Errors != 50 || report.StatusCodes[500] != 50 {
+		t.Errorf("Expected 50 errors, got %d (%v)", report.Errors, report.StatusCodes)
+	}
+	if report.P50 <= 0 || report.Max < report.P99 {
+		t.Errorf("Unexpected latencies: %s", report)
+	}
+}
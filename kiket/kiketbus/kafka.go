@@ -0,0 +1,58 @@
+package kiketbus
+
+import (
+	"context"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+// KafkaRecord is the part of a Kafka message the consumer needs. Map the
+// client library's message type onto it.
+type KafkaRecord struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string][]byte
+}
+
+// KafkaSource consumes records returned by fetch and commits each one with
+// commit once it is handled, giving at-least-once processing. With
+// segmentio/kafka-go, for example:
+//
+//	source := kiketbus.KafkaSource(
+//		func(ctx context.Context) (kiketbus.KafkaRecord, error) {
+//			m, err := reader.FetchMessage(ctx)
+//			rec := kiketbus.KafkaRecord{Topic: m.Topic, Partition: m.Partition, Offset: m.Offset, Value: m.Value, Headers: map[string][]byte{}}
+//			for _, h := range m.Headers {
+//				rec.Headers[h.Key] = h.Value
+//			}
+//			return rec, err
+//		},
+//		func(ctx context.Context, rec kiketbus.KafkaRecord) error {
+//			return reader.CommitMessages(ctx, kafka.Message{Topic: rec.Topic, Partition: rec.Partition, Offset: rec.Offset})
+//		},
+//	)
+//
+// Kafka has no negative acknowledgement: a record whose handler fails is
+// not committed, and is redelivered after a restart or rebalance.
+func KafkaSource(fetch func(ctx context.Context) (KafkaRecord, error), commit func(ctx context.Context, rec KafkaRecord) error) Source {
+	return SourceFunc(func(ctx context.Context) (*Message, error) {
+		rec, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		headers := make(kiket.Headers, len(rec.Headers))
+		for k, v := range rec.Headers {
+			headers[k] = string(v)
+		}
+
+		var ack func(ctx context.Context) error
+		if commit != nil {
+			ack = func(ctx context.Context) error { return commit(ctx, rec) }
+		}
+		return NewMessage(headers, rec.Value, ack, nil), nil
+	})
+}
@@ -0,0 +1,158 @@
+// Package kiketbus consumes Kiket events relayed through a message bus such
+// as Kafka or NATS and dispatches them through an SDK's handler registry,
+// so the same handlers serve webhook and bus deliveries.
+//
+// Relays forward each delivery's signature headers with the message, either
+// as transport headers or in a JSON envelope of the form
+// {"headers": {...}, "body": {...}}. Signatures are verified as for
+// webhooks; set Config.SignatureTolerance to cover consumer lag.
+//
+// The package has no client library dependencies: KafkaSource and
+// NATSSource take small functions that adapt whichever client is in use.
+//
+//	consumer := kiketbus.NewConsumer(sdk, kiketbus.NATSSource(next))
+//	err := consumer.Run(ctx)
+package kiketbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+// Message is one relayed delivery.
+type Message struct {
+	Headers kiket.Headers
+	Body    []byte
+
+	ack  func(ctx context.Context) error
+	nack func(ctx context.Context) error
+}
+
+// NewMessage creates a message. ack is called once the delivery is handled
+// or rejected as invalid; nack, which may be nil, is called when the
+// handler fails so the bus can redeliver.
+func NewMessage(headers kiket.Headers, body []byte, ack, nack func(ctx context.Context) error) *Message {
+	return &Message{Headers: headers, Body: body, ack: ack, nack: nack}
+}
+
+// Source yields relayed deliveries. Receive blocks until a message is
+// available and returns io.EOF when the source is exhausted.
+type Source interface {
+	Receive(ctx context.Context) (*Message, error)
+}
+
+// SourceFunc adapts a function to the Source interface.
+type SourceFunc func(ctx context.Context) (*Message, error)
+
+// Receive calls f.
+func (f SourceFunc) Receive(ctx context.Context) (*Message, error) {
+	return f(ctx)
+}
+
+// ErrorHandler is called when a message cannot be handled. Returning an
+// error stops the consumer.
+type ErrorHandler func(ctx context.Context, msg *Message, err error) error
+
+// Option configures a Consumer.
+type Option func(*Consumer)
+
+// WithErrorHandler replaces the default error handler, which logs the error
+// and keeps consuming.
+func WithErrorHandler(h ErrorHandler) Option {
+	return func(c *Consumer) {
+		if h != nil {
+			c.onError = h
+		}
+	}
+}
+
+// Consumer feeds messages from a Source into an SDK.
+type Consumer struct {
+	sdk     *kiket.SDK
+	source  Source
+	onError ErrorHandler
+}
+
+// NewConsumer creates a consumer dispatching messages from source to sdk.
+func NewConsumer(sdk *kiket.SDK, source Source, opts ...Option) *Consumer {
+	c := &Consumer{
+		sdk:    sdk,
+		source: source,
+		onError: func(ctx context.Context, msg *Message, err error) error {
+			log.Printf("kiketbus: %v", err)
+			return nil
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Run consumes messages until ctx is done, the source is exhausted, or the
+// error handler returns an error.
+//
+// Messages that fail signature verification or cannot be parsed are
+// acknowledged, since redelivering them cannot succeed. Messages whose
+// handler fails are negatively acknowledged when the source supports it,
+// and left unacknowledged otherwise.
+func (c *Consumer) Run(ctx context.Context) error {
+	for {
+		msg, err := c.source.Receive(ctx)
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		if err := c.handle(ctx, msg); err != nil {
+			if stopErr := c.onError(ctx, msg, err); stopErr != nil {
+				return stopErr
+			}
+		}
+	}
+}
+
+func (c *Consumer) handle(ctx context.Context, msg *Message) error {
+	headers, body := unwrap(msg)
+
+	_, err := c.sdk.HandleWebhook(ctx, body, headers)
+	if err == nil || kiket.IsAuthenticationError(err) {
+		if msg.ack != nil {
+			if ackErr := msg.ack(ctx); ackErr != nil && err == nil {
+				err = ackErr
+			}
+		}
+		return err
+	}
+
+	if msg.nack != nil {
+		if nackErr := msg.nack(ctx); nackErr != nil {
+			return errors.Join(err, nackErr)
+		}
+	}
+	return err
+}
+
+// unwrap returns the signed headers and body of msg, opening the JSON
+// envelope when the signature is not carried in transport headers.
+func unwrap(msg *Message) (kiket.Headers, []byte) {
+	if msg.Headers["X-Kiket-Signature"] != "" || msg.Headers["x-kiket-signature"] != "" {
+		return msg.Headers, msg.Body
+	}
+
+	var envelope struct {
+		Headers kiket.Headers   `json:"headers"`
+		Body    json.RawMessage `json:"body"`
+	}
+	if err := json.Unmarshal(msg.Body, &envelope); err != nil || len(envelope.Headers) == 0 || len(envelope.Body) == 0 {
+		return msg.Headers, msg.Body
+	}
+	return envelope.Headers, envelope.Body
+}
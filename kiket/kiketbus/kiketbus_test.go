@@ -0,0 +1,129 @@
+package kiketbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func newSDK(t *testing.T) *kiket.SDK {
+	t.Helper()
+	sdk, err := kiket.New(kiket.Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { sdk.Close() })
+	return sdk
+}
+
+func TestConsumer_DispatchesAndAcknowledges(t *testing.T) {
+	sdk := newSDK(t)
+	var handled []string
+	sdk.On("issue.created", func(ctx context.Context, payload kiket.WebhookPayload, hctx *kiket.HandlerContext) (interface{}, error) {
+		title := payload["issue"].(map[string]interface{})["title"].(string)
+		handled = append(handled, title)
+		if title == "fail" {
+			return nil, errors.New("boom")
+		}
+		return nil, nil
+	})
+
+	signed := func(title string) (map[string][]byte, []byte) {
+		body := `{"event":"issue.created","issue":{"title":"` + title + `"}}`
+		signature, timestamp := kiket.GenerateSignature("secret", body, nil)
+		return map[string][]byte{"X-Kiket-Signature": []byte(signature), "X-Kiket-Timestamp": []byte(timestamp)}, []byte(body)
+	}
+
+	okHeaders, okBody := signed("ok")
+	failHeaders, failBody := signed("fail")
+	envHeaders, envBody := signed("enveloped")
+	envelope, _ := json.Marshal(map[string]interface{}{
+		"headers": map[string]string{"X-Kiket-Signature": string(envHeaders["X-Kiket-Signature"]), "X-Kiket-Timestamp": string(envHeaders["X-Kiket-Timestamp"])},
+		"body":    json.RawMessage(envBody),
+	})
+
+	records := []KafkaRecord{
+		{Offset: 1, Headers: okHeaders, Value: okBody},
+		{Offset: 2, Headers: failHeaders, Value: failBody},
+		{Offset: 3, Headers: map[string][]byte{"X-Kiket-Signature": []byte("bad"), "X-Kiket-Timestamp": okHeaders["X-Kiket-Timestamp"]}, Value: okBody},
+		{Offset: 4, Value: envelope},
+	}
+	var committed []int64
+	source := KafkaSource(
+		func(ctx context.Context) (KafkaRecord, error) {
+			if len(records) == 0 {
+				return KafkaRecord{}, io.EOF
+			}
+			rec := records[0]
+			records = records[1:]
+			return rec, nil
+		},
+		func(ctx context.Context, rec KafkaRecord) error {
+			committed = append(committed, rec.Offset)
+			return nil
+		},
+	)
+
+	var failures int
+	consumer := NewConsumer(sdk, source, WithErrorHandler(func(ctx context.Context, msg *Message, err error) error {
+		failures++
+		return nil
+	}))
+	if err := consumer.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(handled) != 3 || handled[2] != "enveloped" {
+		t.Errorf("Expected ok, fail, and enveloped to be handled, got %v", handled)
+	}
+	// The handler failure stays uncommitted; the bad signature is dropped.
+	if len(committed) != 3 || committed[0] != 1 || committed[1] != 3 || committed[2] != 4 {
+		t.Errorf("Expected offsets 1, 3, 4 to be committed, got %v", committed)
+	}
+	if failures != 2 {
+		t.Errorf("Expected 2 failures, got %d", failures)
+	}
+}
+
+func TestNATSSource_NaksFailedMessages(t *testing.T) {
+	sdk := newSDK(t)
+	sdk.On("issue.created", func(ctx context.Context, payload kiket.WebhookPayload, hctx *kiket.HandlerContext) (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+
+	body := `{"event":"issue.created","issue":{"title":"x"}}`
+	signature, timestamp := kiket.GenerateSignature("secret", body, nil)
+	sent := false
+	var nacked bool
+	source := NATSSource(func(ctx context.Context) (NATSMsg, error) {
+		if sent {
+			return NATSMsg{}, io.EOF
+		}
+		sent = true
+		return NATSMsg{
+			Header: map[string][]string{"X-Kiket-Signature": {signature}, "X-Kiket-Timestamp": {timestamp}},
+			Data:   []byte(body),
+			Ack:    func() error { t.Error("Expected no ack for a failed handler"); return nil },
+			Nak:    func() error { nacked = true; return nil },
+		}, nil
+	})
+
+	stop := errors.New("stop")
+	err := NewConsumer(sdk, source, WithErrorHandler(func(ctx context.Context, msg *Message, err error) error {
+		return stop
+	})).Run(context.Background())
+	if !errors.Is(err, stop) {
+		t.Errorf("Expected the error handler to stop the consumer, got %v", err)
+	}
+	if !nacked {
+		t.Error("Expected the message to be nacked")
+	}
+}
@@ -0,0 +1,54 @@
+package kiketbus
+
+import (
+	"context"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+// NATSMsg is the part of a NATS message the consumer needs. Ack and Nak
+// are optional and only apply to JetStream subscriptions.
+type NATSMsg struct {
+	Subject string
+	Header  map[string][]string
+	Data    []byte
+	Ack     func() error
+	Nak     func() error
+}
+
+// NATSSource consumes messages returned by next. With nats.go, for
+// example:
+//
+//	sub, err := js.PullSubscribe("kiket.events", "my-extension")
+//	source := kiketbus.NATSSource(func(ctx context.Context) (kiketbus.NATSMsg, error) {
+//		msgs, err := sub.Fetch(1, nats.Context(ctx))
+//		if err != nil {
+//			return kiketbus.NATSMsg{}, err
+//		}
+//		m := msgs[0]
+//		return kiketbus.NATSMsg{Subject: m.Subject, Header: m.Header, Data: m.Data, Ack: func() error { return m.Ack() }, Nak: func() error { return m.Nak() }}, nil
+//	})
+func NATSSource(next func(ctx context.Context) (NATSMsg, error)) Source {
+	return SourceFunc(func(ctx context.Context) (*Message, error) {
+		m, err := next(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		headers := make(kiket.Headers, len(m.Header))
+		for k, v := range m.Header {
+			if len(v) > 0 {
+				headers[k] = v[0]
+			}
+		}
+
+		var ack, nack func(ctx context.Context) error
+		if m.Ack != nil {
+			ack = func(context.Context) error { return m.Ack() }
+		}
+		if m.Nak != nil {
+			nack = func(context.Context) error { return m.Nak() }
+		}
+		return NewMessage(headers, m.Data, ack, nack), nil
+	})
+}
@@ -0,0 +1,222 @@
+// Package kiketsentry forwards handler errors and panics to Sentry. It
+// implements kiket.ErrorReporter on top of Sentry's envelope API, so it
+// needs only a DSN and no client library:
+//
+//	reporter, err := kiketsentry.New(os.Getenv("SENTRY_DSN"), kiketsentry.WithEnvironment("production"))
+//	sdk, err := kiket.New(kiket.Config{ErrorReporter: reporter})
+//	defer reporter.Close(ctx)
+//
+// Events are tagged with the Kiket event, version, workspace, and
+// extension, and carry the scrubbed payload as extra data.
+package kiketsentry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+const sendTimeout = 10 * time.Second
+
+// Reporter sends error reports to a Sentry project. Reports are sent in the
+// background; call Close before exiting to wait for them.
+type Reporter struct {
+	dsn         string
+	envelopeURL string
+	auth        string
+	environment string
+	release     string
+	httpClient  *http.Client
+
+	pending sync.WaitGroup
+}
+
+// Option configures a Reporter.
+type Option func(*Reporter)
+
+// WithEnvironment sets the Sentry environment, e.g. "production".
+func WithEnvironment(environment string) Option {
+	return func(r *Reporter) {
+		r.environment = environment
+	}
+}
+
+// WithRelease sets the release reported with each event. It defaults to
+// "<extension id>@<extension version>" from the report.
+func WithRelease(release string) Option {
+	return func(r *Reporter) {
+		r.release = release
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to reach Sentry.
+func WithHTTPClient(client *http.Client) Option {
+	return func(r *Reporter) {
+		r.httpClient = client
+	}
+}
+
+// New creates a Reporter for a Sentry DSN of the form
+// https://<public key>@<host>/<project id>.
+func New(dsn string, opts ...Option) (*Reporter, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Sentry DSN: %w", err)
+	}
+	projectID := strings.TrimPrefix(u.Path, "/")
+	if u.User == nil || u.User.Username() == "" || projectID == "" || u.Host == "" {
+		return nil, errors.New("invalid Sentry DSN: expected https://<key>@<host>/<project>")
+	}
+	if i := strings.LastIndex(projectID, "/"); i >= 0 {
+		// DSNs of self-hosted Sentry behind a path prefix
+		u.Path = "/" + projectID[:i]
+		projectID = projectID[i+1:]
+	} else {
+		u.Path = ""
+	}
+	key := u.User.Username()
+	u.User = nil
+
+	r := &Reporter{
+		dsn:         dsn,
+		envelopeURL: u.String() + "/api/" + projectID + "/envelope/",
+		auth:        fmt.Sprintf("Sentry sentry_version=7, sentry_key=%s, sentry_client=kiket-go/%s", key, kiket.SDKVersion),
+		httpClient:  &http.Client{Timeout: sendTimeout},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
+}
+
+// ReportError sends report to Sentry in the background.
+func (r *Reporter) ReportError(ctx context.Context, report kiket.ErrorReport) {
+	envelope, err := r.envelope(report)
+	if err != nil {
+		return
+	}
+
+	r.pending.Add(1)
+	go func() {
+		defer r.pending.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		defer cancel()
+		_ = r.send(ctx, envelope)
+	}()
+}
+
+// Close waits for reports still being sent, or until ctx is done.
+func (r *Reporter) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Reporter) send(ctx context.Context, envelope []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.envelopeURL, bytes.NewReader(envelope))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", r.auth)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("sentry responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// envelope encodes report as a Sentry envelope holding one event.
+func (r *Reporter) envelope(report kiket.ErrorReport) ([]byte, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	eventID := hex.EncodeToString(id)
+	now := time.Now().UTC()
+
+	level := "error"
+	extra := map[string]interface{}{}
+	if report.Payload != nil {
+		extra["payload"] = report.Payload
+	}
+	var panicErr *kiket.PanicError
+	if errors.As(report.Err, &panicErr) {
+		level = "fatal"
+		extra["stack"] = string(panicErr.Stack)
+	}
+
+	release := r.release
+	if release == "" && report.ExtensionID != "" {
+		release = report.ExtensionID + "@" + report.ExtensionVersion
+	}
+
+	tags := map[string]string{}
+	for key, value := range map[string]string{
+		"kiket.event":         report.Event,
+		"kiket.event_version": report.EventVersion,
+		"kiket.workspace_id":  report.WorkspaceID,
+		"kiket.delivery_id":   report.DeliveryID,
+		"kiket.extension_id":  report.ExtensionID,
+	} {
+		if value != "" {
+			tags[key] = value
+		}
+	}
+
+	event := map[string]interface{}{
+		"event_id":    eventID,
+		"timestamp":   now.Format(time.RFC3339Nano),
+		"platform":    "go",
+		"level":       level,
+		"logger":      "kiket",
+		"release":     release,
+		"environment": r.environment,
+		"tags":        tags,
+		"extra":       extra,
+		"exception": map[string]interface{}{
+			"values": []map[string]interface{}{{
+				"type":  fmt.Sprintf("%T", report.Err),
+				"value": report.Err.Error(),
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, item := range []interface{}{
+		map[string]string{"event_id": eventID, "dsn": r.dsn, "sent_at": now.Format(time.RFC3339Nano)},
+		map[string]string{"type": "event"},
+		event,
+	} {
+		if err := enc.Encode(item); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
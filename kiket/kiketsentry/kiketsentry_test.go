@@ -0,0 +1,71 @@
+package kiketsentry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func TestReporter(t *testing.T) {
+	var auth, path string
+	var items []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, path = r.Header.Get("X-Sentry-Auth"), r.URL.Path
+		scanner := bufio.NewScanner(r.Body)
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			var item map[string]interface{}
+			json.Unmarshal(scanner.Bytes(), &item)
+			items = append(items, item)
+		}
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "http://", "http://pubkey@", 1) + "/42"
+	reporter, err := New(dsn, WithEnvironment("test"))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	reporter.ReportError(context.Background(), kiket.ErrorReport{
+		Err:              &kiket.PanicError{Value: "boom", Stack: []byte("goroutine 1")},
+		Event:            "issue.created",
+		WorkspaceID:      "acme",
+		ExtensionID:      "com.example.ext",
+		ExtensionVersion: "1.2.0",
+		Payload:          map[string]interface{}{"issue": map[string]interface{}{"id": 1}},
+	})
+	if err := reporter.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if path != "/api/42/envelope/" || !strings.Contains(auth, "sentry_key=pubkey") {
+		t.Errorf("Unexpected request %s %s", path, auth)
+	}
+	if len(items) != 3 || items[1]["type"] != "event" {
+		t.Fatalf("Unexpected envelope %v", items)
+	}
+	event := items[2]
+	tags := event["tags"].(map[string]interface{})
+	if event["level"] != "fatal" || event["release"] != "com.example.ext@1.2.0" || event["environment"] != "test" || tags["kiket.workspace_id"] != "acme" {
+		t.Errorf("Unexpected event %v", event)
+	}
+	if extra := event["extra"].(map[string]interface{}); extra["stack"] != "goroutine 1" || extra["payload"] == nil {
+		t.Errorf("Unexpected extra %v", extra)
+	}
+}
+
+func TestNewInvalidDSN(t *testing.T) {
+	if _, err := New("https://sentry.io/42"); err == nil {
+		t.Error("Expected error for DSN without key")
+	}
+	if _, err := New("https://key@sentry.io"); err == nil {
+		t.Error("Expected error for DSN without project")
+	}
+}
@@ -0,0 +1,47 @@
+package kikettest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+// FakeClock is a kiket.Clock frozen at a fixed time until moved with Set or
+// Advance. Pass it as Config.Clock to make signature checks and telemetry
+// timestamps deterministic.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a clock frozen at now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the frozen time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Set moves the clock to now.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+var _ kiket.Clock = (*FakeClock)(nil)
@@ -0,0 +1,25 @@
+package kikettest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func TestFakeClock_ControlsSignatureWindow(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	body := `{"event":"issue.created"}`
+
+	signature, timestamp := kiket.GenerateSignature("secret", body, nil, kiket.WithSignatureClock(clock))
+	headers := kiket.Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp}
+
+	if err := kiket.VerifySignature("secret", []byte(body), headers, kiket.WithSignatureClock(clock)); err != nil {
+		t.Errorf("Expected valid signature at frozen time, got %v", err)
+	}
+
+	clock.Advance(10 * time.Minute)
+	if err := kiket.VerifySignature("secret", []byte(body), headers, kiket.WithSignatureClock(clock)); !kiket.IsAuthenticationError(err) {
+		t.Errorf("Expected timestamp error after advancing, got %v", err)
+	}
+}
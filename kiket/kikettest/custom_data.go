@@ -0,0 +1,348 @@
+package kikettest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+// FakeCustomData is an in-memory kiket.CustomDataClient. Records are
+// normalized through JSON, so numbers come back as float64 just as they do
+// from the API, and each new record gets an increasing numeric "id".
+//
+// List evaluates filters the way the platform does: a scalar matches
+// fields equal to it, a slice matches fields equal to any element, and nil
+// matches fields that are missing or null. Results are in creation order and
+// paged by Limit and Page.
+//
+// Tables have extension visibility unless set with SetTablePermissions;
+// permissions are recorded but not enforced.
+type FakeCustomData struct {
+	mu          sync.Mutex
+	projectID   interface{}
+	nextID      int
+	tables      map[string][]map[string]interface{}
+	permissions map[string]kiket.CustomDataPermissions // by table or record key
+}
+
+// NewFakeCustomData creates an empty fake scoped to projectID.
+func NewFakeCustomData(projectID interface{}) *FakeCustomData {
+	return &FakeCustomData{
+		projectID:   projectID,
+		tables:      map[string][]map[string]interface{}{},
+		permissions: map[string]kiket.CustomDataPermissions{},
+	}
+}
+
+func tableKey(moduleKey, table string) string {
+	return moduleKey + "/" + table
+}
+
+func recordKey(moduleKey, table string, recordID interface{}) string {
+	return tableKey(moduleKey, table) + "/" + fmt.Sprint(normalize(recordID))
+}
+
+func (f *FakeCustomData) checkProject() error {
+	if f.projectID == nil || f.projectID == "" {
+		return errors.New("project_id is required for custom data operations")
+	}
+	return nil
+}
+
+func notFound(moduleKey, table string, recordID interface{}) error {
+	message := fmt.Sprintf("record %v not found in %s/%s", recordID, moduleKey, table)
+	return &kiket.APIError{
+		StatusCode: 404,
+		Body:       fmt.Sprintf(`{"error": {"code": "not_found", "message": %q}}`, message),
+		Code:       "not_found",
+		Message:    message,
+	}
+}
+
+// normalize round-trips v through JSON.
+func normalize(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+func copyRecord(record map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(record))
+	for k, v := range record {
+		out[k] = v
+	}
+	return out
+}
+
+func (f *FakeCustomData) find(moduleKey, table string, recordID interface{}) int {
+	id := normalize(recordID)
+	for i, record := range f.tables[tableKey(moduleKey, table)] {
+		if reflect.DeepEqual(record["id"], id) || fmt.Sprint(record["id"]) == fmt.Sprint(recordID) {
+			return i
+		}
+	}
+	return -1
+}
+
+func matchesFilters(record map[string]interface{}, filters map[string]interface{}) bool {
+	for field, want := range filters {
+		got, ok := record[field]
+		if want == nil {
+			if ok && got != nil {
+				return false
+			}
+			continue
+		}
+
+		switch values := normalize(want).(type) {
+		case []interface{}:
+			matched := false
+			for _, v := range values {
+				if reflect.DeepEqual(got, v) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		default:
+			if !reflect.DeepEqual(got, values) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// List returns the records matching opts.Filters, at most opts.Limit.
+func (f *FakeCustomData) List(ctx context.Context, moduleKey, table string, opts *kiket.CustomDataListOptions) (*kiket.CustomDataListResponse, error) {
+	if err := f.checkProject(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	skip := 0
+	if opts != nil && opts.Limit > 0 && opts.Page > 1 {
+		skip = (opts.Page - 1) * opts.Limit
+	}
+
+	result := &kiket.CustomDataListResponse{Data: []map[string]interface{}{}}
+	for _, record := range f.tables[tableKey(moduleKey, table)] {
+		if opts != nil && !matchesFilters(record, opts.Filters) {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if opts != nil && opts.Limit > 0 && len(result.Data) >= opts.Limit {
+			break
+		}
+		result.Data = append(result.Data, copyRecord(record))
+	}
+	return result, nil
+}
+
+// Get returns a record, or a 404 *kiket.APIError.
+func (f *FakeCustomData) Get(ctx context.Context, moduleKey, table string, recordID interface{}) (*kiket.CustomDataRecordResponse, error) {
+	if err := f.checkProject(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.find(moduleKey, table, recordID)
+	if i < 0 {
+		return nil, notFound(moduleKey, table, recordID)
+	}
+	return &kiket.CustomDataRecordResponse{Data: copyRecord(f.tables[tableKey(moduleKey, table)][i])}, nil
+}
+
+// Create stores record with a new "id".
+func (f *FakeCustomData) Create(ctx context.Context, moduleKey, table string, record map[string]interface{}) (*kiket.CustomDataRecordResponse, error) {
+	if err := f.checkProject(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.create(moduleKey, table, record), nil
+}
+
+// CreateWithPermissions stores record with a new "id" and its own
+// permissions.
+func (f *FakeCustomData) CreateWithPermissions(ctx context.Context, moduleKey, table string, record map[string]interface{}, permissions kiket.CustomDataPermissions) (*kiket.CustomDataRecordResponse, error) {
+	if err := f.checkProject(); err != nil {
+		return nil, err
+	}
+	if err := kiket.ValidateCustomDataPermissions(permissions); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := f.create(moduleKey, table, record)
+	permissions.Source = "record"
+	f.permissions[recordKey(moduleKey, table, result.Data["id"])] = permissions
+	return result, nil
+}
+
+func (f *FakeCustomData) create(moduleKey, table string, record map[string]interface{}) *kiket.CustomDataRecordResponse {
+	stored, _ := normalize(record).(map[string]interface{})
+	if stored == nil {
+		stored = map[string]interface{}{}
+	}
+	f.nextID++
+	stored["id"] = float64(f.nextID)
+
+	key := tableKey(moduleKey, table)
+	f.tables[key] = append(f.tables[key], stored)
+	return &kiket.CustomDataRecordResponse{Data: copyRecord(stored)}
+}
+
+// Update merges record into an existing record.
+func (f *FakeCustomData) Update(ctx context.Context, moduleKey, table string, recordID interface{}, record map[string]interface{}) (*kiket.CustomDataRecordResponse, error) {
+	if err := f.checkProject(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.find(moduleKey, table, recordID)
+	if i < 0 {
+		return nil, notFound(moduleKey, table, recordID)
+	}
+
+	stored := f.tables[tableKey(moduleKey, table)][i]
+	changes, _ := normalize(record).(map[string]interface{})
+	for k, v := range changes {
+		if k != "id" {
+			stored[k] = v
+		}
+	}
+	return &kiket.CustomDataRecordResponse{Data: copyRecord(stored)}, nil
+}
+
+// Delete removes a record, or returns a 404 *kiket.APIError.
+func (f *FakeCustomData) Delete(ctx context.Context, moduleKey, table string, recordID interface{}) error {
+	if err := f.checkProject(); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.find(moduleKey, table, recordID)
+	if i < 0 {
+		return notFound(moduleKey, table, recordID)
+	}
+
+	key := tableKey(moduleKey, table)
+	delete(f.permissions, recordKey(moduleKey, table, f.tables[key][i]["id"]))
+	f.tables[key] = append(f.tables[key][:i], f.tables[key][i+1:]...)
+	return nil
+}
+
+// SetTablePermissions sets the permissions records of a table get by
+// default.
+func (f *FakeCustomData) SetTablePermissions(moduleKey, table string, permissions kiket.CustomDataPermissions) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	permissions.Source = "table"
+	f.permissions[tableKey(moduleKey, table)] = permissions
+}
+
+// TablePermissions returns the table's permissions, extension visibility
+// unless set with SetTablePermissions.
+func (f *FakeCustomData) TablePermissions(ctx context.Context, moduleKey, table string) (*kiket.CustomDataPermissions, error) {
+	if err := f.checkProject(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	permissions := f.tablePermissions(moduleKey, table)
+	return &permissions, nil
+}
+
+func (f *FakeCustomData) tablePermissions(moduleKey, table string) kiket.CustomDataPermissions {
+	if permissions, ok := f.permissions[tableKey(moduleKey, table)]; ok {
+		return permissions
+	}
+	return kiket.CustomDataPermissions{Visibility: kiket.CustomDataVisibilityExtension, Source: "table"}
+}
+
+// RecordPermissions returns the record's own permissions, else the table's.
+func (f *FakeCustomData) RecordPermissions(ctx context.Context, moduleKey, table string, recordID interface{}) (*kiket.CustomDataPermissions, error) {
+	if err := f.checkProject(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.find(moduleKey, table, recordID)
+	if i < 0 {
+		return nil, notFound(moduleKey, table, recordID)
+	}
+	permissions, ok := f.permissions[recordKey(moduleKey, table, f.tables[tableKey(moduleKey, table)][i]["id"])]
+	if !ok {
+		permissions = f.tablePermissions(moduleKey, table)
+	}
+	return &permissions, nil
+}
+
+// SetRecordPermissions replaces the record's permissions.
+func (f *FakeCustomData) SetRecordPermissions(ctx context.Context, moduleKey, table string, recordID interface{}, permissions kiket.CustomDataPermissions) (*kiket.CustomDataPermissions, error) {
+	if err := f.checkProject(); err != nil {
+		return nil, err
+	}
+	if err := kiket.ValidateCustomDataPermissions(permissions); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.find(moduleKey, table, recordID)
+	if i < 0 {
+		return nil, notFound(moduleKey, table, recordID)
+	}
+	permissions.Source = "record"
+	f.permissions[recordKey(moduleKey, table, f.tables[tableKey(moduleKey, table)][i]["id"])] = permissions
+	return &permissions, nil
+}
+
+// Records returns a copy of every record in a table, in creation order.
+func (f *FakeCustomData) Records(moduleKey, table string) []map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records := make([]map[string]interface{}, 0, len(f.tables[tableKey(moduleKey, table)]))
+	for _, record := range f.tables[tableKey(moduleKey, table)] {
+		records = append(records, copyRecord(record))
+	}
+	return records
+}
+
+var _ kiket.CustomDataClient = (*FakeCustomData)(nil)
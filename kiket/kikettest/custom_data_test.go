@@ -0,0 +1,72 @@
+package kikettest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func TestFakeCustomData_EvaluatesFilters(t *testing.T) {
+	data := NewFakeCustomData(7)
+	ctx := context.Background()
+
+	for _, record := range []map[string]interface{}{
+		{"name": "a", "status": "active", "score": 1},
+		{"name": "b", "status": "archived", "score": 2},
+		{"name": "c", "status": "active", "score": 3},
+		{"name": "d", "status": "pending"},
+	} {
+		if _, err := data.Create(ctx, "crm", "contacts", record); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	list, _ := data.List(ctx, "crm", "contacts", &kiket.CustomDataListOptions{
+		Filters: map[string]interface{}{"status": "active", "score": 3},
+	})
+	if len(list.Data) != 1 || list.Data[0]["name"] != "c" {
+		t.Errorf("Expected only c, got %v", list.Data)
+	}
+
+	list, _ = data.List(ctx, "crm", "contacts", &kiket.CustomDataListOptions{
+		Filters: map[string]interface{}{"status": []string{"active", "pending"}},
+		Limit:   2,
+	})
+	if len(list.Data) != 2 || list.Data[1]["name"] != "c" {
+		t.Errorf("Expected a and c, got %v", list.Data)
+	}
+
+	list, _ = data.List(ctx, "crm", "contacts", &kiket.CustomDataListOptions{
+		Filters: map[string]interface{}{"score": nil},
+	})
+	if len(list.Data) != 1 || list.Data[0]["name"] != "d" {
+		t.Errorf("Expected only d, got %v", list.Data)
+	}
+}
+
+func TestFakeCustomData_UpdateAndDelete(t *testing.T) {
+	data := NewFakeCustomData(7)
+	ctx := context.Background()
+
+	created, _ := data.Create(ctx, "crm", "contacts", map[string]interface{}{"name": "a"})
+	id := created.Data["id"]
+
+	updated, err := data.Update(ctx, "crm", "contacts", 1, map[string]interface{}{"name": "b"})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if updated.Data["name"] != "b" || updated.Data["id"] != id {
+		t.Errorf("Unexpected record: %v", updated.Data)
+	}
+
+	if err := data.Delete(ctx, "crm", "contacts", id); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	_, err = data.Get(ctx, "crm", "contacts", id)
+	var apiErr *kiket.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("Expected 404, got %v", err)
+	}
+}
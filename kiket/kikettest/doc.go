@@ -0,0 +1,2 @@
+// Package kikettest provides utilities for testing Kiket extensions.
+package kikettest
@@ -0,0 +1,76 @@
+package kikettest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// Payload returns a realistic sample payload for event at version ("v1"
+// when empty), matching the shape the platform sends. Each call returns a
+// fresh copy that tests may modify. It panics when no fixture exists; use
+// LoadPayload to check instead.
+func Payload(event, version string) kiket.WebhookPayload {
+	payload, err := LoadPayload(event, version)
+	if err != nil {
+		panic(err)
+	}
+	return payload
+}
+
+// LoadPayload is like Payload but returns an error for unknown
+// event/version pairs.
+func LoadPayload(event, version string) (kiket.WebhookPayload, error) {
+	if version == "" {
+		version = "v1"
+	}
+
+	data, err := fixtures.ReadFile(path.Join("fixtures", version, event+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("kikettest: no fixture for %s (%s)", event, version)
+	}
+
+	var payload kiket.WebhookPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("kikettest: invalid fixture for %s (%s): %w", event, version, err)
+	}
+	return payload, nil
+}
+
+// PayloadVersions returns the versions with a fixture for event, sorted.
+func PayloadVersions(event string) []string {
+	var versions []string
+	entries, _ := fs.ReadDir(fixtures, "fixtures")
+	for _, entry := range entries {
+		if _, err := fs.Stat(fixtures, path.Join("fixtures", entry.Name(), event+".json")); err == nil {
+			versions = append(versions, entry.Name())
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// PayloadEvents returns the events that have at least one fixture, sorted.
+func PayloadEvents() []string {
+	seen := map[string]bool{}
+	matches, _ := fs.Glob(fixtures, "fixtures/*/*.json")
+	for _, match := range matches {
+		seen[strings.TrimSuffix(path.Base(match), ".json")] = true
+	}
+
+	events := make([]string, 0, len(seen))
+	for event := range seen {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+	return events
+}
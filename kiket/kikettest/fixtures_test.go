@@ -0,0 +1,39 @@
+package kikettest
+
+import (
+	"testing"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func TestPayload_CoversKnownEvents(t *testing.T) {
+	for _, event := range kiket.KnownEvents {
+		for _, version := range PayloadVersions(event) {
+			payload, err := LoadPayload(event, version)
+			if err != nil {
+				t.Errorf("%s %s: %v", event, version, err)
+				continue
+			}
+			if payload["event"] != event {
+				t.Errorf("%s %s: expected event field %q, got %v", event, version, event, payload["event"])
+			}
+		}
+		if len(PayloadVersions(event)) == 0 {
+			t.Errorf("Expected a fixture for %s", event)
+		}
+	}
+}
+
+func TestPayload_DecodesIntoModels(t *testing.T) {
+	issue, err := Payload("issue.created", "v2").Issue()
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if issue.Key != "OPS-42" {
+		t.Errorf("Expected OPS-42, got %s", issue.Key)
+	}
+
+	if _, err := LoadPayload("issue.created", "v9"); err == nil {
+		t.Error("Expected error for unknown version")
+	}
+}
@@ -0,0 +1,124 @@
+package kikettest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+// EnvUpdateGolden rewrites golden files instead of comparing against them
+// when set to "1", e.g. KIKETTEST_UPDATE_GOLDEN=1 go test ./...
+const EnvUpdateGolden = "KIKETTEST_UPDATE_GOLDEN"
+
+// Transcript captures what a handler did: the value it returned (or its
+// error) and the API calls it made through a MockClient.
+type Transcript struct {
+	Response interface{}      `json:"response,omitempty"`
+	Error    string           `json:"error,omitempty"`
+	Calls    []TranscriptCall `json:"calls"`
+}
+
+// TranscriptCall is a Call in golden-file form, with the body kept as JSON.
+type TranscriptCall struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Params  map[string]string `json:"params,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// NewHandlerContext builds a HandlerContext whose Client and Endpoints
+// talk to mock.
+func NewHandlerContext(mock *MockClient, extensionID, event string) *kiket.HandlerContext {
+	endpoints := kiket.NewEndpoints(mock, extensionID, "v1")
+	return &kiket.HandlerContext{
+		Event:        event,
+		EventVersion: "v1",
+		Headers:      kiket.Headers{},
+		Client:       mock,
+		Endpoints:    endpoints,
+		Settings:     kiket.Settings{},
+		ExtensionID:  extensionID,
+		Secrets:      endpoints.Secrets,
+		Metrics:      kiket.NewMetrics(),
+	}
+}
+
+// Record runs handler with payload against mock and returns the
+// transcript. Only calls made during this run are included.
+func Record(ctx context.Context, handler kiket.WebhookHandler, mock *MockClient, payload kiket.WebhookPayload) *Transcript {
+	event, _ := payload["event"].(string)
+	before := len(mock.Calls())
+
+	response, err := handler(ctx, payload, NewHandlerContext(mock, "com.example.test", event))
+
+	transcript := &Transcript{Response: response, Calls: []TranscriptCall{}}
+	if err != nil {
+		transcript.Error = err.Error()
+	}
+	for _, call := range mock.Calls()[before:] {
+		transcript.Calls = append(transcript.Calls, TranscriptCall{
+			Method:  call.Method,
+			Path:    call.Path,
+			Params:  call.Params,
+			Headers: call.Headers,
+			Body:    call.Body,
+		})
+	}
+	return transcript
+}
+
+// AssertGolden compares got, encoded as indented JSON with map keys
+// sorted, against testdata/<name>.golden.json. With KIKETTEST_UPDATE_GOLDEN=1
+// the file is written instead.
+func AssertGolden(t testing.TB, name string, got interface{}) {
+	t.Helper()
+
+	actual, err := stableJSON(got)
+	if err != nil {
+		t.Fatalf("kikettest: cannot encode %s: %v", name, err)
+	}
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if os.Getenv(EnvUpdateGolden) == "1" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("kikettest: %v", err)
+		}
+		if err := os.WriteFile(path, actual, 0o644); err != nil {
+			t.Fatalf("kikettest: %v", err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("kikettest: cannot read golden file (run with %s=1 to create it): %v", EnvUpdateGolden, err)
+	}
+	expected = bytes.ReplaceAll(expected, []byte("\r\n"), []byte("\n"))
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("kikettest: %s does not match golden file %s\n--- expected\n%s\n--- got\n%s", name, path, expected, actual)
+	}
+}
+
+// stableJSON encodes v through a generic round trip so every object, struct
+// or map, is written with sorted keys.
+func stableJSON(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	out, err := json.MarshalIndent(generic, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
@@ -0,0 +1,27 @@
+package kikettest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func TestRecord_MatchesGolden(t *testing.T) {
+	mock := NewMockClient()
+	mock.On("POST", "/api/v1/ext/issues/*/labels").Return(`{"data": {"id": 1042, "labels": ["payments", "triaged"]}}`)
+
+	handler := func(ctx context.Context, payload kiket.WebhookPayload, hctx *kiket.HandlerContext) (interface{}, error) {
+		issue, err := payload.Issue()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := hctx.Endpoints.Issues().AddLabel(ctx, issue.ID, "triaged"); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"status": "labelled", "issue": issue.Key}, nil
+	}
+
+	transcript := Record(context.Background(), handler, mock, Payload("issue.created", "v1"))
+	AssertGolden(t, "issue_created_triage", transcript)
+}
@@ -0,0 +1,292 @@
+package kikettest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+// Call records a request made through a MockClient.
+type Call struct {
+	Method  string
+	Path    string
+	Body    []byte // JSON-encoded request body, nil for GET and DELETE
+	Params  map[string]string
+	Headers kiket.Headers
+}
+
+// Expectation is a stubbed request. Configure it with the With* and Return*
+// methods returned by MockClient.On.
+type Expectation struct {
+	method    string
+	path      string
+	body      interface{}
+	bodyMatch func([]byte) bool
+	params    map[string]string
+
+	status   int
+	response []byte
+	err      error
+
+	times int // 0 = any number of calls
+	calls int
+}
+
+// MockClient is a scriptable kiket.Client for unit tests. Requests are
+// matched against expectations in registration order; an expectation whose
+// Times limit is used up no longer matches.
+//
+//	mock := kikettest.NewMockClient()
+//	mock.On("GET", "/api/v1/ext/issues/42").Return(`{"data": {"id": 42}}`)
+//	mock.On("POST", "/api/v1/ext/issues/*/labels").WithBody(map[string]string{"label": "bug"}).Once()
+//
+//	issue, err := kiket.NewIssuesClient(mock).Get(ctx, 42)
+//	mock.AssertExpectations(t)
+type MockClient struct {
+	mu           sync.Mutex
+	expectations []*Expectation
+	calls        []Call
+	unexpected   []Call
+}
+
+// NewMockClient creates a mock client without expectations.
+func NewMockClient() *MockClient {
+	return &MockClient{}
+}
+
+// On registers an expectation for method and path. The path may contain
+// path.Match wildcards such as "*" for a single segment.
+func (m *MockClient) On(method, path string) *Expectation {
+	e := &Expectation{method: strings.ToUpper(method), path: path, status: http.StatusOK, response: []byte("{}")}
+
+	m.mu.Lock()
+	m.expectations = append(m.expectations, e)
+	m.mu.Unlock()
+	return e
+}
+
+// WithBody matches requests whose JSON body equals body once both are
+// encoded and decoded as JSON.
+func (e *Expectation) WithBody(body interface{}) *Expectation {
+	e.body = body
+	return e
+}
+
+// WithBodyMatch matches requests for which fn returns true.
+func (e *Expectation) WithBodyMatch(fn func(body []byte) bool) *Expectation {
+	e.bodyMatch = fn
+	return e
+}
+
+// WithParams matches requests that carry at least these query parameters.
+func (e *Expectation) WithParams(params map[string]string) *Expectation {
+	e.params = params
+	return e
+}
+
+// Return responds with body: a string or []byte is sent as is, anything
+// else is encoded as JSON.
+func (e *Expectation) Return(body interface{}) *Expectation {
+	return e.ReturnStatus(http.StatusOK, body)
+}
+
+// ReturnStatus responds with a status code and body. Statuses of 400 and
+// above produce a *kiket.APIError, as the real client does.
+func (e *Expectation) ReturnStatus(status int, body interface{}) *Expectation {
+	e.status = status
+	switch b := body.(type) {
+	case nil:
+		e.response = []byte("{}")
+	case string:
+		e.response = []byte(b)
+	case []byte:
+		e.response = b
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			panic(fmt.Sprintf("kikettest: cannot encode response: %v", err))
+		}
+		e.response = data
+	}
+	return e
+}
+
+// ReturnError makes the request fail with err, e.g. to simulate a network
+// error.
+func (e *Expectation) ReturnError(err error) *Expectation {
+	e.err = err
+	return e
+}
+
+// Times limits the expectation to n calls and makes AssertExpectations
+// require exactly n.
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+// Once is Times(1).
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+func (e *Expectation) String() string {
+	return e.method + " " + e.path
+}
+
+func (e *Expectation) matches(call Call) bool {
+	if e.method != call.Method {
+		return false
+	}
+	if ok, _ := path.Match(e.path, call.Path); !ok {
+		return false
+	}
+	if e.times > 0 && e.calls >= e.times {
+		return false
+	}
+	for k, v := range e.params {
+		if call.Params[k] != v {
+			return false
+		}
+	}
+	if e.bodyMatch != nil && !e.bodyMatch(call.Body) {
+		return false
+	}
+	if e.body != nil && !jsonEqual(e.body, call.Body) {
+		return false
+	}
+	return true
+}
+
+func jsonEqual(expected interface{}, actual []byte) bool {
+	want, err := json.Marshal(expected)
+	if err != nil {
+		return false
+	}
+	var a, b interface{}
+	if json.Unmarshal(want, &a) != nil || json.Unmarshal(actual, &b) != nil {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func (m *MockClient) do(method, path string, data interface{}, opts *kiket.RequestOptions) ([]byte, error) {
+	call := Call{Method: method, Path: path}
+	if data != nil {
+		body, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		call.Body = body
+	}
+	if opts != nil {
+		call.Params = opts.Params
+		call.Headers = opts.Headers
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, call)
+	for _, e := range m.expectations {
+		if !e.matches(call) {
+			continue
+		}
+		e.calls++
+		if e.err != nil {
+			return nil, e.err
+		}
+		if e.status >= 400 {
+			return nil, &kiket.APIError{StatusCode: e.status, Body: string(e.response)}
+		}
+		return bytes.Clone(e.response), nil
+	}
+
+	m.unexpected = append(m.unexpected, call)
+	return nil, fmt.Errorf("kikettest: unexpected call %s %s", method, path)
+}
+
+// Get records a GET request and returns the matching stub.
+func (m *MockClient) Get(ctx context.Context, path string, opts *kiket.RequestOptions) ([]byte, error) {
+	return m.do(http.MethodGet, path, nil, opts)
+}
+
+// Post records a POST request and returns the matching stub.
+func (m *MockClient) Post(ctx context.Context, path string, data interface{}, opts *kiket.RequestOptions) ([]byte, error) {
+	return m.do(http.MethodPost, path, data, opts)
+}
+
+// Put records a PUT request and returns the matching stub.
+func (m *MockClient) Put(ctx context.Context, path string, data interface{}, opts *kiket.RequestOptions) ([]byte, error) {
+	return m.do(http.MethodPut, path, data, opts)
+}
+
+// Patch records a PATCH request and returns the matching stub.
+func (m *MockClient) Patch(ctx context.Context, path string, data interface{}, opts *kiket.RequestOptions) ([]byte, error) {
+	return m.do(http.MethodPatch, path, data, opts)
+}
+
+// Delete records a DELETE request and returns the matching stub.
+func (m *MockClient) Delete(ctx context.Context, path string, opts *kiket.RequestOptions) ([]byte, error) {
+	return m.do(http.MethodDelete, path, nil, opts)
+}
+
+// Close does nothing.
+func (m *MockClient) Close() error {
+	return nil
+}
+
+// Calls returns every request made so far, in order.
+func (m *MockClient) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// CallCount returns how many requests matched method and path (wildcards
+// allowed).
+func (m *MockClient) CallCount(method, pattern string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, call := range m.calls {
+		if ok, _ := path.Match(pattern, call.Path); ok && call.Method == strings.ToUpper(method) {
+			count++
+		}
+	}
+	return count
+}
+
+// AssertExpectations fails the test if an expectation was not called (or
+// not called exactly Times(n) times) or if a request matched nothing.
+func (m *MockClient) AssertExpectations(t testing.TB) {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, e := range m.expectations {
+		switch {
+		case e.times > 0 && e.calls != e.times:
+			t.Errorf("kikettest: expected %s to be called %d time(s), got %d", e, e.times, e.calls)
+		case e.times == 0 && e.calls == 0:
+			t.Errorf("kikettest: expected %s to be called", e)
+		}
+	}
+	for _, call := range m.unexpected {
+		t.Errorf("kikettest: unexpected call %s %s", call.Method, call.Path)
+	}
+}
+
+var _ kiket.Client = (*MockClient)(nil)
@@ -0,0 +1,51 @@
+package kikettest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func TestMockClient_MatchesAndCounts(t *testing.T) {
+	mock := NewMockClient()
+	mock.On("GET", "/api/v1/ext/issues/*").Return(`{"data": {"id": 42, "title": "Bug"}}`)
+	mock.On("POST", "/api/v1/ext/issues").WithBody(map[string]interface{}{"title": "New"}).
+		ReturnStatus(201, map[string]interface{}{"data": map[string]interface{}{"id": 43}}).Once()
+
+	issues := kiket.NewIssuesClient(mock)
+	ctx := context.Background()
+
+	issue, err := issues.Get(ctx, 42)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if issue.Title != "Bug" {
+		t.Errorf("Expected Bug, got %s", issue.Title)
+	}
+
+	if _, err := mock.Post(ctx, "/api/v1/ext/issues", map[string]string{"title": "New"}, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := mock.Post(ctx, "/api/v1/ext/issues", map[string]string{"title": "New"}, nil); err == nil {
+		t.Error("Expected error once Times limit is used up")
+	}
+
+	if got := mock.CallCount("POST", "/api/v1/ext/issues"); got != 2 {
+		t.Errorf("Expected 2 calls, got %d", got)
+	}
+}
+
+func TestMockClient_ReturnStatusProducesAPIError(t *testing.T) {
+	mock := NewMockClient()
+	mock.On("DELETE", "/api/v1/ext/issues/7").ReturnStatus(404, `{"error": "not found"}`)
+
+	_, err := mock.Delete(context.Background(), "/api/v1/ext/issues/7", nil)
+
+	var apiErr *kiket.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Fatalf("Expected 404 APIError, got %v", err)
+	}
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,109 @@
+package kikettest
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+// SecretMutation records a change made through FakeSecrets.
+type SecretMutation struct {
+	Op    string // "set", "delete", or "rotate"
+	Key   string
+	Value string // empty for deletes
+}
+
+// FakeSecrets is an in-memory kiket.SecretManager. Assign it to
+// HandlerContext.Secrets or Endpoints.Secrets to test handlers without
+// stubbing HTTP. Like the API-backed manager, Get returns an empty string
+// for missing keys.
+type FakeSecrets struct {
+	mu        sync.Mutex
+	values    map[string]string
+	mutations []SecretMutation
+}
+
+// NewFakeSecrets creates a fake seeded with initial values.
+func NewFakeSecrets(initial map[string]string) *FakeSecrets {
+	values := make(map[string]string, len(initial))
+	for k, v := range initial {
+		values[k] = v
+	}
+	return &FakeSecrets{values: values}
+}
+
+// Get returns the value for key, or "" if it is not set.
+func (f *FakeSecrets) Get(ctx context.Context, key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.values[key], nil
+}
+
+// Set stores value under key.
+func (f *FakeSecrets) Set(ctx context.Context, key string, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.values[key] = value
+	f.mutations = append(f.mutations, SecretMutation{Op: "set", Key: key, Value: value})
+	return nil
+}
+
+// Delete removes key.
+func (f *FakeSecrets) Delete(ctx context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.values, key)
+	f.mutations = append(f.mutations, SecretMutation{Op: "delete", Key: key})
+	return nil
+}
+
+// List returns the stored keys, sorted.
+func (f *FakeSecrets) List(ctx context.Context) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	keys := make([]string, 0, len(f.values))
+	for k := range f.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Rotate replaces the value for key. It is recorded as a single "rotate"
+// mutation.
+func (f *FakeSecrets) Rotate(ctx context.Context, key string, newValue string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.values[key] = newValue
+	f.mutations = append(f.mutations, SecretMutation{Op: "rotate", Key: key, Value: newValue})
+	return nil
+}
+
+// Values returns a copy of the current secrets.
+func (f *FakeSecrets) Values() map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	values := make(map[string]string, len(f.values))
+	for k, v := range f.values {
+		values[k] = v
+	}
+	return values
+}
+
+// Mutations returns the changes made since creation, in order.
+func (f *FakeSecrets) Mutations() []SecretMutation {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]SecretMutation(nil), f.mutations...)
+}
+
+var _ kiket.SecretManager = (*FakeSecrets)(nil)
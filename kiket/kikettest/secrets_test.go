@@ -0,0 +1,29 @@
+package kikettest
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFakeSecrets_TracksMutations(t *testing.T) {
+	secrets := NewFakeSecrets(map[string]string{"API_KEY": "old"})
+	ctx := context.Background()
+
+	if err := secrets.Rotate(ctx, "API_KEY", "new"); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	secrets.Set(ctx, "TOKEN", "t")
+	secrets.Delete(ctx, "TOKEN")
+
+	if v, _ := secrets.Get(ctx, "API_KEY"); v != "new" {
+		t.Errorf("Expected new, got %s", v)
+	}
+	if v, _ := secrets.Get(ctx, "TOKEN"); v != "" {
+		t.Errorf("Expected empty value for deleted key, got %s", v)
+	}
+
+	mutations := secrets.Mutations()
+	if len(mutations) != 3 || mutations[0].Op != "rotate" || mutations[2].Op != "delete" {
+		t.Errorf("Unexpected mutations: %+v", mutations)
+	}
+}
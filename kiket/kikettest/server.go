@@ -0,0 +1,474 @@
+package kikettest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+// ExtensionEvent is an event logged through Endpoints.LogEvent.
+type ExtensionEvent struct {
+	ExtensionID string                 `json:"-"`
+	Event       string                 `json:"event"`
+	Version     string                 `json:"version"`
+	Data        map[string]interface{} `json:"data"`
+	Timestamp   string                 `json:"timestamp"`
+}
+
+// Server emulates the subset of the Kiket API the SDK calls: secrets,
+// settings, extension events, custom data, SLA events and timers, and
+// telemetry. Point
+// an SDK or extension binary at it with Config, then inspect the state it
+// recorded.
+//
+//	srv := kikettest.NewServer()
+//	defer srv.Close()
+//
+//	sdk, err := kiket.New(srv.Config("com.example.ext"))
+type Server struct {
+	*httptest.Server
+
+	// APIKey, when set, must be sent as X-Kiket-API-Key on every request.
+	APIKey string
+
+	mu         sync.Mutex
+	secrets    map[string]map[string]string
+	settings   map[string]kiket.Settings
+	events     []ExtensionEvent
+	customData map[string]*FakeCustomData
+	slaEvents  []kiket.SLAEventRecord
+	slaTimers  map[string]kiket.SLATimer
+	telemetry  []map[string]interface{}
+}
+
+// NewServer starts an emulator with empty state.
+func NewServer() *Server {
+	s := &Server{
+		secrets:    map[string]map[string]string{},
+		settings:   map[string]kiket.Settings{},
+		customData: map[string]*FakeCustomData{},
+		slaTimers:  map[string]kiket.SLATimer{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Config returns an SDK configuration that talks to the emulator.
+func (s *Server) Config(extensionID string) kiket.Config {
+	apiKey := s.APIKey
+	if apiKey == "" {
+		apiKey = "test-api-key"
+	}
+	return kiket.Config{
+		BaseURL:         s.URL,
+		TelemetryURL:    s.URL,
+		ExtensionID:     extensionID,
+		ExtensionAPIKey: apiKey,
+		WebhookSecret:   "test-webhook-secret",
+	}
+}
+
+// SetSecret stores a secret for an extension.
+func (s *Server) SetSecret(extensionID, key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.secrets[extensionID] == nil {
+		s.secrets[extensionID] = map[string]string{}
+	}
+	s.secrets[extensionID][key] = value
+}
+
+// Secrets returns a copy of an extension's secrets.
+func (s *Server) Secrets(extensionID string) map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := map[string]string{}
+	for k, v := range s.secrets[extensionID] {
+		out[k] = v
+	}
+	return out
+}
+
+// SetSettings sets the workspace-configured settings of an extension.
+func (s *Server) SetSettings(extensionID string, settings kiket.Settings) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.settings[extensionID] = settings
+}
+
+// Events returns the extension events logged so far.
+func (s *Server) Events() []ExtensionEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]ExtensionEvent(nil), s.events...)
+}
+
+// CustomData returns the custom data store for a project, for seeding and
+// inspection.
+func (s *Server) CustomData(projectID interface{}) *FakeCustomData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.customDataFor(fmt.Sprint(projectID))
+}
+
+func (s *Server) customDataFor(projectID string) *FakeCustomData {
+	data, ok := s.customData[projectID]
+	if !ok {
+		data = NewFakeCustomData(projectID)
+		s.customData[projectID] = data
+	}
+	return data
+}
+
+// AddSLAEvent adds an SLA event returned by the SLA events endpoint.
+func (s *Server) AddSLAEvent(event kiket.SLAEventRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.slaEvents = append(s.slaEvents, event)
+}
+
+// SLATimer returns the SLA timer state of an issue, and false when its
+// timers were never paused.
+func (s *Server) SLATimer(issueID interface{}) (kiket.SLATimer, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timer, ok := s.slaTimers[fmt.Sprint(issueID)]
+	return timer, ok
+}
+
+// Telemetry returns the telemetry records received so far.
+func (s *Server) Telemetry() []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]map[string]interface{}(nil), s.telemetry...)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func readBody(r *http.Request, out interface{}) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, out)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if s.APIKey != "" && r.Header.Get("X-Kiket-API-Key") != s.APIKey {
+		writeError(w, http.StatusUnauthorized, "invalid API key")
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case path == "/telemetry":
+		s.serveTelemetry(w, r)
+	case strings.HasPrefix(path, "/api/v1/extensions/"):
+		s.serveExtension(w, r, strings.Split(strings.TrimPrefix(path, "/api/v1/extensions/"), "/"))
+	case strings.HasPrefix(path, "/api/v1/ext/custom_data/"):
+		s.serveCustomData(w, r, strings.Split(strings.TrimPrefix(path, "/api/v1/ext/custom_data/"), "/"))
+	case path == "/api/v1/ext/sla/events":
+		s.serveSLAEvents(w, r)
+	case strings.HasPrefix(path, "/api/v1/ext/sla/timers/"):
+		s.serveSLATimer(w, r, strings.TrimPrefix(path, "/api/v1/ext/sla/timers/"))
+	default:
+		writeError(w, http.StatusNotFound, "not found: "+r.Method+" "+r.URL.Path)
+	}
+}
+
+func (s *Server) serveTelemetry(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var record map[string]interface{}
+	if err := readBody(r, &record); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	s.telemetry = append(s.telemetry, record)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusAccepted, map[string]string{})
+}
+
+// serveExtension handles /api/v1/extensions/{id}/...
+func (s *Server) serveExtension(w http.ResponseWriter, r *http.Request, parts []string) {
+	extensionID := parts[0]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": extensionID})
+
+	case len(parts) == 2 && parts[1] == "settings" && r.Method == http.MethodGet:
+		settings := s.settings[extensionID]
+		if settings == nil {
+			settings = kiket.Settings{}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"settings": settings})
+
+	case len(parts) == 2 && parts[1] == "events" && r.Method == http.MethodPost:
+		var event ExtensionEvent
+		if err := readBody(r, &event); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		event.ExtensionID = extensionID
+		s.events = append(s.events, event)
+		writeJSON(w, http.StatusCreated, map[string]string{})
+
+	case len(parts) == 2 && parts[1] == "secrets" && r.Method == http.MethodGet:
+		keys := make([]string, 0, len(s.secrets[extensionID]))
+		for k := range s.secrets[extensionID] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"keys": keys})
+
+	case len(parts) == 3 && parts[1] == "secrets":
+		s.serveSecret(w, r, extensionID, parts[2])
+
+	default:
+		writeError(w, http.StatusNotFound, "not found: "+r.Method+" "+r.URL.Path)
+	}
+}
+
+func (s *Server) serveSecret(w http.ResponseWriter, r *http.Request, extensionID, key string) {
+	secrets := s.secrets[extensionID]
+
+	switch r.Method {
+	case http.MethodGet:
+		value, ok := secrets[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "secret not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"value": value})
+	case http.MethodPost, http.MethodPut:
+		var body struct {
+			Value string `json:"value"`
+		}
+		if err := readBody(r, &body); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if secrets == nil {
+			secrets = map[string]string{}
+			s.secrets[extensionID] = secrets
+		}
+		secrets[key] = body.Value
+		writeJSON(w, http.StatusOK, map[string]string{})
+	case http.MethodDelete:
+		delete(secrets, key)
+		writeJSON(w, http.StatusOK, map[string]string{})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// serveCustomData handles /api/v1/ext/custom_data/{module}/{table}[/{id}]
+// and their /permissions.
+func (s *Server) serveCustomData(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) < 2 || len(parts) > 4 || (len(parts) == 4 && parts[3] != "permissions") {
+		writeError(w, http.StatusNotFound, "not found: "+r.Method+" "+r.URL.Path)
+		return
+	}
+	projectID := r.URL.Query().Get("project_id")
+	if projectID == "" {
+		writeError(w, http.StatusBadRequest, "project_id is required")
+		return
+	}
+
+	s.mu.Lock()
+	data := s.customDataFor(projectID)
+	s.mu.Unlock()
+
+	moduleKey, table := parts[0], parts[1]
+	ctx := r.Context()
+
+	var body struct {
+		Record      map[string]interface{}       `json:"record"`
+		Permissions *kiket.CustomDataPermissions `json:"permissions"`
+	}
+	if err := readBody(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var (
+		result interface{}
+		err    error
+		status = http.StatusOK
+	)
+	switch {
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		opts := &kiket.CustomDataListOptions{}
+		opts.Limit, _ = strconv.Atoi(r.URL.Query().Get("limit"))
+		opts.Page, _ = strconv.Atoi(r.URL.Query().Get("page"))
+		if filters := r.URL.Query().Get("filters"); filters != "" {
+			if err := json.Unmarshal([]byte(filters), &opts.Filters); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid filters")
+				return
+			}
+		}
+		result, err = data.List(ctx, moduleKey, table, opts)
+	case len(parts) == 2 && r.Method == http.MethodPost && body.Permissions != nil:
+		result, err = data.CreateWithPermissions(ctx, moduleKey, table, body.Record, *body.Permissions)
+		status = http.StatusCreated
+	case len(parts) == 2 && r.Method == http.MethodPost:
+		result, err = data.Create(ctx, moduleKey, table, body.Record)
+		status = http.StatusCreated
+	case len(parts) == 3 && parts[2] == "permissions" && r.Method == http.MethodGet:
+		result, err = dataEnvelope(data.TablePermissions(ctx, moduleKey, table))
+	case len(parts) == 4 && r.Method == http.MethodGet:
+		result, err = dataEnvelope(data.RecordPermissions(ctx, moduleKey, table, parts[2]))
+	case len(parts) == 4 && r.Method == http.MethodPut:
+		if body.Permissions == nil {
+			writeError(w, http.StatusBadRequest, "permissions are required")
+			return
+		}
+		result, err = dataEnvelope(data.SetRecordPermissions(ctx, moduleKey, table, parts[2], *body.Permissions))
+	case len(parts) == 3 && r.Method == http.MethodGet:
+		result, err = data.Get(ctx, moduleKey, table, parts[2])
+	case len(parts) == 3 && r.Method == http.MethodPatch:
+		result, err = data.Update(ctx, moduleKey, table, parts[2], body.Record)
+	case len(parts) == 3 && r.Method == http.MethodDelete:
+		err = data.Delete(ctx, moduleKey, table, parts[2])
+		result = map[string]string{}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if err != nil {
+		var apiErr *kiket.APIError
+		if errors.As(err, &apiErr) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(apiErr.StatusCode)
+			io.WriteString(w, apiErr.Body)
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, status, result)
+}
+
+// dataEnvelope wraps a result in the {"data": ...} envelope.
+func dataEnvelope(result interface{}, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"data": result}, nil
+}
+
+func (s *Server) serveSLAEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := []kiket.SLAEventRecord{}
+	for _, event := range s.slaEvents {
+		if fmt.Sprint(event.ProjectID) != query.Get("project_id") {
+			continue
+		}
+		if issueID := query.Get("issue_id"); issueID != "" && fmt.Sprint(event.IssueID) != issueID {
+			continue
+		}
+		if state := query.Get("state"); state != "" && event.State != state {
+			continue
+		}
+		if limit > 0 && len(events) >= limit {
+			break
+		}
+		events = append(events, event)
+	}
+	writeJSON(w, http.StatusOK, kiket.SLAEventsListResponse{Data: events})
+}
+
+func (s *Server) serveSLATimer(w http.ResponseWriter, r *http.Request, action string) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var body struct {
+		IssueID interface{} `json:"issue_id"`
+		Reason  string      `json:"reason"`
+	}
+	if err := readBody(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := fmt.Sprint(body.IssueID)
+	timer := s.slaTimers[key]
+	timer.IssueID = body.IssueID
+	timer.ProjectID = r.URL.Query().Get("project_id")
+	switch action {
+	case "pause":
+		if timer.Paused {
+			writeError(w, http.StatusConflict, "SLA timers already paused")
+			return
+		}
+		pausedAt := time.Now().UTC().Format(time.RFC3339)
+		timer.Paused, timer.Reason, timer.PausedAt = true, body.Reason, &pausedAt
+	case "resume":
+		if !timer.Paused {
+			writeError(w, http.StatusConflict, "SLA timers not paused")
+			return
+		}
+		if pausedAt, err := time.Parse(time.RFC3339, *timer.PausedAt); err == nil {
+			timer.PausedSeconds += int64(time.Since(pausedAt) / time.Second)
+		}
+		timer.Paused, timer.Reason, timer.PausedAt = false, "", nil
+	default:
+		writeError(w, http.StatusNotFound, "not found: "+r.Method+" "+r.URL.Path)
+		return
+	}
+	s.slaTimers[key] = timer
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": timer})
+}
@@ -0,0 +1,93 @@
+package kikettest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func TestServer_EndToEnd(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.SetSecret("com.example.ext", "API_TOKEN", "s3cret")
+	srv.AddSLAEvent(kiket.SLAEventRecord{ID: 1, IssueID: 42, ProjectID: 7, State: "breached"})
+
+	sdk, err := kiket.New(srv.Config("com.example.ext"))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	ctx := context.Background()
+	endpoints := sdk.Endpoints()
+
+	token, err := endpoints.Secrets.Get(ctx, "API_TOKEN")
+	if err != nil || token != "s3cret" {
+		t.Errorf("Expected s3cret, got %q (%v)", token, err)
+	}
+	if err := endpoints.Secrets.Set(ctx, "OTHER", "x"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if srv.Secrets("com.example.ext")["OTHER"] != "x" {
+		t.Error("Expected secret to be stored on the server")
+	}
+
+	customData := endpoints.CustomData(7)
+	if _, err := customData.Create(ctx, "crm", "contacts", map[string]interface{}{"status": "active"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	list, err := customData.List(ctx, "crm", "contacts", &kiket.CustomDataListOptions{
+		Filters: map[string]interface{}{"status": "active"},
+	})
+	if err != nil || len(list.Data) != 1 {
+		t.Errorf("Expected one record, got %v (%v)", list, err)
+	}
+	if len(srv.CustomData(7).Records("crm", "contacts")) != 1 {
+		t.Error("Expected record to be visible on the server")
+	}
+
+	srv.CustomData(7).SetTablePermissions("crm", "contacts", kiket.CustomDataPermissions{Visibility: kiket.CustomDataVisibilityProject})
+	restricted := kiket.CustomDataPermissions{Visibility: kiket.CustomDataVisibilityRestricted, ReadRoles: []string{"admin"}}
+	created, err := customData.CreateWithPermissions(ctx, "crm", "contacts", map[string]interface{}{"status": "vip"}, restricted)
+	if err != nil {
+		t.Fatalf("CreateWithPermissions failed: %v", err)
+	}
+	perms, err := customData.RecordPermissions(ctx, "crm", "contacts", created.Data["id"])
+	if err != nil || perms.Visibility != "restricted" || perms.Source != "record" {
+		t.Errorf("Expected restricted record permissions, got %+v (%v)", perms, err)
+	}
+	perms, err = customData.RecordPermissions(ctx, "crm", "contacts", list.Data[0]["id"])
+	if err != nil || perms.Visibility != "project" || perms.Source != "table" {
+		t.Errorf("Expected table permissions, got %+v (%v)", perms, err)
+	}
+	if _, err := customData.SetRecordPermissions(ctx, "crm", "contacts", created.Data["id"], kiket.CustomDataPermissions{Visibility: "restricted"}); err == nil {
+		t.Error("Expected restricted permissions without readers to be rejected")
+	}
+
+	sla, err := endpoints.SLAEvents(7).List(ctx, &kiket.SLAEventsListOptions{State: "breached"})
+	if err != nil || len(sla.Data) != 1 {
+		t.Errorf("Expected one SLA event, got %v (%v)", sla, err)
+	}
+	timer, err := endpoints.SLAEvents(7).Pause(ctx, 42, "waiting on vendor")
+	if err != nil || !timer.Paused || timer.Reason != "waiting on vendor" {
+		t.Errorf("Expected paused timer, got %+v (%v)", timer, err)
+	}
+	if _, err := endpoints.SLAEvents(7).Pause(ctx, 42, "again"); err == nil {
+		t.Error("Expected pausing a paused timer to fail")
+	}
+	if _, err := endpoints.SLAEvents(7).Resume(ctx, 42); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if timer, ok := srv.SLATimer(42); !ok || timer.Paused {
+		t.Errorf("Expected resumed timer on the server, got %+v", timer)
+	}
+
+	if err := endpoints.LogEvent(ctx, "sync.completed", map[string]interface{}{"count": 3}); err != nil {
+		t.Fatalf("LogEvent failed: %v", err)
+	}
+	if events := srv.Events(); len(events) != 1 || events[0].Event != "sync.completed" {
+		t.Errorf("Unexpected events: %+v", events)
+	}
+}
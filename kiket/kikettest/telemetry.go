@@ -0,0 +1,110 @@
+package kikettest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+// captureWait is how long AssertRecorded waits for the reporter's
+// background flush.
+const captureWait = 2 * time.Second
+
+// TelemetryCapture is a telemetry exporter that keeps every record in
+// memory for assertions.
+//
+//	capture := kikettest.CaptureTelemetry()
+//	sdk, err := kiket.New(kiket.Config{
+//		TelemetryEnabled: true,
+//		TelemetryOptions: []kiket.TelemetryOption{capture.Option()},
+//	})
+//	...
+//	record := capture.AssertRecorded(t, "issue.created", "ok")
+type TelemetryCapture struct {
+	mu      sync.Mutex
+	records []kiket.TelemetryRecord
+	notify  chan struct{}
+}
+
+// CaptureTelemetry creates an empty capture.
+func CaptureTelemetry() *TelemetryCapture {
+	return &TelemetryCapture{notify: make(chan struct{}, 1)}
+}
+
+// Option installs the capture as an exporter and flushes after every
+// record so assertions see them promptly.
+func (c *TelemetryCapture) Option() kiket.TelemetryOption {
+	return func(r *kiket.TelemetryReporter) {
+		kiket.WithTelemetryExporter(c)(r)
+		kiket.WithTelemetryBatchSize(1)(r)
+	}
+}
+
+// Export implements kiket.TelemetryExporter.
+func (c *TelemetryCapture) Export(ctx context.Context, records []kiket.TelemetryRecord) error {
+	c.mu.Lock()
+	c.records = append(c.records, records...)
+	c.mu.Unlock()
+
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Records returns every captured record, in order.
+func (c *TelemetryCapture) Records() []kiket.TelemetryRecord {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]kiket.TelemetryRecord(nil), c.records...)
+}
+
+// Find returns the captured records for event.
+func (c *TelemetryCapture) Find(event string) []kiket.TelemetryRecord {
+	var found []kiket.TelemetryRecord
+	for _, record := range c.Records() {
+		if record.Event == event {
+			found = append(found, record)
+		}
+	}
+	return found
+}
+
+// Reset discards the captured records.
+func (c *TelemetryCapture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.records = nil
+}
+
+// AssertRecorded waits briefly for a record of event with status ("ok" or
+// "error") and fails the test if none arrives. It returns the first match.
+func (c *TelemetryCapture) AssertRecorded(t testing.TB, event, status string) kiket.TelemetryRecord {
+	t.Helper()
+
+	deadline := time.NewTimer(captureWait)
+	defer deadline.Stop()
+
+	for {
+		for _, record := range c.Find(event) {
+			if record.Status == status {
+				return record
+			}
+		}
+
+		select {
+		case <-c.notify:
+		case <-deadline.C:
+			t.Fatalf("kikettest: no %q telemetry record with status %q (captured %d records)", event, status, len(c.Records()))
+			return kiket.TelemetryRecord{}
+		}
+	}
+}
+
+var _ kiket.TelemetryExporter = (*TelemetryCapture)(nil)
@@ -0,0 +1,40 @@
+package kikettest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func TestCaptureTelemetry_RecordsHandlerOutcomes(t *testing.T) {
+	capture := CaptureTelemetry()
+	sdk, err := kiket.New(kiket.Config{
+		ExtensionID:      "com.example.ext",
+		ExtensionAPIKey:  "key",
+		WebhookSecret:    "secret",
+		TelemetryEnabled: true,
+		TelemetryOptions: []kiket.TelemetryOption{capture.Option()},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	sdk.On("issue.created", func(ctx context.Context, payload kiket.WebhookPayload, hctx *kiket.HandlerContext) (interface{}, error) {
+		return nil, nil
+	})
+	sdk.On("issue.closed", func(ctx context.Context, payload kiket.WebhookPayload, hctx *kiket.HandlerContext) (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+
+	Deliver(sdk, "issue.created", Payload("issue.created", "v1"), nil)
+	Deliver(sdk, "issue.closed", Payload("issue.closed", "v1"), nil)
+
+	capture.AssertRecorded(t, "issue.created", "ok")
+	record := capture.AssertRecorded(t, "issue.closed", "error")
+	if record.ErrorMessage != "boom" {
+		t.Errorf("Expected boom, got %s", record.ErrorMessage)
+	}
+}
@@ -0,0 +1,64 @@
+package kikettest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func TestDeliver_SignsAndDispatches(t *testing.T) {
+	sdk, err := kiket.New(kiket.Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	var title string
+	sdk.On("issue.created", func(ctx context.Context, payload kiket.WebhookPayload, hctx *kiket.HandlerContext) (interface{}, error) {
+		issue, err := payload.Issue()
+		if err != nil {
+			return nil, err
+		}
+		title = issue.Title
+		return map[string]string{"status": "ok"}, nil
+	})
+
+	rec, err := Deliver(sdk, "issue.created", map[string]interface{}{
+		"issue": map[string]interface{}{"id": 1, "title": "Bug"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Deliver failed: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if title != "Bug" {
+		t.Errorf("Expected Bug, got %s", title)
+	}
+	if !strings.Contains(rec.Body.String(), `"ok"`) {
+		t.Errorf("Expected handler result in body, got %s", rec.Body.String())
+	}
+}
+
+func TestNewWebhookRequest_WrongSecretIsRejected(t *testing.T) {
+	req, err := NewWebhookRequest("wrong", "issue.created", nil, nil)
+	if err != nil {
+		t.Fatalf("NewWebhookRequest failed: %v", err)
+	}
+
+	headers := kiket.Headers{}
+	for k := range req.Header {
+		headers[k] = req.Header.Get(k)
+	}
+	body := `{"event":"issue.created"}`
+	if err := kiket.VerifySignature("secret", []byte(body), headers); !kiket.IsAuthenticationError(err) {
+		t.Errorf("Expected authentication error, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package kiketzstd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+func TestCodec_RoundTripsRequestsAndResponses(t *testing.T) {
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+
+		body := r.Body
+		if r.Header.Get("Content-Encoding") == "zstd" {
+			zr, err := zstd.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("Invalid zstd body: %v", err)
+				return
+			}
+			defer zr.Close()
+			body = io.NopCloser(zr)
+		}
+		data, _ := io.ReadAll(body)
+
+		w.Header().Set("Accept-Encoding", "zstd, gzip")
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "zstd") {
+			t.Errorf("Expected zstd in Accept-Encoding, got %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "zstd")
+		zw, _ := zstd.NewWriter(w)
+		zw.Write(data)
+		zw.Close()
+	}))
+	defer server.Close()
+
+	client := kiket.NewHTTPClient(kiket.WithBaseURL(server.URL), kiket.WithContentEncoding(Codec(), kiket.GzipCodec()))
+	large := map[string]string{"blob": strings.Repeat("x", 4096)}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(context.Background(), "/echo", large, nil)
+		if err != nil {
+			t.Fatalf("Post failed: %v", err)
+		}
+		if !strings.Contains(string(resp), strings.Repeat("x", 4096)) {
+			t.Errorf("Expected decompressed echo, got %d bytes", len(resp))
+		}
+	}
+
+	// The server's Accept-Encoding from the first response enables zstd,
+	// the preferred codec, for the second request.
+	if len(encodings) != 2 || encodings[0] != "" || encodings[1] != "zstd" {
+		t.Errorf("Unexpected request encodings: %v", encodings)
+	}
+}
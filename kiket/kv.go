@@ -0,0 +1,109 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// kvStore implements the KVStore interface.
+type kvStore struct {
+	client      Client
+	extensionID string
+	basePath    string
+}
+
+// NewKVStore creates a key-value store scoped to the extension and the
+// workspace of the API credential.
+func NewKVStore(client Client, extensionID string) KVStore {
+	return &kvStore{
+		client:      client,
+		extensionID: extensionID,
+		basePath:    extensionPath(extensionID) + "/kv",
+	}
+}
+
+func (s *kvStore) path(key string) string {
+	if key == "" {
+		return s.basePath
+	}
+	return joinPath(s.basePath, key)
+}
+
+func (s *kvStore) Get(ctx context.Context, key string, out interface{}) (bool, error) {
+	if s.extensionID == "" {
+		return false, errors.New("extension ID required for KV operations")
+	}
+
+	resp, err := s.client.Get(ctx, s.path(key), nil)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, err
+	}
+
+	result, err := decodeEnvelope[json.RawMessage](resp, "value")
+	if err != nil {
+		return false, err
+	}
+	if out != nil {
+		if err := json.Unmarshal(result, out); err != nil {
+			return false, fmt.Errorf("failed to decode value for %s: %w", key, err)
+		}
+	}
+
+	return true, nil
+}
+
+func (s *kvStore) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if s.extensionID == "" {
+		return errors.New("extension ID required for KV operations")
+	}
+	if key == "" {
+		return errors.New("KV key is required")
+	}
+
+	body := map[string]interface{}{"value": value}
+	if ttl > 0 {
+		body["ttl_seconds"] = int64((ttl + time.Second - 1) / time.Second)
+	}
+
+	_, err := s.client.Put(ctx, s.path(key), body, nil)
+	return err
+}
+
+func (s *kvStore) Delete(ctx context.Context, key string) error {
+	if s.extensionID == "" {
+		return errors.New("extension ID required for KV operations")
+	}
+
+	_, err := s.client.Delete(ctx, s.path(key), nil)
+	return err
+}
+
+func (s *kvStore) List(ctx context.Context, prefix string) ([]string, error) {
+	if s.extensionID == "" {
+		return nil, errors.New("extension ID required for KV operations")
+	}
+
+	var opts *RequestOptions
+	if prefix != "" {
+		opts = &RequestOptions{Params: map[string]string{"prefix": prefix}}
+	}
+
+	resp, err := s.client.Get(ctx, s.path(""), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeEnvelope[[]string](resp, "keys")
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestKVStore_BuildsRequests(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		var body map[string]interface{}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			json.Unmarshal(data, &body)
+		}
+		bodies = append(bodies, body)
+
+		switch {
+		case r.URL.Path == "/api/v1/extensions/com.example.ext/kv/missing":
+			http.NotFound(w, r)
+		case r.URL.Path == "/api/v1/extensions/com.example.ext/kv":
+			w.Write([]byte(`{"keys": ["sync/cursor", "sync/last_run"]}`))
+		default:
+			w.Write([]byte(`{"value": {"cursor": "c42", "count": 3}}`))
+		}
+	}))
+	defer server.Close()
+
+	kv := NewKVStore(NewHTTPClient(WithBaseURL(server.URL)), "com.example.ext")
+	ctx := context.Background()
+
+	var state struct {
+		Cursor string `json:"cursor"`
+		Count  int    `json:"count"`
+	}
+	found, err := kv.Get(ctx, "sync/cursor", &state)
+	if err != nil || !found || state.Cursor != "c42" || state.Count != 3 {
+		t.Fatalf("Unexpected get result %+v, found=%v (%v)", state, found, err)
+	}
+	if found, err := kv.Get(ctx, "missing", nil); err != nil || found {
+		t.Errorf("Expected a missing key to be reported as not found, got found=%v (%v)", found, err)
+	}
+	if err := kv.Set(ctx, "sync/cursor", map[string]string{"cursor": "c43"}, 1500*time.Millisecond); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	keys, err := kv.List(ctx, "sync/")
+	if err != nil || !reflect.DeepEqual(keys, []string{"sync/cursor", "sync/last_run"}) {
+		t.Fatalf("Unexpected keys %v (%v)", keys, err)
+	}
+	if err := kv.Delete(ctx, "sync/cursor"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	expected := []string{
+		"GET /api/v1/extensions/com.example.ext/kv/sync%2Fcursor",
+		"GET /api/v1/extensions/com.example.ext/kv/missing",
+		"PUT /api/v1/extensions/com.example.ext/kv/sync%2Fcursor",
+		"GET /api/v1/extensions/com.example.ext/kv?prefix=sync%2F",
+		"DELETE /api/v1/extensions/com.example.ext/kv/sync%2Fcursor",
+	}
+	for i, want := range expected {
+		if requests[i] != want {
+			t.Errorf("Expected request %q, got %q", want, requests[i])
+		}
+	}
+	set := map[string]interface{}{"value": map[string]interface{}{"cursor": "c43"}, "ttl_seconds": float64(2)}
+	if !reflect.DeepEqual(bodies[2], set) {
+		t.Errorf("Expected the TTL rounded up to whole seconds, got %v", bodies[2])
+	}
+
+	if err := NewKVStore(NewHTTPClient(WithBaseURL(server.URL)), "").Set(ctx, "k", 1, 0); err == nil {
+		t.Error("Expected error without an extension ID")
+	}
+}
@@ -0,0 +1,178 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	labelsPath = "/api/v1/ext/labels"
+	// labelsPageSize is the page size find scans labels with.
+	labelsPageSize = 100
+)
+
+// labelsClient implements the LabelsClient interface.
+type labelsClient struct {
+	client    Client
+	projectID interface{}
+}
+
+// NewLabelsClient creates a new labels client.
+func NewLabelsClient(client Client, projectID interface{}) LabelsClient {
+	return &labelsClient{
+		client:    client,
+		projectID: projectID,
+	}
+}
+
+func (c *labelsClient) params() map[string]string {
+	return map[string]string{
+		"project_id": fmt.Sprintf("%v", c.projectID),
+	}
+}
+
+func (c *labelsClient) List(ctx context.Context) ([]Label, error) {
+	if c.projectID == nil || c.projectID == "" {
+		return nil, errors.New("projectID is required for label operations")
+	}
+
+	resp, err := c.client.Get(ctx, labelsPath, &RequestOptions{
+		Params: c.params(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeData[[]Label](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *labelsClient) Create(ctx context.Context, label Label) (*Label, error) {
+	if c.projectID == nil || c.projectID == "" {
+		return nil, errors.New("projectID is required for label operations")
+	}
+	if label.Name == "" {
+		return nil, errors.New("label name is required")
+	}
+
+	resp, err := c.client.Post(ctx, labelsPath, map[string]interface{}{"label": label}, &RequestOptions{
+		Params: c.params(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeData[Label](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *labelsClient) FindOrCreate(ctx context.Context, label Label) (*Label, error) {
+	if existing, err := c.find(ctx, label.Name); err != nil || existing != nil {
+		return existing, err
+	}
+
+	created, err := c.Create(ctx, label)
+	if err != nil {
+		// Another worker may have created it concurrently
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusConflict || apiErr.StatusCode == http.StatusUnprocessableEntity) {
+			if existing, findErr := c.find(ctx, label.Name); findErr == nil && existing != nil {
+				return existing, nil
+			}
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+// find looks up a label by name, ignoring case. It filters by name and
+// pages through the results, so projects with more labels than one page,
+// or servers that ignore the filter, are scanned in full.
+func (c *labelsClient) find(ctx context.Context, name string) (*Label, error) {
+	if c.projectID == nil || c.projectID == "" {
+		return nil, errors.New("projectID is required for label operations")
+	}
+
+	for page := 1; ; page++ {
+		params := c.params()
+		params["name"] = name
+		params["page"] = strconv.Itoa(page)
+		params["per_page"] = strconv.Itoa(labelsPageSize)
+
+		resp, err := c.client.Get(ctx, labelsPath, &RequestOptions{Params: params})
+		if err != nil {
+			return nil, err
+		}
+		labels, err := decodeData[[]Label](resp)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range labels {
+			if strings.EqualFold(labels[i].Name, name) {
+				return &labels[i], nil
+			}
+		}
+		if len(labels) < labelsPageSize {
+			return nil, nil
+		}
+	}
+}
+
+func (c *labelsClient) ApplyToIssue(ctx context.Context, issueID interface{}, names ...string) error {
+	if issueID == nil || issueID == "" {
+		return errors.New("issue ID is required")
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	path := joinPath(issuesPath, issueID, "labels")
+	_, err := c.client.Post(ctx, path, map[string][]string{"labels": names}, nil)
+	return err
+}
+
+func (c *labelsClient) RemoveFromIssue(ctx context.Context, issueID interface{}, name string) error {
+	if issueID == nil || issueID == "" {
+		return errors.New("issue ID is required")
+	}
+
+	path := joinPath(issuesPath, issueID, "labels", name)
+	_, err := c.client.Delete(ctx, path, nil)
+	return err
+}
+
+func (c *labelsClient) ApplyToProject(ctx context.Context, names ...string) error {
+	if c.projectID == nil || c.projectID == "" {
+		return errors.New("projectID is required for label operations")
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	path := joinPath(projectsPath, c.projectID, "labels")
+	_, err := c.client.Post(ctx, path, map[string][]string{"labels": names}, nil)
+	return err
+}
+
+func (c *labelsClient) RemoveFromProject(ctx context.Context, name string) error {
+	if c.projectID == nil || c.projectID == "" {
+		return errors.New("projectID is required for label operations")
+	}
+
+	path := joinPath(projectsPath, c.projectID, "labels", name)
+	_, err := c.client.Delete(ctx, path, nil)
+	return err
+}
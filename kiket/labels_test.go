@@ -0,0 +1,184 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// labelPage returns n labels named label-<offset+i>, as a data envelope.
+func labelPage(offset, n int) string {
+	labels := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		labels = append(labels, fmt.Sprintf(`{"id": %d, "name": "label-%d"}`, offset+i, offset+i))
+	}
+	return `{"data": [` + strings.Join(labels, ",") + `]}`
+}
+
+func TestLabelsClient_FindOrCreateFindsLabelOnLaterPage(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		if r.URL.Query().Get("page") == "1" {
+			w.Write([]byte(labelPage(0, labelsPageSize)))
+			return
+		}
+		w.Write([]byte(`{"data": [{"id": 500, "name": "Triage", "color": "#ff0000"}]}`))
+	}))
+	defer server.Close()
+
+	labels := NewLabelsClient(NewHTTPClient(WithBaseURL(server.URL)), 7)
+	label, err := labels.FindOrCreate(context.Background(), Label{Name: "triage"})
+	if err != nil {
+		t.Fatalf("FindOrCreate failed: %v", err)
+	}
+	if label.Name != "Triage" || label.Color != "#ff0000" {
+		t.Errorf("Expected the existing label, got %+v", label)
+	}
+
+	expected := []string{
+		"GET /api/v1/ext/labels?name=triage&page=1&per_page=100&project_id=7",
+		"GET /api/v1/ext/labels?name=triage&page=2&per_page=100&project_id=7",
+	}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestLabelsClient_FindOrCreateCreatesMissingLabel(t *testing.T) {
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"data": []}`))
+			return
+		}
+		if r.URL.RequestURI() != "/api/v1/ext/labels?project_id=7" {
+			t.Errorf("Unexpected create request %s", r.URL.RequestURI())
+		}
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &created)
+		w.Write([]byte(`{"data": {"id": 9, "name": "triage", "color": "#00ff00"}}`))
+	}))
+	defer server.Close()
+
+	labels := NewLabelsClient(NewHTTPClient(WithBaseURL(server.URL)), 7)
+	label, err := labels.FindOrCreate(context.Background(), Label{Name: "triage", Color: "#00ff00"})
+	if err != nil {
+		t.Fatalf("FindOrCreate failed: %v", err)
+	}
+	if label.ID == nil || label.Name != "triage" {
+		t.Errorf("Expected the created label, got %+v", label)
+	}
+	if body, _ := created["label"].(map[string]interface{}); body["name"] != "triage" || body["color"] != "#00ff00" {
+		t.Errorf("Expected label body, got %v", created)
+	}
+}
+
+func TestLabelsClient_FindOrCreateRecoversFromConflict(t *testing.T) {
+	for _, status := range []int{http.StatusConflict, http.StatusUnprocessableEntity} {
+		t.Run(fmt.Sprint(status), func(t *testing.T) {
+			var lists int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					w.WriteHeader(status)
+					w.Write([]byte(`{"error": "name has already been taken"}`))
+					return
+				}
+				// Another worker creates the label between the lookup and the create
+				if lists++; lists == 1 {
+					w.Write([]byte(`{"data": []}`))
+					return
+				}
+				w.Write([]byte(`{"data": [{"id": 3, "name": "triage"}]}`))
+			}))
+			defer server.Close()
+
+			labels := NewLabelsClient(NewHTTPClient(WithBaseURL(server.URL)), 7)
+			label, err := labels.FindOrCreate(context.Background(), Label{Name: "triage"})
+			if err != nil {
+				t.Fatalf("Expected the conflict to be resolved, got %v", err)
+			}
+			if label.Name != "triage" {
+				t.Errorf("Expected the concurrently created label, got %+v", label)
+			}
+		})
+	}
+}
+
+func TestLabelsClient_FindOrCreateReturnsOtherErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	labels := NewLabelsClient(NewHTTPClient(WithBaseURL(server.URL)), 7)
+	var apiErr *APIError
+	if _, err := labels.FindOrCreate(context.Background(), Label{Name: "triage"}); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected the 403 to be returned, got %v", err)
+	}
+}
+
+func TestLabelsClient_BuildsRequests(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		var body map[string]interface{}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			json.Unmarshal(data, &body)
+		}
+		bodies = append(bodies, body)
+		w.Write([]byte(`{"data": [{"id": 1, "name": "bug", "color": "#ff0000"}]}`))
+	}))
+	defer server.Close()
+
+	labels := NewLabelsClient(NewHTTPClient(WithBaseURL(server.URL)), 7)
+	ctx := context.Background()
+
+	list, err := labels.List(ctx)
+	if err != nil || len(list) != 1 || list[0].Name != "bug" {
+		t.Fatalf("Unexpected list result %+v (%v)", list, err)
+	}
+	if err := labels.ApplyToIssue(ctx, 42, "bug", "p1"); err != nil {
+		t.Fatalf("ApplyToIssue failed: %v", err)
+	}
+	if err := labels.RemoveFromIssue(ctx, 42, "needs review"); err != nil {
+		t.Fatalf("RemoveFromIssue failed: %v", err)
+	}
+	if err := labels.ApplyToProject(ctx, "ops"); err != nil {
+		t.Fatalf("ApplyToProject failed: %v", err)
+	}
+	if err := labels.RemoveFromProject(ctx, "ops"); err != nil {
+		t.Fatalf("RemoveFromProject failed: %v", err)
+	}
+
+	expected := []string{
+		"GET /api/v1/ext/labels?project_id=7",
+		"POST /api/v1/ext/issues/42/labels",
+		"DELETE /api/v1/ext/issues/42/labels/needs%20review",
+		"POST /api/v1/ext/projects/7/labels",
+		"DELETE /api/v1/ext/projects/7/labels/ops",
+	}
+	for i, want := range expected {
+		if requests[i] != want {
+			t.Errorf("Expected request %q, got %q", want, requests[i])
+		}
+	}
+	if names, _ := bodies[1]["labels"].([]interface{}); len(names) != 2 || names[0] != "bug" {
+		t.Errorf("Expected label names body, got %v", bodies[1])
+	}
+
+	if _, err := NewLabelsClient(NewHTTPClient(WithBaseURL(server.URL)), nil).List(ctx); err == nil {
+		t.Error("Expected error when projectID is missing")
+	}
+}
@@ -1,50 +1,291 @@
 package kiket
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
-// LoadManifest loads an extension manifest from file.
-func LoadManifest(manifestPath string) (*Manifest, error) {
+// defaultManifestNames are the file names searched when no path is given.
+var defaultManifestNames = []string{
+	"extension.yaml",
+	"manifest.yaml",
+	"extension.yml",
+	"manifest.yml",
+	"extension.json",
+	"manifest.json",
+}
+
+// ErrManifestNotFound is returned when no manifest file exists at the given
+// path or under any of the default names.
+var ErrManifestNotFound = errors.New("manifest not found")
+
+// ManifestParseError reports a manifest that exists but cannot be parsed.
+type ManifestParseError struct {
+	// File the manifest was read from
+	Path string
+	// Line of the first problem, or 0 if unknown
+	Line int
+	Err  error
+}
+
+func (e *ManifestParseError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.Path, e.Line, manifestErrorMessage(e.Err))
+	}
+	return fmt.Sprintf("%s: %s", e.Path, manifestErrorMessage(e.Err))
+}
+
+func (e *ManifestParseError) Unwrap() error {
+	return e.Err
+}
+
+var yamlLinePattern = regexp.MustCompile(`line (\d+): `)
+
+// manifestErrorMessage strips the yaml prefix and the first line number,
+// which ManifestParseError already reports.
+func manifestErrorMessage(err error) string {
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	msg = strings.TrimPrefix(msg, "unmarshal errors:\n  ")
+	if loc := yamlLinePattern.FindStringIndex(msg); loc != nil && loc[0] == 0 {
+		msg = msg[loc[1]:]
+	}
+	return msg
+}
+
+func newManifestParseError(path string, err error) *ManifestParseError {
+	parseErr := &ManifestParseError{Path: path, Err: err}
+	if m := yamlLinePattern.FindStringSubmatch(err.Error()); m != nil {
+		parseErr.Line, _ = strconv.Atoi(m[1])
+	}
+	return parseErr
+}
+
+// ManifestOption configures manifest loading.
+type ManifestOption func(*manifestOptions)
+
+type manifestOptions struct {
+	strict bool
+}
+
+// WithStrictManifest makes unknown fields (such as a misspelled
+// delivery_secret) parse errors instead of being silently ignored.
+func WithStrictManifest() ManifestOption {
+	return func(o *manifestOptions) {
+		o.strict = true
+	}
+}
+
+// ParseManifest parses a manifest in YAML or JSON. JSON manifests use the
+// same snake_case keys as YAML.
+func ParseManifest(content []byte, opts ...ManifestOption) (*Manifest, error) {
+	options := manifestOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// JSON is a subset of YAML, so one decoder handles both formats
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(options.strict)
+
+	var manifest Manifest
+	if err := decoder.Decode(&manifest); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// LoadManifest loads an extension manifest from file. YAML and JSON
+// manifests are accepted. An empty path searches the current directory for
+// the default manifest names. It returns an error wrapping
+// ErrManifestNotFound if no file exists, and a *ManifestParseError if the
+// file cannot be parsed.
+func LoadManifest(manifestPath string, opts ...ManifestOption) (*Manifest, error) {
+	manifest, _, err := loadManifestFile(manifestPath, opts...)
+	return manifest, err
+}
+
+// loadManifestFile loads a manifest and reports which file it came from.
+func loadManifestFile(manifestPath string, opts ...ManifestOption) (*Manifest, string, error) {
 	paths := []string{manifestPath}
 	if manifestPath == "" {
 		cwd, err := os.Getwd()
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
-		paths = []string{
-			filepath.Join(cwd, "extension.yaml"),
-			filepath.Join(cwd, "manifest.yaml"),
-			filepath.Join(cwd, "extension.yml"),
-			filepath.Join(cwd, "manifest.yml"),
+		paths = make([]string, 0, len(defaultManifestNames))
+		for _, name := range defaultManifestNames {
+			paths = append(paths, filepath.Join(cwd, name))
 		}
 	}
 
 	for _, p := range paths {
-		if p == "" {
-			continue
-		}
-
 		content, err := os.ReadFile(p)
 		if err != nil {
 			if os.IsNotExist(err) {
 				continue
 			}
+			return nil, "", err
+		}
+
+		manifest, err := ParseManifest(content, opts...)
+		if err != nil {
+			return nil, "", newManifestParseError(p, err)
+		}
+
+		return manifest, p, nil
+	}
+
+	return nil, "", manifestNotFound(paths)
+}
+
+// LoadManifestFS loads an extension manifest from a file system, such as an
+// embed.FS, so single-binary extensions can ship their manifest with
+// go:embed. An empty path searches the root for the default manifest names.
+// Errors are reported as for LoadManifest.
+//
+//	//go:embed extension.yaml
+//	var manifestFS embed.FS
+//
+//	manifest, err := kiket.LoadManifestFS(manifestFS, "extension.yaml")
+func LoadManifestFS(fsys fs.FS, manifestPath string, opts ...ManifestOption) (*Manifest, error) {
+	paths := []string{manifestPath}
+	if manifestPath == "" {
+		paths = defaultManifestNames
+	}
+
+	for _, p := range paths {
+		content, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
 			return nil, err
 		}
 
-		var manifest Manifest
-		if err := yaml.Unmarshal(content, &manifest); err != nil {
+		manifest, err := ParseManifest(content, opts...)
+		if err != nil {
+			return nil, newManifestParseError(p, err)
+		}
+
+		return manifest, nil
+	}
+
+	return nil, manifestNotFound(paths)
+}
+
+func manifestNotFound(paths []string) error {
+	return fmt.Errorf("%w (looked for %s)", ErrManifestNotFound, strings.Join(paths, ", "))
+}
+
+// UnmarshalYAML accepts either a bare event name or a mapping.
+func (e *ManifestEvent) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		e.Name = node.Value
+		return nil
+	}
+
+	type plain ManifestEvent
+	return node.Decode((*plain)(e))
+}
+
+// MarshalYAML writes subscriptions without versions as a bare event name.
+func (e ManifestEvent) MarshalYAML() (interface{}, error) {
+	if len(e.Versions) == 0 {
+		return e.Name, nil
+	}
+
+	type plain ManifestEvent
+	return plain(e), nil
+}
+
+// EventNames returns the names of all subscribed events.
+func (m *Manifest) EventNames() []string {
+	if m == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(m.Events))
+	for _, e := range m.Events {
+		names = append(names, e.Name)
+	}
+	return names
+}
+
+// SubscribesTo reports whether the manifest subscribes to an event version.
+// Subscriptions without versions match every version.
+func (m *Manifest) SubscribesTo(event, version string) bool {
+	if m == nil {
+		return false
+	}
+
+	for _, e := range m.Events {
+		if e.Name != event {
 			continue
 		}
+		if len(e.Versions) == 0 {
+			return true
+		}
+		for _, v := range e.Versions {
+			if v == version {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasScope reports whether the manifest declares an API scope.
+func (m *Manifest) HasScope(scope string) bool {
+	if m == nil {
+		return false
+	}
+
+	for _, s := range m.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Setting returns the setting definition for key.
+func (m *Manifest) Setting(key string) (ManifestSetting, bool) {
+	if m == nil {
+		return ManifestSetting{}, false
+	}
+
+	for _, s := range m.Settings {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return ManifestSetting{}, false
+}
 
-		return &manifest, nil
+// UIContributions returns the UI contributions for a location, or all of
+// them when location is empty.
+func (m *Manifest) UIContributions(location string) []ManifestUIContribution {
+	if m == nil {
+		return nil
 	}
 
-	return nil, nil
+	var result []ManifestUIContribution
+	for _, c := range m.UI {
+		if location == "" || c.Location == location {
+			result = append(result, c)
+		}
+	}
+	return result
 }
 
 // SettingsDefaults extracts default values from a manifest.
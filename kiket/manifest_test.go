@@ -0,0 +1,230 @@
+package kiket
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"gopkg.in/yaml.v3"
+)
+
+const testManifestYAML = `
+id: com.example.triage
+version: 1.2.0
+name: Triage Bot
+delivery_secret: s3cret
+settings:
+  - key: api_token
+    secret: true
+  - key: default_priority
+    default: medium
+events:
+  - issue.created
+  - name: issue.updated
+    versions: [v2]
+scopes:
+  - issues:read
+  - issues:write
+endpoints:
+  - path: /webhook
+    events: [issue.created, issue.updated]
+ui:
+  - key: triage-panel
+    location: issue_panel
+    title: Triage
+    url: /ui/panel
+`
+
+func writeTestManifest(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	return path
+}
+
+func TestLoadManifest_FullSchema(t *testing.T) {
+	manifest, err := LoadManifest(writeTestManifest(t, "extension.yaml", testManifestYAML))
+	if err != nil {
+		t.Fatalf("LoadManifest failed: %v", err)
+	}
+
+	if names := manifest.EventNames(); len(names) != 2 || names[0] != "issue.created" {
+		t.Errorf("Expected [issue.created issue.updated], got %v", names)
+	}
+	if !manifest.SubscribesTo("issue.created", "v3") {
+		t.Error("Expected unversioned subscription to match any version")
+	}
+	if manifest.SubscribesTo("issue.updated", "v1") || !manifest.SubscribesTo("issue.updated", "v2") {
+		t.Error("Expected issue.updated to match only v2")
+	}
+	if !manifest.HasScope("issues:write") || manifest.HasScope("admin") {
+		t.Errorf("Unexpected scopes: %v", manifest.Scopes)
+	}
+	if len(manifest.Endpoints) != 1 || manifest.Endpoints[0].Path != "/webhook" {
+		t.Errorf("Unexpected endpoints: %+v", manifest.Endpoints)
+	}
+	if panels := manifest.UIContributions("issue_panel"); len(panels) != 1 || panels[0].Title != "Triage" {
+		t.Errorf("Unexpected UI contributions: %+v", panels)
+	}
+	if setting, ok := manifest.Setting("api_token"); !ok || !setting.Secret {
+		t.Errorf("Expected secret api_token setting, got %+v", setting)
+	}
+}
+
+func TestValidateManifest_ReportsActionableIssues(t *testing.T) {
+	manifest := &Manifest{
+		Version: "1.0",
+		Settings: []ManifestSetting{
+			{Key: "api_token", Secret: true},
+			{Key: "api_token"},
+		},
+		Events: []ManifestEvent{{Name: "issue.created"}, {Name: "issue.craeted"}},
+	}
+
+	issues := ValidateManifest(manifest)
+
+	want := map[string]ValidationSeverity{
+		"id":              SeverityError,
+		"version":         SeverityWarning,
+		"settings[0].key": SeverityWarning,
+		"settings[1].key": SeverityError,
+		"events[1]":       SeverityWarning,
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("Expected %d issues, got %d: %v", len(want), len(issues), issues)
+	}
+	for _, issue := range issues {
+		if severity, ok := want[issue.Field]; !ok || severity != issue.Severity {
+			t.Errorf("Unexpected issue: %v", issue)
+		}
+	}
+}
+
+func TestValidateManifest_ValidManifest(t *testing.T) {
+	os.Setenv("KIKET_SECRET_API_TOKEN", "token")
+	defer os.Unsetenv("KIKET_SECRET_API_TOKEN")
+
+	manifest, err := LoadManifest(writeTestManifest(t, "extension.yaml", testManifestYAML))
+	if err != nil {
+		t.Fatalf("LoadManifest failed: %v", err)
+	}
+
+	if issues := ValidateManifest(manifest); len(issues) != 0 {
+		t.Errorf("Expected no issues, got %v", issues)
+	}
+}
+
+func TestSDK_GenerateManifestFromHandlers(t *testing.T) {
+	sdk, err := New(Config{
+		ManifestPath:    writeTestManifest(t, "extension.yaml", testManifestYAML),
+		ExtensionAPIKey: "key",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	noop := func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		return nil, nil
+	}
+	sdk.On("issue.updated", noop, "v2")
+	sdk.On("issue.created", noop)
+	sdk.On("issue.created", noop, "v2")
+
+	var buf bytes.Buffer
+	if err := sdk.GenerateManifest(&buf); err != nil {
+		t.Fatalf("GenerateManifest failed: %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "s3cret") {
+		t.Error("Expected delivery secret to be omitted")
+	}
+
+	var generated Manifest
+	if err := yaml.Unmarshal(buf.Bytes(), &generated); err != nil {
+		t.Fatalf("Generated manifest is not valid YAML: %v\n%s", err, output)
+	}
+	if generated.ID != "com.example.triage" || generated.SDKVersion != SDKVersion {
+		t.Errorf("Unexpected identity: %+v", generated)
+	}
+	if len(generated.Events) != 2 || generated.Events[0].Name != "issue.created" {
+		t.Fatalf("Expected sorted events, got %+v", generated.Events)
+	}
+	if got := generated.Events[0].Versions; len(got) != 2 || got[0] != "v1" || got[1] != "v2" {
+		t.Errorf("Expected versions [v1 v2], got %v", got)
+	}
+	if len(generated.Settings) != 2 {
+		t.Errorf("Expected manifest settings to be carried over, got %v", generated.Settings)
+	}
+}
+
+func TestLoadManifestFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"manifest.yaml":        {Data: []byte(testManifestYAML)},
+		"config/extension.yml": {Data: []byte("id: com.example.nested\nversion: 2.0.0\n")},
+	}
+
+	manifest, err := LoadManifestFS(fsys, "")
+	if err != nil {
+		t.Fatalf("LoadManifestFS failed: %v", err)
+	}
+	if manifest == nil || manifest.ID != "com.example.triage" {
+		t.Fatalf("Expected default manifest name to be found, got %+v", manifest)
+	}
+
+	nested, err := LoadManifestFS(fsys, "config/extension.yml")
+	if err != nil || nested == nil || nested.Version != "2.0.0" {
+		t.Errorf("Expected nested manifest, got %+v (%v)", nested, err)
+	}
+}
+
+func TestLoadManifest_JSONAndStrictMode(t *testing.T) {
+	jsonManifest := "{\n\t\"id\": \"com.example.json\",\n\t\"version\": \"1.0.0\",\n\t\"events\": [\"issue.created\", {\"name\": \"issue.updated\", \"versions\": [\"v2\"]}],\n\t\"settings\": [{\"key\": \"limit\", \"type\": \"integer\", \"default\": 5}]\n}\n"
+
+	manifest, err := LoadManifest(writeTestManifest(t, "extension.json", jsonManifest))
+	if err != nil || manifest == nil {
+		t.Fatalf("Expected JSON manifest to load, got %+v (%v)", manifest, err)
+	}
+	if manifest.ID != "com.example.json" || len(manifest.Events) != 2 || manifest.Events[1].Versions[0] != "v2" {
+		t.Errorf("Unexpected JSON manifest: %+v", manifest)
+	}
+
+	typo := writeTestManifest(t, "extension.yaml", "id: com.example.ext\nversion: 1.0.0\ndelivery_secrert: oops\n")
+	if _, err := LoadManifest(typo); err != nil {
+		t.Errorf("Expected lenient mode to ignore unknown fields, got %v", err)
+	}
+	_, err = LoadManifest(typo, WithStrictManifest())
+	if err == nil || !strings.Contains(err.Error(), "delivery_secrert") {
+		t.Errorf("Expected strict mode to reject delivery_secrert, got %v", err)
+	}
+}
+
+func TestLoadManifest_ReportsMissingAndMalformedFiles(t *testing.T) {
+	_, err := LoadManifest(filepath.Join(t.TempDir(), "extension.yaml"))
+	if !errors.Is(err, ErrManifestNotFound) {
+		t.Errorf("Expected ErrManifestNotFound, got %v", err)
+	}
+
+	path := writeTestManifest(t, "extension.yaml", "id: com.example.ext\nversion: 1.0.0\nsettings:\n  - key: [unclosed\n")
+	_, err = LoadManifest(path)
+	var parseErr *ManifestParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Expected *ManifestParseError, got %v", err)
+	}
+	if parseErr.Path != path || parseErr.Line == 0 {
+		t.Errorf("Expected file and line in parse error, got %v", parseErr)
+	}
+
+	_, err = LoadManifest(writeTestManifest(t, "extension.yaml", "id: com.example.ext\nversion: 1.0.0\nevents: 3\n"))
+	if !errors.As(err, &parseErr) || parseErr.Line != 3 {
+		t.Errorf("Expected type error on line 3, got %v", err)
+	}
+}
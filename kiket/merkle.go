@@ -0,0 +1,192 @@
+package kiket
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RecordIterator yields records one at a time, like *Iterator, so
+// IterateCustomData results can be hashed directly.
+type RecordIterator interface {
+	Next() bool
+	Item() map[string]interface{}
+	Err() error
+}
+
+// HashRecords computes the content hash of each record as records yields
+// it and passes it to fn, in order, without keeping the records. It
+// returns the number of records hashed and stops at the first error from
+// records or fn. Pass a MerkleBuilder's Add to compute the root of
+// millions of records in constant memory:
+//
+//	builder := kiket.NewMerkleBuilder()
+//	n, err := kiket.HashRecords(kiket.NewJSONRecordReader(file), builder.Add)
+//	ok := err == nil && builder.Root() == anchor.MerkleRoot
+func HashRecords(records RecordIterator, fn func(contentHash string) error) (int64, error) {
+	var n int64
+	for records.Next() {
+		if err := fn(ComputeContentHash(records.Item())); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, records.Err()
+}
+
+// jsonRecordReader decodes records from a JSON stream.
+type jsonRecordReader struct {
+	reader  *bufio.Reader
+	decoder *json.Decoder // nil until the first Next
+	array   bool          // inside a top-level array
+	record  map[string]interface{}
+	err     error
+}
+
+// NewJSONRecordReader returns a RecordIterator over the JSON objects of r,
+// either newline-delimited or in one top-level array, decoding one at a
+// time.
+func NewJSONRecordReader(r io.Reader) RecordIterator {
+	return &jsonRecordReader{reader: bufio.NewReader(r)}
+}
+
+func (j *jsonRecordReader) Next() bool {
+	if j.err != nil {
+		return false
+	}
+	if j.decoder == nil {
+		if j.err = j.open(); j.err != nil {
+			return false
+		}
+	}
+	if !j.decoder.More() {
+		if j.array {
+			if _, err := j.decoder.Token(); err != nil {
+				j.err = fmt.Errorf("failed to read records: %w", err)
+			}
+		}
+		return false
+	}
+
+	j.record = nil
+	if err := j.decoder.Decode(&j.record); err != nil {
+		j.err = fmt.Errorf("failed to read records: %w", err)
+		return false
+	}
+	return true
+}
+
+// open starts decoding, consuming the opening bracket when the stream is
+// an array.
+func (j *jsonRecordReader) open() error {
+	j.decoder = json.NewDecoder(j.reader)
+	for {
+		c, err := j.reader.ReadByte()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read records: %w", err)
+		}
+		if c == ' ' || c == '\t' || c == '\r' || c == '\n' {
+			continue
+		}
+		j.reader.UnreadByte()
+		if c != '[' {
+			return nil
+		}
+		j.array = true
+		if _, err := j.decoder.Token(); err != nil {
+			return fmt.Errorf("failed to read records: %w", err)
+		}
+		return nil
+	}
+}
+
+func (j *jsonRecordReader) Item() map[string]interface{} {
+	return j.record
+}
+
+func (j *jsonRecordReader) Err() error {
+	return j.err
+}
+
+// channelRecords iterates over the records received from a channel.
+type channelRecords struct {
+	records <-chan map[string]interface{}
+	record  map[string]interface{}
+}
+
+// ChannelRecords returns a RecordIterator over the records received from
+// records until it is closed.
+func ChannelRecords(records <-chan map[string]interface{}) RecordIterator {
+	return &channelRecords{records: records}
+}
+
+func (c *channelRecords) Next() bool {
+	record, ok := <-c.records
+	c.record = record
+	return ok
+}
+
+func (c *channelRecords) Item() map[string]interface{} {
+	return c.record
+}
+
+func (c *channelRecords) Err() error {
+	return nil
+}
+
+// MerkleBuilder computes the Merkle root of a sequence of content hashes
+// as they are added, keeping only one node per tree level. The tree is
+// shaped as in RFC 9162 and hashed with the pair hash VerifyProofLocally
+// and VerifyConsistencyLocally use.
+type MerkleBuilder struct {
+	// Roots of the complete subtrees so far, largest first
+	subtrees [][]byte
+	size     int64
+}
+
+// NewMerkleBuilder returns an empty builder.
+func NewMerkleBuilder() *MerkleBuilder {
+	return &MerkleBuilder{}
+}
+
+// Add appends a leaf, a hex content hash with or without "0x".
+func (b *MerkleBuilder) Add(contentHash string) error {
+	node, err := hex.DecodeString(strings.TrimPrefix(contentHash, "0x"))
+	if err != nil || len(node) != 32 {
+		return fmt.Errorf("invalid content hash %q", contentHash)
+	}
+
+	// Merge the subtrees the new leaf completes, like a binary carry
+	for size := b.size; size&1 == 1; size >>= 1 {
+		last := len(b.subtrees) - 1
+		node = hashPair(b.subtrees[last], node)
+		b.subtrees = b.subtrees[:last]
+	}
+	b.subtrees = append(b.subtrees, node)
+	b.size++
+	return nil
+}
+
+// Len returns the number of leaves added.
+func (b *MerkleBuilder) Len() int64 {
+	return b.size
+}
+
+// Root returns the Merkle root of the leaves added so far, "0x"-prefixed,
+// or "" when there are none. More leaves can be added afterwards.
+func (b *MerkleBuilder) Root() string {
+	if len(b.subtrees) == 0 {
+		return ""
+	}
+	root := b.subtrees[len(b.subtrees)-1]
+	for i := len(b.subtrees) - 2; i >= 0; i-- {
+		root = hashPair(b.subtrees[i], root)
+	}
+	return "0x" + hex.EncodeToString(root)
+}
@@ -0,0 +1,130 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// MessageReceivedEvent is the webhook event delivered for messages on
+// subscribed topics.
+const MessageReceivedEvent = "message.received"
+
+const messagesPath = "/api/v1/ext/messages"
+
+// messagesClient implements the MessagesClient interface.
+type messagesClient struct {
+	client Client
+}
+
+// NewMessagesClient creates a new messaging client.
+func NewMessagesClient(client Client) MessagesClient {
+	return &messagesClient{client: client}
+}
+
+func (c *messagesClient) RegisterTopic(ctx context.Context, topic MessageTopic) error {
+	if topic.Name == "" {
+		return errors.New("topic name is required")
+	}
+
+	_, err := c.client.Put(ctx, messagesPath+"/topics/"+url.PathEscape(topic.Name), map[string]interface{}{"topic": topic}, nil)
+	return err
+}
+
+func (c *messagesClient) Publish(ctx context.Context, topic string, payload map[string]interface{}) (*Message, error) {
+	if topic == "" {
+		return nil, errors.New("topic is required")
+	}
+
+	resp, err := c.client.Post(ctx, messagesPath, map[string]interface{}{
+		"topic":   topic,
+		"payload": payload,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeData[Message](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *messagesClient) Subscribe(ctx context.Context, topic string) error {
+	if topic == "" {
+		return errors.New("topic is required")
+	}
+
+	_, err := c.client.Post(ctx, messagesPath+"/subscriptions", map[string]string{"topic": topic}, nil)
+	return err
+}
+
+func (c *messagesClient) Unsubscribe(ctx context.Context, topic string) error {
+	if topic == "" {
+		return errors.New("topic is required")
+	}
+
+	_, err := c.client.Delete(ctx, messagesPath+"/subscriptions/"+url.PathEscape(topic), nil)
+	return err
+}
+
+func (c *messagesClient) Subscriptions(ctx context.Context) ([]string, error) {
+	resp, err := c.client.Get(ctx, messagesPath+"/subscriptions", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeEnvelope[[]string](resp, "topics")
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// MessageFromPayload extracts the message from a message.received webhook
+// payload.
+func MessageFromPayload(payload WebhookPayload) (*Message, error) {
+	var message Message
+	if err := payload.Decode("message", &message); err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// OnMessage registers a handler for messages published on topic. The
+// extension must also be subscribed to the topic (see MessagesClient).
+func (s *SDK) OnMessage(topic string, handler WebhookHandler) {
+	s.handlersMu.Lock()
+	first := s.messageHandlers == nil
+	if first {
+		s.messageHandlers = make(map[string]WebhookHandler)
+	}
+	s.messageHandlers[topic] = handler
+	s.handlersMu.Unlock()
+
+	if first {
+		s.On(MessageReceivedEvent, s.dispatchMessage)
+	}
+}
+
+// dispatchMessage routes a message.received delivery to the handler
+// registered for the message's topic.
+func (s *SDK) dispatchMessage(ctx context.Context, payload WebhookPayload, handlerCtx *HandlerContext) (interface{}, error) {
+	message, err := MessageFromPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	s.handlersMu.RLock()
+	handler := s.messageHandlers[message.Topic]
+	s.handlersMu.RUnlock()
+
+	if handler == nil {
+		return nil, fmt.Errorf("no handler registered for topic %s", message.Topic)
+	}
+	return handler(ctx, payload, handlerCtx)
+}
@@ -0,0 +1,102 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestMessagesClient_BuildsRequests(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		var body map[string]interface{}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			json.Unmarshal(data, &body)
+		}
+		bodies = append(bodies, body)
+
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"topics": ["crm.contact_synced"]}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"id": "msg_1", "topic": "crm.contact_synced", "source_extension_id": "com.example.crm", "payload": {"contact_id": 7}}}`))
+	}))
+	defer server.Close()
+
+	messages := NewMessagesClient(NewHTTPClient(WithBaseURL(server.URL)))
+	ctx := context.Background()
+
+	if err := messages.RegisterTopic(ctx, MessageTopic{Name: "crm.contact_synced", Description: "A contact was synced"}); err != nil {
+		t.Fatalf("RegisterTopic failed: %v", err)
+	}
+	message, err := messages.Publish(ctx, "crm.contact_synced", map[string]interface{}{"contact_id": 7})
+	if err != nil || message.ID != "msg_1" || message.SourceExtensionID != "com.example.crm" || message.Payload["contact_id"] != float64(7) {
+		t.Fatalf("Unexpected message %+v (%v)", message, err)
+	}
+	if err := messages.Subscribe(ctx, "crm.contact_synced"); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	topics, err := messages.Subscriptions(ctx)
+	if err != nil || !reflect.DeepEqual(topics, []string{"crm.contact_synced"}) {
+		t.Fatalf("Unexpected subscriptions %v (%v)", topics, err)
+	}
+	if err := messages.Unsubscribe(ctx, "crm/contacts"); err != nil {
+		t.Fatalf("Unsubscribe failed: %v", err)
+	}
+
+	expected := []string{
+		"PUT /api/v1/ext/messages/topics/crm.contact_synced",
+		"POST /api/v1/ext/messages",
+		"POST /api/v1/ext/messages/subscriptions",
+		"GET /api/v1/ext/messages/subscriptions",
+		"DELETE /api/v1/ext/messages/subscriptions/crm%2Fcontacts",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+	if topic, _ := bodies[0]["topic"].(map[string]interface{}); topic["description"] != "A contact was synced" {
+		t.Errorf("Expected the topic body, got %v", bodies[0])
+	}
+	if bodies[1]["topic"] != "crm.contact_synced" || bodies[2]["topic"] != "crm.contact_synced" {
+		t.Errorf("Expected the topic in publish and subscribe bodies, got %v and %v", bodies[1], bodies[2])
+	}
+}
+
+func TestSDK_OnMessageRoutesByTopic(t *testing.T) {
+	sdk, err := New(Config{ExtensionAPIKey: "key", WebhookSecret: "secret"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	var received []interface{}
+	sdk.OnMessage("crm.contact_synced", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		message, err := MessageFromPayload(payload)
+		if err != nil {
+			return nil, err
+		}
+		received = append(received, message.Payload["contact_id"])
+		return nil, nil
+	})
+
+	deliver := func(body string) error {
+		signature, timestamp := GenerateSignature("secret", body, nil)
+		_, err := sdk.HandleWebhook(context.Background(), []byte(body), Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp})
+		return err
+	}
+	if err := deliver(`{"event":"message.received","message":{"id":"msg_1","topic":"crm.contact_synced","payload":{"contact_id":7}}}`); err != nil {
+		t.Fatalf("HandleWebhook failed: %v", err)
+	}
+	if !reflect.DeepEqual(received, []interface{}{float64(7)}) {
+		t.Errorf("Expected the message payload, got %v", received)
+	}
+	if err := deliver(`{"event":"message.received","message":{"id":"msg_2","topic":"billing.invoice_paid"}}`); err == nil {
+		t.Error("Expected error for a topic without a handler")
+	}
+}
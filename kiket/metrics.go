@@ -0,0 +1,120 @@
+package kiket
+
+import (
+	"sync"
+	"time"
+)
+
+// MetricKind identifies the type of a custom metric.
+type MetricKind string
+
+const (
+	MetricCounter MetricKind = "counter"
+	MetricGauge   MetricKind = "gauge"
+	MetricTimer   MetricKind = "timer"
+)
+
+// Metric is a custom metric reported alongside a telemetry record.
+type Metric struct {
+	Name string     `json:"name"`
+	Kind MetricKind `json:"kind"`
+	// Counter total, last gauge value, or total timer milliseconds
+	Value float64 `json:"value"`
+	// Number of observations (counters and timers)
+	Count int64 `json:"count,omitempty"`
+	// Longest observed timing in milliseconds (timers only)
+	MaxMs float64 `json:"max_ms,omitempty"`
+}
+
+// Metrics collects custom metrics during a webhook delivery. They are sent
+// with the delivery's telemetry record, which telemetry sampling then never
+// drops. All methods are safe for concurrent use and are no-ops on a nil
+// receiver.
+type Metrics struct {
+	mu      sync.Mutex
+	metrics map[string]*Metric
+	order   []string
+}
+
+// NewMetrics creates an empty metrics collector.
+func NewMetrics() *Metrics {
+	return &Metrics{metrics: make(map[string]*Metric)}
+}
+
+// Incr increments a counter by one.
+func (m *Metrics) Incr(name string) {
+	m.Add(name, 1)
+}
+
+// Add increments a counter by delta.
+func (m *Metrics) Add(name string, delta float64) {
+	m.observe(name, MetricCounter, func(metric *Metric) {
+		metric.Value += delta
+		metric.Count++
+	})
+}
+
+// Gauge sets a gauge to value, replacing any earlier value.
+func (m *Metrics) Gauge(name string, value float64) {
+	m.observe(name, MetricGauge, func(metric *Metric) {
+		metric.Value = value
+	})
+}
+
+// Timing records a duration observation.
+func (m *Metrics) Timing(name string, d time.Duration) {
+	ms := float64(d) / float64(time.Millisecond)
+	m.observe(name, MetricTimer, func(metric *Metric) {
+		metric.Value += ms
+		metric.Count++
+		if ms > metric.MaxMs {
+			metric.MaxMs = ms
+		}
+	})
+}
+
+// Since records the time elapsed since start, for use with defer:
+//
+//	defer hctx.Metrics.Since("crm.sync", time.Now())
+func (m *Metrics) Since(name string, start time.Time) {
+	m.Timing(name, time.Since(start))
+}
+
+// Snapshot returns the collected metrics in first-observed order.
+func (m *Metrics) Snapshot() []Metric {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.order) == 0 {
+		return nil
+	}
+
+	result := make([]Metric, 0, len(m.order))
+	for _, key := range m.order {
+		result = append(result, *m.metrics[key])
+	}
+	return result
+}
+
+func (m *Metrics) observe(name string, kind MetricKind, update func(*Metric)) {
+	if m == nil || name == "" {
+		return
+	}
+
+	key := string(kind) + ":" + name
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metric, ok := m.metrics[key]
+	if !ok {
+		metric = &Metric{Name: name, Kind: kind}
+		m.metrics[key] = metric
+		m.order = append(m.order, key)
+	}
+	update(metric)
+}
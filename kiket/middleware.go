@@ -0,0 +1,38 @@
+package kiket
+
+import "net/http"
+
+// RoundTripFunc performs one HTTP round trip of an API call.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the round trips of every API call, streams included, to
+// add logging, metrics, headers, or request signing without forking the
+// client. It sees the request with the SDK's headers and authentication
+// already set, and may return a response or error without calling next.
+//
+//	client := kiket.NewHTTPClient(kiket.WithMiddleware(func(next kiket.RoundTripFunc) kiket.RoundTripFunc {
+//		return func(req *http.Request) (*http.Response, error) {
+//			start := time.Now()
+//			resp, err := next(req)
+//			log.Printf("%s %s took %s", req.Method, req.URL.Path, time.Since(start))
+//			return resp, err
+//		}
+//	}))
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware adds middleware to the client. The first registered runs
+// outermost. Retried calls, e.g. after a 429, pass through it again.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *HTTPClient) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// roundTrip sends req with client through the middleware chain.
+func (c *HTTPClient) roundTrip(client *http.Client, req *http.Request) (*http.Response, error) {
+	next := RoundTripFunc(client.Do)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		next = c.middleware[i](next)
+	}
+	return next(req)
+}
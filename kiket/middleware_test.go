@@ -0,0 +1,51 @@
+package kiket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestHTTPClient_MiddlewareWrapsEveryCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-Id") != "req-1" {
+			t.Errorf("Expected middleware header, got %q", r.Header.Get("X-Request-Id"))
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" "+req.Method)
+				return next(req)
+			}
+		}
+	}
+	client := NewHTTPClient(WithBaseURL(server.URL), WithMiddleware(trace("outer"), func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Request-Id", "req-1")
+			return next(req)
+		}
+	}), WithMiddleware(trace("inner")))
+
+	ctx := context.Background()
+	if _, err := client.Get(ctx, "/api/v1/ext/labels", nil); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if _, err := client.Post(ctx, "/api/v1/ext/labels", map[string]string{"name": "bug"}, nil); err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	if _, err := client.Delete(ctx, "/api/v1/ext/labels/1", nil); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	expected := []string{"outer GET", "inner GET", "outer POST", "inner POST", "outer DELETE", "inner DELETE"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected %v, got %v", expected, calls)
+	}
+}
@@ -0,0 +1,7 @@
+// Package models contains the core Kiket platform resources shared by the
+// SDK's typed clients and webhook payloads. The structs are generated from
+// openapi.yaml; edit the spec and run go generate rather than editing
+// models_gen.go.
+package models
+
+//go:generate go run ./internal/gen -spec openapi.yaml -out models_gen.go
@@ -0,0 +1,168 @@
+// Command gen generates Go structs from the component schemas of an OpenAPI
+// spec. It supports the subset of OpenAPI used by openapi.yaml: scalar
+// types, arrays, $ref, free-form objects, nullable, and x-go-type overrides.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type schema struct {
+	Type                 string      `yaml:"type"`
+	Format               string      `yaml:"format"`
+	Description          string      `yaml:"description"`
+	Ref                  string      `yaml:"$ref"`
+	Items                *schema     `yaml:"items"`
+	Required             []string    `yaml:"required"`
+	Nullable             bool        `yaml:"nullable"`
+	AdditionalProperties interface{} `yaml:"additionalProperties"`
+	GoType               string      `yaml:"x-go-type"`
+	Properties           yaml.Node   `yaml:"properties"`
+}
+
+var initialisms = map[string]string{
+	"id": "ID", "ids": "IDs", "url": "URL", "api": "API", "uri": "URI", "http": "HTTP",
+}
+
+func main() {
+	specPath := flag.String("spec", "openapi.yaml", "OpenAPI spec to read")
+	outPath := flag.String("out", "models_gen.go", "Go file to write")
+	pkg := flag.String("package", "models", "package name")
+	flag.Parse()
+
+	content, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var spec struct {
+		Components struct {
+			Schemas yaml.Node `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		log.Fatalf("failed to parse %s: %v", *specPath, err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by internal/gen from %s. DO NOT EDIT.\n\n", *specPath)
+	fmt.Fprintf(&buf, "package %s\n", *pkg)
+
+	schemas := spec.Components.Schemas.Content
+	for i := 0; i+1 < len(schemas); i += 2 {
+		name := schemas[i].Value
+		var s schema
+		if err := schemas[i+1].Decode(&s); err != nil {
+			log.Fatalf("schema %s: %v", name, err)
+		}
+		if err := writeStruct(&buf, name, &s); err != nil {
+			log.Fatalf("schema %s: %v", name, err)
+		}
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format generated code: %v\n%s", err, buf.String())
+	}
+	if err := os.WriteFile(*outPath, formatted, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeStruct(buf *bytes.Buffer, name string, s *schema) error {
+	required := make(map[string]bool, len(s.Required))
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	buf.WriteString("\n")
+	if s.Description != "" {
+		fmt.Fprintf(buf, "// %s\n", s.Description)
+	}
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+
+	props := s.Properties.Content
+	for i := 0; i+1 < len(props); i += 2 {
+		jsonName := props[i].Value
+		var prop schema
+		if err := props[i+1].Decode(&prop); err != nil {
+			return fmt.Errorf("property %s: %w", jsonName, err)
+		}
+
+		goType, err := goTypeOf(&prop)
+		if err != nil {
+			return fmt.Errorf("property %s: %w", jsonName, err)
+		}
+		if prop.Nullable && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map[") && goType != "interface{}" {
+			goType = "*" + goType
+		}
+
+		tag := jsonName
+		if !required[jsonName] {
+			tag += ",omitempty"
+		}
+
+		if prop.Description != "" {
+			fmt.Fprintf(buf, "\t// %s\n", prop.Description)
+		}
+		fmt.Fprintf(buf, "\t%s %s `json:\"%s\"`\n", fieldName(jsonName), goType, tag)
+	}
+
+	buf.WriteString("}\n")
+	return nil
+}
+
+func goTypeOf(s *schema) (string, error) {
+	if s.GoType != "" {
+		return s.GoType, nil
+	}
+	if s.Ref != "" {
+		return s.Ref[strings.LastIndex(s.Ref, "/")+1:], nil
+	}
+
+	switch s.Type {
+	case "string":
+		return "string", nil
+	case "integer":
+		return "int64", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		item, err := goTypeOf(s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case "object":
+		return "map[string]interface{}", nil
+	}
+	return "", fmt.Errorf("unsupported type %q", s.Type)
+}
+
+func fieldName(jsonName string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(jsonName, "_") {
+		if part == "" {
+			continue
+		}
+		if upper, ok := initialisms[part]; ok {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
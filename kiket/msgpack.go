@@ -0,0 +1,288 @@
+package kiket
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+type msgpackFormat struct{}
+
+// MsgPackFormat returns the MessagePack payload format
+// ("application/msgpack"). Binary values become base64 strings and
+// timestamps become RFC 3339 strings.
+func MsgPackFormat() PayloadFormat {
+	return msgpackFormat{}
+}
+
+func (msgpackFormat) ContentType() string { return "application/msgpack" }
+
+func (msgpackFormat) AppendJSON(dst, data []byte) ([]byte, error) {
+	d := msgpackDecoder{data: data}
+	dst, err := d.value(dst, 0)
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, errors.New("trailing data after payload")
+	}
+	return dst, nil
+}
+
+type msgpackDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *msgpackDecoder) next(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.pos < n {
+		return nil, errFormatTruncated
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *msgpackDecoder) uint(size int) (uint64, error) {
+	b, err := d.next(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+}
+
+func (d *msgpackDecoder) length(size int) (int, error) {
+	n, err := d.uint(size)
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(d.data)) {
+		return 0, errFormatTruncated
+	}
+	return int(n), nil
+}
+
+func (d *msgpackDecoder) value(dst []byte, depth int) ([]byte, error) {
+	if depth > maxFormatDepth {
+		return nil, errors.New("payload nested too deeply")
+	}
+	b, err := d.next(1)
+	if err != nil {
+		return nil, err
+	}
+	c := b[0]
+
+	switch {
+	case c <= 0x7f:
+		return strconv.AppendUint(dst, uint64(c), 10), nil
+	case c >= 0xe0:
+		return strconv.AppendInt(dst, int64(int8(c)), 10), nil
+	case c >= 0x80 && c <= 0x8f:
+		return d.object(dst, int(c&0x0f), depth)
+	case c >= 0x90 && c <= 0x9f:
+		return d.array(dst, int(c&0x0f), depth)
+	case c >= 0xa0 && c <= 0xbf:
+		return d.str(dst, int(c&0x1f))
+	}
+
+	switch c {
+	case 0xc0:
+		return append(dst, "null"...), nil
+	case 0xc2:
+		return append(dst, "false"...), nil
+	case 0xc3:
+		return append(dst, "true"...), nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.length(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		raw, err := d.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return appendJSONBase64(dst, raw), nil
+	case 0xc7, 0xc8, 0xc9:
+		n, err := d.length(1 << (c - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return d.ext(dst, n)
+	case 0xca:
+		bits, err := d.uint(4)
+		if err != nil {
+			return nil, err
+		}
+		return appendJSONFloat(dst, float64(math.Float32frombits(uint32(bits))))
+	case 0xcb:
+		bits, err := d.uint(8)
+		if err != nil {
+			return nil, err
+		}
+		return appendJSONFloat(dst, math.Float64frombits(bits))
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, err := d.uint(1 << (c - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		return strconv.AppendUint(dst, n, 10), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		n, err := d.uint(size)
+		if err != nil {
+			return nil, err
+		}
+		// Sign-extend from the encoded width.
+		shift := 64 - 8*size
+		return strconv.AppendInt(dst, int64(n<<shift)>>shift, 10), nil
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.ext(dst, 1<<(c-0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.length(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.str(dst, n)
+	case 0xdc, 0xdd:
+		n, err := d.length(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.array(dst, n, depth)
+	case 0xde, 0xdf:
+		n, err := d.length(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.object(dst, n, depth)
+	}
+	return nil, fmt.Errorf("invalid msgpack type byte 0x%02x", c)
+}
+
+func (d *msgpackDecoder) str(dst []byte, n int) ([]byte, error) {
+	s, err := d.next(n)
+	if err != nil {
+		return nil, err
+	}
+	return appendJSONString(dst, s), nil
+}
+
+func (d *msgpackDecoder) array(dst []byte, n int, depth int) ([]byte, error) {
+	dst = append(dst, '[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		var err error
+		if dst, err = d.value(dst, depth+1); err != nil {
+			return nil, err
+		}
+	}
+	return append(dst, ']'), nil
+}
+
+func (d *msgpackDecoder) object(dst []byte, n int, depth int) ([]byte, error) {
+	dst = append(dst, '{')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		var err error
+		if dst, err = d.key(dst); err != nil {
+			return nil, err
+		}
+		dst = append(dst, ':')
+		if dst, err = d.value(dst, depth+1); err != nil {
+			return nil, err
+		}
+	}
+	return append(dst, '}'), nil
+}
+
+// key transcodes a map key, which JSON requires to be a string. Integer
+// keys are quoted.
+func (d *msgpackDecoder) key(dst []byte) ([]byte, error) {
+	if d.pos >= len(d.data) {
+		return nil, errFormatTruncated
+	}
+	c := d.data[d.pos]
+	if (c >= 0xa0 && c <= 0xbf) || (c >= 0xd9 && c <= 0xdb) {
+		return d.value(dst, 0)
+	}
+	if c <= 0x7f || c >= 0xe0 || (c >= 0xcc && c <= 0xd3) {
+		dst = append(dst, '"')
+		dst, err := d.value(dst, 0)
+		if err != nil {
+			return nil, err
+		}
+		return append(dst, '"'), nil
+	}
+	return nil, fmt.Errorf("unsupported msgpack map key type 0x%02x", c)
+}
+
+// ext transcodes an extension value. Only the timestamp extension (-1) is
+// supported.
+func (d *msgpackDecoder) ext(dst []byte, n int) ([]byte, error) {
+	typ, err := d.next(1)
+	if err != nil {
+		return nil, err
+	}
+	data, err := d.next(n)
+	if err != nil {
+		return nil, err
+	}
+	if int8(typ[0]) != -1 {
+		return nil, fmt.Errorf("unsupported msgpack extension type %d", int8(typ[0]))
+	}
+
+	var t time.Time
+	switch n {
+	case 4:
+		t = time.Unix(int64(binary.BigEndian.Uint32(data)), 0)
+	case 8:
+		v := binary.BigEndian.Uint64(data)
+		t = time.Unix(int64(v&0x3ffffffff), int64(v>>34))
+	case 12:
+		t = time.Unix(int64(binary.BigEndian.Uint64(data[4:])), int64(binary.BigEndian.Uint32(data)))
+	default:
+		return nil, errors.New("invalid msgpack timestamp")
+	}
+	return appendJSONString(dst, []byte(t.UTC().Format(time.RFC3339Nano))), nil
+}
+
+// appendJSONBase64 appends raw as a base64 JSON string, as encoding/json
+// encodes []byte.
+func appendJSONBase64(dst, raw []byte) []byte {
+	dst = append(dst, '"')
+	start := len(dst)
+	dst = append(dst, make([]byte, base64.StdEncoding.EncodedLen(len(raw)))...)
+	base64.StdEncoding.Encode(dst[start:], raw)
+	return append(dst, '"')
+}
+
+// appendJSONFloat appends f as encoding/json would, rejecting values JSON
+// cannot represent.
+func appendJSONFloat(dst []byte, f float64) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, errors.New("NaN and infinity are not supported")
+	}
+	b, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return append(dst, b...), nil
+}
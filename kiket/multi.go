@@ -0,0 +1,213 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// WorkspaceIDHeader identifies the workspace a delivery belongs to.
+const WorkspaceIDHeader = "X-Kiket-Workspace-Id"
+
+// ErrUnknownWorkspace is returned for deliveries to a workspace that is not
+// registered with a MultiSDK.
+var ErrUnknownWorkspace = errors.New("unknown workspace")
+
+// WorkspaceCredentials are the per-workspace values of an extension
+// installed in several workspaces.
+type WorkspaceCredentials struct {
+	// Extension API key for the workspace
+	ExtensionAPIKey string
+	// Workspace token, used when ExtensionAPIKey is empty
+	WorkspaceToken string
+	// Webhook HMAC secret of the workspace's installation
+	WebhookSecret string
+	// Settings overriding the shared Config.Settings
+	Settings Settings
+}
+
+// MultiSDK serves an extension installed in many workspaces. It keeps one
+// SDK per workspace, built from a shared Config and the workspace's
+// credentials, and routes each delivery by its X-Kiket-Workspace-Id header.
+// Handlers are registered once and apply to every workspace:
+//
+//	multi := kiket.NewMultiSDK(kiket.Config{ManifestPath: "extension.yaml"})
+//	multi.On("issue.created", handleIssueCreated)
+//	multi.AddWorkspace("acme", kiket.WorkspaceCredentials{ExtensionAPIKey: key, WebhookSecret: secret})
+//	http.Handle("/webhooks", multi)
+//
+// Within a handler, HandlerContext.Client and Endpoints use the delivering
+// workspace's credentials and HandlerContext.WorkspaceID names it.
+type MultiSDK struct {
+	base Config
+
+	mu         sync.RWMutex
+	workspaces map[string]*SDK
+	handlers   []*HandlerMetadata
+}
+
+// NewMultiSDK creates a MultiSDK whose workspaces share base. Credential
+// fields in base are ignored; they come from AddWorkspace.
+func NewMultiSDK(base Config) *MultiSDK {
+	base.ExtensionAPIKey = ""
+	base.WorkspaceToken = ""
+	base.WebhookSecret = ""
+	return &MultiSDK{
+		base:       base,
+		workspaces: make(map[string]*SDK),
+	}
+}
+
+// AddWorkspace registers a workspace, replacing any previous registration
+// with the same ID. The replaced SDK is closed.
+func (m *MultiSDK) AddWorkspace(workspaceID string, credentials WorkspaceCredentials) error {
+	if workspaceID == "" {
+		return errors.New("workspace ID is required")
+	}
+
+	config := m.base
+	config.ExtensionAPIKey = credentials.ExtensionAPIKey
+	config.WorkspaceToken = credentials.WorkspaceToken
+	config.WebhookSecret = credentials.WebhookSecret
+	config.Settings = mergeSettings(m.base.Settings, credentials.Settings)
+
+	sdk, err := New(config)
+	if err != nil {
+		return fmt.Errorf("workspace %s: %w", workspaceID, err)
+	}
+
+	m.mu.Lock()
+	for _, meta := range m.handlers {
+		sdk.register(meta)
+	}
+	previous := m.workspaces[workspaceID]
+	m.workspaces[workspaceID] = sdk
+	m.mu.Unlock()
+
+	if previous != nil {
+		return previous.Close()
+	}
+	return nil
+}
+
+// RemoveWorkspace unregisters a workspace, for example after the extension
+// is uninstalled from it, and shuts down its SDK.
+func (m *MultiSDK) RemoveWorkspace(ctx context.Context, workspaceID string) error {
+	m.mu.Lock()
+	sdk := m.workspaces[workspaceID]
+	delete(m.workspaces, workspaceID)
+	m.mu.Unlock()
+
+	if sdk == nil {
+		return ErrUnknownWorkspace
+	}
+	return sdk.Shutdown(ctx)
+}
+
+// Workspace returns the SDK of a registered workspace, for API calls made
+// outside of a delivery.
+func (m *MultiSDK) Workspace(workspaceID string) (*SDK, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sdk, ok := m.workspaces[workspaceID]
+	return sdk, ok
+}
+
+// WorkspaceIDs returns the registered workspace IDs in sorted order.
+func (m *MultiSDK) WorkspaceIDs() []string {
+	m.mu.RLock()
+	ids := make([]string, 0, len(m.workspaces))
+	for id := range m.workspaces {
+		ids = append(ids, id)
+	}
+	m.mu.RUnlock()
+
+	sort.Strings(ids)
+	return ids
+}
+
+// On registers a webhook handler for an event in every workspace.
+func (m *MultiSDK) On(event string, handler WebhookHandler, versions ...string) {
+	for _, version := range handlerVersions(versions) {
+		m.register(&HandlerMetadata{Event: event, Version: version, Handler: handler})
+	}
+}
+
+// OnRaw registers a raw webhook handler (see SDK.OnRaw) in every workspace.
+func (m *MultiSDK) OnRaw(event string, handler RawWebhookHandler, versions ...string) {
+	for _, version := range handlerVersions(versions) {
+		m.register(&HandlerMetadata{Event: event, Version: version, RawHandler: handler})
+	}
+}
+
+func (m *MultiSDK) register(meta *HandlerMetadata) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.handlers = append(m.handlers, meta)
+	for _, sdk := range m.workspaces {
+		sdk.register(meta)
+	}
+}
+
+// HandleWebhook routes a delivery to its workspace's SDK.
+func (m *MultiSDK) HandleWebhook(ctx context.Context, body []byte, headers Headers) (interface{}, error) {
+	sdk, err := m.route(headerValue(headers, WorkspaceIDHeader))
+	if err != nil {
+		return nil, err
+	}
+	return sdk.HandleWebhook(ctx, body, headers)
+}
+
+// ServeHTTP implements http.Handler, answering 404 for deliveries to
+// unregistered workspaces.
+func (m *MultiSDK) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sdk, err := m.route(r.Header.Get(WorkspaceIDHeader))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sdk.ServeHTTP(w, r)
+}
+
+func (m *MultiSDK) route(workspaceID string) (*SDK, error) {
+	if workspaceID == "" {
+		return nil, fmt.Errorf("missing %s header", WorkspaceIDHeader)
+	}
+	sdk, ok := m.Workspace(workspaceID)
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownWorkspace, workspaceID)
+	}
+	return sdk, nil
+}
+
+// Close closes every workspace SDK, waiting up to defaultShutdownTimeout.
+func (m *MultiSDK) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultShutdownTimeout)
+	defer cancel()
+
+	return m.Shutdown(ctx)
+}
+
+// Shutdown shuts down every workspace SDK (see SDK.Shutdown) and returns
+// the first error.
+func (m *MultiSDK) Shutdown(ctx context.Context) error {
+	m.mu.RLock()
+	sdks := make([]*SDK, 0, len(m.workspaces))
+	for _, sdk := range m.workspaces {
+		sdks = append(sdks, sdk)
+	}
+	m.mu.RUnlock()
+
+	var first error
+	for _, sdk := range sdks {
+		if err := sdk.Shutdown(ctx); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
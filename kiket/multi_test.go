@@ -0,0 +1,85 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMultiSDK_RoutesByWorkspace(t *testing.T) {
+	multi := NewMultiSDK(Config{
+		ExtensionID: "com.example.ext",
+		Settings:    Settings{"channel": "#general"},
+	})
+	defer multi.Close()
+
+	type seen struct {
+		workspace, channel string
+		client             Client
+	}
+	deliveries := make(chan seen, 2)
+	multi.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		deliveries <- seen{hctx.WorkspaceID, hctx.Settings["channel"].(string), hctx.Client}
+		return nil, nil
+	})
+
+	if err := multi.AddWorkspace("acme", WorkspaceCredentials{ExtensionAPIKey: "acme-key", WebhookSecret: "acme-secret"}); err != nil {
+		t.Fatalf("AddWorkspace failed: %v", err)
+	}
+	if err := multi.AddWorkspace("globex", WorkspaceCredentials{
+		ExtensionAPIKey: "globex-key",
+		WebhookSecret:   "globex-secret",
+		Settings:        Settings{"channel": "#ops"},
+	}); err != nil {
+		t.Fatalf("AddWorkspace failed: %v", err)
+	}
+
+	deliver := func(workspace, secret string) int {
+		body := `{"event":"issue.created","issue":{"id":1}}`
+		signature, timestamp := GenerateSignature(secret, body, nil)
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+		req.Header.Set("X-Kiket-Signature", signature)
+		req.Header.Set("X-Kiket-Timestamp", timestamp)
+		req.Header.Set(WorkspaceIDHeader, workspace)
+		rec := httptest.NewRecorder()
+		multi.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := deliver("globex", "globex-secret"); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	got := <-deliveries
+	globex, _ := multi.Workspace("globex")
+	if got.workspace != "globex" || got.channel != "#ops" || got.client != globex.Client() {
+		t.Errorf("Unexpected handler context %+v", got)
+	}
+
+	if code := deliver("acme", "acme-secret"); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if got := <-deliveries; got.workspace != "acme" || got.channel != "#general" {
+		t.Errorf("Unexpected handler context %+v", got)
+	}
+
+	if code := deliver("acme", "globex-secret"); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for another workspace's signature, got %d", code)
+	}
+	if code := deliver("initech", "secret"); code != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown workspace, got %d", code)
+	}
+
+	if err := multi.RemoveWorkspace(context.Background(), "acme"); err != nil {
+		t.Fatalf("RemoveWorkspace failed: %v", err)
+	}
+	_, err := multi.HandleWebhook(context.Background(), []byte(`{}`), Headers{WorkspaceIDHeader: "acme"})
+	if !errors.Is(err, ErrUnknownWorkspace) {
+		t.Errorf("Expected ErrUnknownWorkspace, got %v", err)
+	}
+	if ids := multi.WorkspaceIDs(); len(ids) != 1 || ids[0] != "globex" {
+		t.Errorf("Expected [globex], got %v", ids)
+	}
+}
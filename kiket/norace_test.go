@@ -0,0 +1,5 @@
+//go:build !race
+
+package kiket
+
+const raceEnabled = false
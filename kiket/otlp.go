@@ -0,0 +1,434 @@
+package kiket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const otlpScopeName = "github.com/kiket-dev/kiket/sdk/go/kiket"
+
+// OTLPExporter converts telemetry records into OpenTelemetry logs and
+// metrics and ships them to a collector over OTLP/HTTP with JSON encoding.
+//
+// Each record becomes a log record. Handler durations are aggregated per
+// event, version, and status into a summary metric; heartbeat, histogram,
+// and relay records are left out. Custom metrics are mapped to sums
+// (counters), gauges, and summaries (timers).
+type OTLPExporter struct {
+	endpoint    string
+	headers     map[string]string
+	serviceName string
+	logs        bool
+	metrics     bool
+	httpClient  *http.Client
+}
+
+// OTLPOption configures the OTLP exporter.
+type OTLPOption func(*OTLPExporter)
+
+// WithOTLPHeaders sets headers sent with every export, e.g. vendor API keys.
+func WithOTLPHeaders(headers map[string]string) OTLPOption {
+	return func(e *OTLPExporter) {
+		for k, v := range headers {
+			e.headers[k] = v
+		}
+	}
+}
+
+// WithOTLPServiceName sets the service.name resource attribute. Defaults to
+// the extension ID of each record.
+func WithOTLPServiceName(name string) OTLPOption {
+	return func(e *OTLPExporter) {
+		e.serviceName = name
+	}
+}
+
+// WithOTLPLogs enables or disables the log signal (enabled by default).
+func WithOTLPLogs(enabled bool) OTLPOption {
+	return func(e *OTLPExporter) {
+		e.logs = enabled
+	}
+}
+
+// WithOTLPMetrics enables or disables the metric signal (enabled by default).
+func WithOTLPMetrics(enabled bool) OTLPOption {
+	return func(e *OTLPExporter) {
+		e.metrics = enabled
+	}
+}
+
+// WithOTLPHTTPClient sets the HTTP client used for exports.
+func WithOTLPHTTPClient(client *http.Client) OTLPOption {
+	return func(e *OTLPExporter) {
+		e.httpClient = client
+	}
+}
+
+// NewOTLPExporter creates an exporter for the collector at endpoint, e.g.
+// "http://localhost:4318". Signals are posted to /v1/logs and /v1/metrics.
+func NewOTLPExporter(endpoint string, opts ...OTLPOption) *OTLPExporter {
+	e := &OTLPExporter{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		headers:  make(map[string]string),
+		logs:     true,
+		metrics:  true,
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Export implements TelemetryExporter.
+func (e *OTLPExporter) Export(ctx context.Context, records []TelemetryRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	if e.logs {
+		if err := e.post(ctx, "/v1/logs", e.buildLogs(records)); err != nil {
+			return err
+		}
+	}
+	if e.metrics {
+		if payload := e.buildMetrics(records); payload != nil {
+			if err := e.post(ctx, "/v1/metrics", payload); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (e *OTLPExporter) post(ctx context.Context, path string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal OTLP payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("OTLP export to %s failed (status %d): %s", path, resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+// OTLP/JSON wire types. Field names follow the proto3 JSON mapping; 64-bit
+// integers are encoded as strings.
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string         `json:"timeUnixNano"`
+	SeverityNumber int            `json:"severityNumber"`
+	SeverityText   string         `json:"severityText"`
+	Body           otlpValue      `json:"body"`
+	Attributes     []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpNumberDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string         `json:"timeUnixNano"`
+	AsDouble          float64        `json:"asDouble"`
+}
+
+type otlpQuantileValue struct {
+	Quantile float64 `json:"quantile"`
+	Value    float64 `json:"value"`
+}
+
+type otlpSummaryDataPoint struct {
+	Attributes        []otlpKeyValue      `json:"attributes"`
+	StartTimeUnixNano string              `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string              `json:"timeUnixNano"`
+	Count             string              `json:"count"`
+	Sum               float64             `json:"sum"`
+	QuantileValues    []otlpQuantileValue `json:"quantileValues,omitempty"`
+}
+
+type otlpSum struct {
+	DataPoints             []otlpNumberDataPoint `json:"dataPoints"`
+	AggregationTemporality int                   `json:"aggregationTemporality"`
+	IsMonotonic            bool                  `json:"isMonotonic"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpNumberDataPoint `json:"dataPoints"`
+}
+
+type otlpSummary struct {
+	DataPoints []otlpSummaryDataPoint `json:"dataPoints"`
+}
+
+type otlpMetric struct {
+	Name    string       `json:"name"`
+	Unit    string       `json:"unit,omitempty"`
+	Sum     *otlpSum     `json:"sum,omitempty"`
+	Gauge   *otlpGauge   `json:"gauge,omitempty"`
+	Summary *otlpSummary `json:"summary,omitempty"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+const (
+	otlpSeverityInfo  = 9
+	otlpSeverityError = 17
+
+	otlpTemporalityDelta = 1
+)
+
+func otlpString(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpValue{StringValue: &value}}
+}
+
+func otlpNanos(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func (e *OTLPExporter) resource(record TelemetryRecord) otlpResource {
+	name := e.serviceName
+	if name == "" {
+		name = record.ExtensionID
+	}
+
+	attrs := []otlpKeyValue{otlpString("service.name", name)}
+	if record.ExtensionVersion != "" {
+		attrs = append(attrs, otlpString("service.version", record.ExtensionVersion))
+	}
+	if record.ExtensionID != "" {
+		attrs = append(attrs, otlpString("kiket.extension.id", record.ExtensionID))
+	}
+	return otlpResource{Attributes: attrs}
+}
+
+func (e *OTLPExporter) buildLogs(records []TelemetryRecord) map[string]interface{} {
+	logs := make([]otlpLogRecord, 0, len(records))
+	for _, record := range records {
+		severity, severityText := otlpSeverityInfo, "INFO"
+		if record.Status == "error" {
+			severity, severityText = otlpSeverityError, "ERROR"
+		}
+
+		body := record.Event + " " + record.Status
+		if record.ErrorMessage != "" {
+			body += ": " + record.ErrorMessage
+		}
+
+		duration := float64(record.DurationMs)
+		attrs := []otlpKeyValue{
+			otlpString("kiket.event", record.Event),
+			otlpString("kiket.event.version", record.Version),
+			otlpString("kiket.status", record.Status),
+			{Key: "kiket.duration_ms", Value: otlpValue{DoubleValue: &duration}},
+		}
+		if record.ErrorClass != "" {
+			attrs = append(attrs, otlpString("exception.type", record.ErrorClass))
+		}
+		for k, v := range record.Metadata {
+			attrs = append(attrs, otlpString("kiket.metadata."+k, fmt.Sprint(v)))
+		}
+
+		logs = append(logs, otlpLogRecord{
+			TimeUnixNano:   otlpNanos(record.Timestamp),
+			SeverityNumber: severity,
+			SeverityText:   severityText,
+			Body:           otlpValue{StringValue: &body},
+			Attributes:     attrs,
+		})
+	}
+
+	return map[string]interface{}{
+		"resourceLogs": []otlpResourceLogs{{
+			Resource: e.resource(records[0]),
+			ScopeLogs: []otlpScopeLogs{{
+				Scope:      otlpScope{Name: otlpScopeName},
+				LogRecords: logs,
+			}},
+		}},
+	}
+}
+
+// buildMetrics returns the metrics payload, or nil when records carry no
+// handler durations or custom metrics.
+func (e *OTLPExporter) buildMetrics(records []TelemetryRecord) map[string]interface{} {
+	start := records[0].Timestamp
+	end := records[len(records)-1].Timestamp
+
+	// Aggregate handler durations per event/version/status
+	type durationKey struct{ event, version, status string }
+	type durationAgg struct {
+		count int64
+		sum   float64
+		max   float64
+	}
+	durations := make(map[durationKey]*durationAgg)
+	var durationOrder []durationKey
+
+	var metrics []otlpMetric
+	for _, record := range records {
+		attrs := []otlpKeyValue{
+			otlpString("kiket.event", record.Event),
+			otlpString("kiket.event.version", record.Version),
+		}
+		for _, m := range record.Metrics {
+			metrics = append(metrics, otlpCustomMetric(m, attrs, record.Timestamp))
+		}
+		if !isHandlerRecord(record) {
+			continue
+		}
+
+		key := durationKey{record.Event, record.Version, record.Status}
+		agg, ok := durations[key]
+		if !ok {
+			agg = &durationAgg{}
+			durations[key] = agg
+			durationOrder = append(durationOrder, key)
+		}
+		ms := float64(record.DurationMs)
+		agg.count++
+		agg.sum += ms
+		if ms > agg.max {
+			agg.max = ms
+		}
+	}
+
+	points := make([]otlpSummaryDataPoint, 0, len(durationOrder))
+	for _, key := range durationOrder {
+		agg := durations[key]
+		points = append(points, otlpSummaryDataPoint{
+			Attributes: []otlpKeyValue{
+				otlpString("kiket.event", key.event),
+				otlpString("kiket.event.version", key.version),
+				otlpString("kiket.status", key.status),
+			},
+			StartTimeUnixNano: otlpNanos(start),
+			TimeUnixNano:      otlpNanos(end),
+			Count:             strconv.FormatInt(agg.count, 10),
+			Sum:               agg.sum,
+			QuantileValues:    []otlpQuantileValue{{Quantile: 1, Value: agg.max}},
+		})
+	}
+	if len(points) > 0 {
+		metrics = append([]otlpMetric{{
+			Name:    "kiket.handler.duration",
+			Unit:    "ms",
+			Summary: &otlpSummary{DataPoints: points},
+		}}, metrics...)
+	}
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"resourceMetrics": []otlpResourceMetrics{{
+			Resource: e.resource(records[0]),
+			ScopeMetrics: []otlpScopeMetrics{{
+				Scope:   otlpScope{Name: otlpScopeName},
+				Metrics: metrics,
+			}},
+		}},
+	}
+}
+
+func otlpCustomMetric(m Metric, attrs []otlpKeyValue, at time.Time) otlpMetric {
+	ts := otlpNanos(at)
+	point := otlpNumberDataPoint{Attributes: attrs, TimeUnixNano: ts, AsDouble: m.Value}
+
+	switch m.Kind {
+	case MetricGauge:
+		return otlpMetric{Name: m.Name, Gauge: &otlpGauge{DataPoints: []otlpNumberDataPoint{point}}}
+	case MetricTimer:
+		return otlpMetric{Name: m.Name, Unit: "ms", Summary: &otlpSummary{DataPoints: []otlpSummaryDataPoint{{
+			Attributes:     attrs,
+			TimeUnixNano:   ts,
+			Count:          strconv.FormatInt(m.Count, 10),
+			Sum:            m.Value,
+			QuantileValues: []otlpQuantileValue{{Quantile: 1, Value: m.MaxMs}},
+		}}}}
+	default:
+		return otlpMetric{Name: m.Name, Sum: &otlpSum{
+			DataPoints:             []otlpNumberDataPoint{point},
+			AggregationTemporality: otlpTemporalityDelta,
+			IsMonotonic:            true,
+		}}
+	}
+}
+
+// isHandlerRecord reports whether record timed a handler invocation, as
+// opposed to a heartbeat, a histogram, or a relayed event.
+func isHandlerRecord(record TelemetryRecord) bool {
+	switch {
+	case record.Event == heartbeatEvent, record.Event == histogramEvent:
+		return false
+	case strings.HasPrefix(record.Event, "relay:"):
+		return false
+	default:
+		return true
+	}
+}
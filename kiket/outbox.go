@@ -0,0 +1,407 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultOutboxInterval    = 5 * time.Second
+	defaultOutboxMaxAttempts = 10
+	outboxRetryBackoff       = time.Second
+	maxOutboxRetryBackoff    = 5 * time.Minute
+	outboxBatchSize          = 100
+	outboxFlushTimeout       = 30 * time.Second
+)
+
+// ErrOutboxDisabled is returned by Outbox methods when Config.OutboxStore
+// is not set.
+var ErrOutboxDisabled = errors.New("outbox not configured; set Config.OutboxStore")
+
+// OutboxEntry is an API mutation a handler queued on its Outbox.
+type OutboxEntry struct {
+	// Unique ID, also sent as the Idempotency-Key header
+	ID     string          `json:"id"`
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	// Extra request headers, e.g. ActAsHeader
+	Headers     Headers `json:"headers,omitempty"`
+	WorkspaceID string  `json:"workspace_id,omitempty"`
+	// Event and delivery whose handler queued the entry
+	Event      string    `json:"event,omitempty"`
+	DeliveryID string    `json:"delivery_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	Attempts int `json:"attempts"`
+	// Earliest time of the next attempt (zero: now)
+	NextAttempt time.Time `json:"next_attempt,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// OutboxStore persists committed outbox entries until they are applied.
+// Implement it on the extension's database so entries survive restarts;
+// NewMemoryOutboxStore keeps them in memory.
+type OutboxStore interface {
+	// Add persists the entries one delivery committed, all or none.
+	Add(ctx context.Context, entries []OutboxEntry) error
+	// Pending returns up to limit entries that are due at now, oldest
+	// first. It skips entries whose NextAttempt is after now, and the later
+	// entries of their delivery, so a batch of backed-off entries cannot
+	// starve the ones behind it.
+	Pending(ctx context.Context, now time.Time, limit int) ([]OutboxEntry, error)
+	// Update saves an entry's attempt count, next attempt, and last error.
+	Update(ctx context.Context, entry OutboxEntry) error
+	// Remove deletes an entry that was applied or given up on.
+	Remove(ctx context.Context, id string) error
+}
+
+// Outbox collects the API mutations of one delivery. They are committed to
+// the OutboxStore only when the handler returns without error, then
+// applied in order with retries, so a handler failing midway leaves no
+// side effects half-applied. Reads should still go through the clients.
+//
+//	path := kiket.JoinPath("/api/v1/ext/issues", issueID, "assign")
+//	if err := hctx.Outbox.Post(path, map[string]interface{}{"assignee_id": userID}); err != nil {
+//		return nil, err
+//	}
+type Outbox struct {
+	event       string
+	deliveryID  string
+	workspaceID string
+
+	mu      sync.Mutex
+	entries []OutboxEntry
+}
+
+// Post queues a POST of body to path.
+func (o *Outbox) Post(path string, body interface{}) error {
+	return o.Enqueue(http.MethodPost, path, body, nil)
+}
+
+// Put queues a PUT of body to path.
+func (o *Outbox) Put(path string, body interface{}) error {
+	return o.Enqueue(http.MethodPut, path, body, nil)
+}
+
+// Patch queues a PATCH of body to path.
+func (o *Outbox) Patch(path string, body interface{}) error {
+	return o.Enqueue(http.MethodPatch, path, body, nil)
+}
+
+// Delete queues a DELETE of path.
+func (o *Outbox) Delete(path string) error {
+	return o.Enqueue(http.MethodDelete, path, nil, nil)
+}
+
+// Enqueue queues a request with extra headers. body is encoded now, so
+// later changes to it are not sent.
+func (o *Outbox) Enqueue(method, path string, body interface{}, headers Headers) error {
+	if o == nil {
+		return ErrOutboxDisabled
+	}
+
+	entry := OutboxEntry{
+		Method:      method,
+		Path:        path,
+		Headers:     headers,
+		WorkspaceID: o.workspaceID,
+		Event:       o.event,
+		DeliveryID:  o.deliveryID,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal outbox body: %w", err)
+		}
+		entry.Body = data
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.entries = append(o.entries, entry)
+	return nil
+}
+
+// Len returns the number of queued mutations.
+func (o *Outbox) Len() int {
+	if o == nil {
+		return 0
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.entries)
+}
+
+// take returns the queued entries with IDs assigned and empties the outbox.
+func (o *Outbox) take() []OutboxEntry {
+	o.mu.Lock()
+	entries := o.entries
+	o.entries = nil
+	o.mu.Unlock()
+
+	prefix := o.deliveryID
+	if prefix == "" {
+		prefix = randomHex(8)
+	}
+	for i := range entries {
+		entries[i].ID = prefix + "-" + strconv.Itoa(i+1)
+	}
+	return entries
+}
+
+// memoryOutboxStore is the in-memory OutboxStore.
+type memoryOutboxStore struct {
+	mu      sync.Mutex
+	entries []OutboxEntry
+}
+
+// NewMemoryOutboxStore returns an OutboxStore that keeps entries in memory.
+// Entries not yet applied are lost when the process exits.
+func NewMemoryOutboxStore() OutboxStore {
+	return &memoryOutboxStore{}
+}
+
+func (m *memoryOutboxStore) Add(ctx context.Context, entries []OutboxEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = append(m.entries, entries...)
+	return nil
+}
+
+func (m *memoryOutboxStore) Pending(ctx context.Context, now time.Time, limit int) ([]OutboxEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	held := make(map[string]bool)
+	var due []OutboxEntry
+	for _, entry := range m.entries {
+		if len(due) >= limit {
+			break
+		}
+		if entry.DeliveryID != "" && held[entry.DeliveryID] {
+			continue
+		}
+		if now.Before(entry.NextAttempt) {
+			held[entry.DeliveryID] = true
+			continue
+		}
+		due = append(due, entry)
+	}
+	return due, nil
+}
+
+func (m *memoryOutboxStore) Update(ctx context.Context, entry OutboxEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.entries {
+		if m.entries[i].ID == entry.ID {
+			m.entries[i] = entry
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *memoryOutboxStore) Remove(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.entries {
+		if m.entries[i].ID == id {
+			m.entries = append(m.entries[:i], m.entries[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// outboxRelay applies committed entries in the background.
+type outboxRelay struct {
+	store       OutboxStore
+	client      Client
+	logger      *log.Logger
+	interval    time.Duration
+	maxAttempts int
+
+	flushMu   sync.Mutex
+	wake      chan struct{}
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+func newOutboxRelay(config Config, client Client) *outboxRelay {
+	r := &outboxRelay{
+		store:       config.OutboxStore,
+		client:      client,
+		logger:      config.Logger,
+		interval:    defaultOutboxInterval,
+		maxAttempts: defaultOutboxMaxAttempts,
+		wake:        make(chan struct{}, 1),
+		done:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+	}
+	go r.loop()
+	return r
+}
+
+// commit persists a delivery's entries and wakes the relay.
+func (r *outboxRelay) commit(ctx context.Context, outbox *Outbox) error {
+	entries := outbox.take()
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := r.store.Add(ctx, entries); err != nil {
+		return fmt.Errorf("failed to commit outbox: %w", err)
+	}
+
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// flush applies the due entries. Entries of a delivery are applied in
+// order: one that fails holds back the rest of its delivery.
+func (r *outboxRelay) flush(ctx context.Context) error {
+	r.flushMu.Lock()
+	defer r.flushMu.Unlock()
+
+	now := time.Now()
+	entries, err := r.store.Pending(ctx, now, outboxBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to read outbox: %w", err)
+	}
+
+	held := make(map[string]bool) // deliveries with an entry not applied yet
+	var errs []error
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			break
+		}
+		if (entry.DeliveryID != "" && held[entry.DeliveryID]) || now.Before(entry.NextAttempt) {
+			held[entry.DeliveryID] = true
+			continue
+		}
+
+		applyErr := r.apply(ctx, entry)
+		switch {
+		case applyErr == nil:
+			err = r.store.Remove(ctx, entry.ID)
+		case isRetryableAPIError(applyErr) && entry.Attempts+1 < r.maxAttempts:
+			held[entry.DeliveryID] = true
+			entry.Attempts++
+			entry.LastError = applyErr.Error()
+			entry.NextAttempt = now.Add(outboxBackoff(entry.Attempts))
+			err = r.store.Update(ctx, entry)
+		default:
+			// Rejected, or out of attempts: drop it so the rest can proceed
+			errs = append(errs, fmt.Errorf("outbox gave up on %s %s after %d attempt(s): %w", entry.Method, entry.Path, entry.Attempts+1, applyErr))
+			err = r.store.Remove(ctx, entry.ID)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to update outbox: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// apply sends one entry with its idempotency key, attributed to the
+// delivery that queued it.
+func (r *outboxRelay) apply(ctx context.Context, entry OutboxEntry) error {
+	if entry.WorkspaceID != "" {
+		ctx = ContextWithWorkspace(ctx, entry.WorkspaceID)
+	}
+	if entry.DeliveryID != "" {
+		ctx = contextWithHandler(ctx, &HandlerContext{Event: entry.Event, DeliveryID: entry.DeliveryID, WorkspaceID: entry.WorkspaceID})
+	}
+	headers := Headers{idempotencyKeyHeader: entry.ID}
+	for name, value := range entry.Headers {
+		headers[name] = value
+	}
+	opts := &RequestOptions{Headers: headers}
+
+	var body interface{}
+	if entry.Body != nil {
+		body = entry.Body
+	}
+	var err error
+	switch entry.Method {
+	case http.MethodPost:
+		_, err = r.client.Post(ctx, entry.Path, body, opts)
+	case http.MethodPut:
+		_, err = r.client.Put(ctx, entry.Path, body, opts)
+	case http.MethodPatch:
+		_, err = r.client.Patch(ctx, entry.Path, body, opts)
+	case http.MethodDelete:
+		_, err = r.client.Delete(ctx, entry.Path, opts)
+	default:
+		err = fmt.Errorf("unsupported outbox method %q", entry.Method)
+	}
+	return err
+}
+
+// outboxBackoff doubles from 1s per attempt, up to 5m.
+func outboxBackoff(attempts int) time.Duration {
+	backoff := outboxRetryBackoff
+	for i := 1; i < attempts && backoff < maxOutboxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxOutboxRetryBackoff {
+		backoff = maxOutboxRetryBackoff
+	}
+	return backoff
+}
+
+func (r *outboxRelay) loop() {
+	defer close(r.stopped)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), outboxFlushTimeout)
+		if err := r.flush(ctx); err != nil {
+			r.logger.Printf("kiket: %v", err)
+		}
+		cancel()
+	}
+}
+
+// close stops the relay loop and makes a last attempt at due entries.
+func (r *outboxRelay) close(ctx context.Context) error {
+	r.closeOnce.Do(func() { close(r.done) })
+	select {
+	case <-r.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return r.flush(ctx)
+}
+
+// FlushOutbox applies the committed outbox entries that are due now,
+// returning the errors of entries that were given up on. Background
+// flushes run every 5s and after each commit; call it to apply entries
+// synchronously, e.g. in tests. It does nothing without Config.OutboxStore.
+func (s *SDK) FlushOutbox(ctx context.Context) error {
+	if s.outbox == nil {
+		return nil
+	}
+	return s.outbox.flush(ctx)
+}
@@ -0,0 +1,189 @@
+package kiket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSDK_OutboxAppliesMutationsOnlyAfterSuccess(t *testing.T) {
+	var mu sync.Mutex
+	var applied []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		applied = append(applied, r.Method+" "+r.URL.Path+" "+r.Header.Get("Idempotency-Key"))
+		mu.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	sdk, err := New(Config{
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+		BaseURL:         server.URL,
+		OutboxStore:     NewMemoryOutboxStore(),
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		if err := hctx.Outbox.Post("/api/v1/ext/issues/1/assign", map[string]int{"assignee_id": 7}); err != nil {
+			return nil, err
+		}
+		if err := hctx.Outbox.Delete("/api/v1/ext/issues/1/labels/triage"); err != nil {
+			return nil, err
+		}
+		if payload["fail"] == true {
+			return nil, errors.New("sync failed midway")
+		}
+		return nil, nil
+	})
+
+	deliver := func(id, body string) {
+		signature, timestamp := GenerateSignature("secret", body, nil)
+		headers := Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp, "X-Kiket-Delivery-Id": id}
+		sdk.HandleWebhook(context.Background(), []byte(body), headers)
+		if err := sdk.FlushOutbox(context.Background()); err != nil {
+			t.Fatalf("FlushOutbox failed: %v", err)
+		}
+	}
+
+	deliver("d1", `{"event":"issue.created","fail":true}`)
+	mu.Lock()
+	if len(applied) != 0 {
+		t.Errorf("Expected no mutations from a failed handler, got %v", applied)
+	}
+	mu.Unlock()
+
+	deliver("d2", `{"event":"issue.created"}`)
+	expected := []string{
+		"POST /api/v1/ext/issues/1/assign d2-1",
+		"DELETE /api/v1/ext/issues/1/labels/triage d2-2",
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(applied, expected) {
+		t.Errorf("Expected %v, got %v", expected, applied)
+	}
+}
+
+func TestSDK_OutboxShutdownTwice(t *testing.T) {
+	sdk, err := New(Config{
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+		OutboxStore:     NewMemoryOutboxStore(),
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := sdk.Shutdown(context.Background()); err != nil {
+			t.Errorf("Shutdown %d failed: %v", i+1, err)
+		}
+	}
+}
+
+func TestSDK_OutboxBackedOffEntriesDoNotStarveDueOnes(t *testing.T) {
+	var mu sync.Mutex
+	var applied []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		applied = append(applied, r.Header.Get("Idempotency-Key"))
+		mu.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	store := NewMemoryOutboxStore()
+	later := time.Now().Add(time.Hour)
+	var entries []OutboxEntry
+	for i := 0; i < 2*outboxBatchSize; i++ {
+		entries = append(entries, OutboxEntry{
+			ID:          fmt.Sprintf("held-%d", i),
+			Method:      http.MethodPost,
+			Path:        "/api/v1/ext/issues/1/comments",
+			DeliveryID:  fmt.Sprintf("held-%d", i),
+			NextAttempt: later,
+		})
+	}
+	entries = append(entries, OutboxEntry{ID: "due-1", Method: http.MethodPost, Path: "/api/v1/ext/issues/2/comments", DeliveryID: "due"})
+	if err := store.Add(context.Background(), entries); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	sdk, err := New(Config{
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+		BaseURL:         server.URL,
+		OutboxStore:     store,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	if err := sdk.FlushOutbox(context.Background()); err != nil {
+		t.Fatalf("FlushOutbox failed: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(applied, []string{"due-1"}) {
+		t.Errorf("Expected the due entry to be applied, got %v", applied)
+	}
+}
+
+func TestSDK_OutboxCallsAreAttributedToTheirDelivery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var audit bytes.Buffer
+	sdk, err := New(Config{
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+		BaseURL:         server.URL,
+		AuditLog:        &audit,
+		OutboxStore:     NewMemoryOutboxStore(),
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		return nil, hctx.Outbox.Post("/api/v1/ext/issues/1/comments", map[string]string{"body": "hi"})
+	})
+
+	body := `{"event":"issue.created"}`
+	signature, timestamp := GenerateSignature("secret", body, nil)
+	if _, err := sdk.HandleWebhook(context.Background(), []byte(body), Headers{
+		"X-Kiket-Signature":   signature,
+		"X-Kiket-Timestamp":   timestamp,
+		"X-Kiket-Delivery-Id": "del_1",
+		WorkspaceIDHeader:     "ws_1",
+	}); err != nil {
+		t.Fatalf("HandleWebhook failed: %v", err)
+	}
+	if err := sdk.FlushOutbox(context.Background()); err != nil {
+		t.Fatalf("FlushOutbox failed: %v", err)
+	}
+
+	var entry CallAuditEntry
+	if err := json.NewDecoder(&audit).Decode(&entry); err != nil {
+		t.Fatalf("Expected an audit entry, got error %v", err)
+	}
+	if entry.Event != "issue.created" || entry.DeliveryID != "del_1" || entry.WorkspaceID != "ws_1" {
+		t.Errorf("Expected the outbox call attributed to del_1, got %+v", entry)
+	}
+}
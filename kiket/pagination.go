@@ -0,0 +1,148 @@
+package kiket
+
+import (
+	"context"
+	"strconv"
+)
+
+// PageFunc fetches the page identified by token ("" for the first page) and
+// returns its items and the token of the next page ("" when there is none).
+// Page-numbered endpoints use the page number as the token.
+type PageFunc[T any] func(ctx context.Context, token string) (items []T, next string, err error)
+
+// IteratorOption configures an Iterator.
+type IteratorOption func(*iteratorOptions)
+
+type iteratorOptions struct {
+	prefetch bool
+}
+
+// WithPrefetch fetches the next page in the background while the caller
+// processes the current one, which roughly halves the wall-clock time of
+// full scans when processing and fetching take similar time.
+func WithPrefetch() IteratorOption {
+	return func(o *iteratorOptions) {
+		o.prefetch = true
+	}
+}
+
+type pageResult[T any] struct {
+	items []T
+	next  string
+	err   error
+}
+
+// Iterator walks every item of a paginated endpoint:
+//
+//	it := audit.Anchors(ctx, kiket.ListAnchorsOptions{}, kiket.WithPrefetch())
+//	defer it.Close()
+//	for it.Next() {
+//		anchor := it.Item()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	fetch  PageFunc[T]
+	opts   iteratorOptions
+
+	items   []T
+	index   int
+	next    string
+	started bool
+	done    bool
+	err     error
+
+	pending chan pageResult[T]
+}
+
+// NewIterator creates an iterator over the pages returned by fetch.
+func NewIterator[T any](ctx context.Context, fetch PageFunc[T], opts ...IteratorOption) *Iterator[T] {
+	it := &Iterator[T]{fetch: fetch, index: -1}
+	for _, opt := range opts {
+		opt(&it.opts)
+	}
+	it.ctx, it.cancel = context.WithCancel(ctx)
+	return it
+}
+
+// Next advances to the next item, fetching pages as needed. It returns
+// false at the end or on error; check Err afterwards.
+func (it *Iterator[T]) Next() bool {
+	for {
+		if it.index+1 < len(it.items) {
+			it.index++
+			return true
+		}
+		if it.done || it.err != nil {
+			return false
+		}
+		if it.started && it.next == "" {
+			it.finish()
+			return false
+		}
+		it.load()
+	}
+}
+
+// Item returns the current item.
+func (it *Iterator[T]) Item() T {
+	return it.items[it.index]
+}
+
+// Err returns the error that stopped iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops any background prefetch. Iterators that ran to the end are
+// closed already.
+func (it *Iterator[T]) Close() {
+	it.finish()
+}
+
+func (it *Iterator[T]) finish() {
+	it.done = true
+	it.cancel()
+}
+
+// load replaces the current page with the next one, taking it from the
+// prefetch channel when one is in flight.
+func (it *Iterator[T]) load() {
+	var result pageResult[T]
+	if it.pending != nil {
+		result = <-it.pending
+		it.pending = nil
+	} else {
+		result = it.get(it.next)
+	}
+
+	it.started = true
+	it.items, it.index, it.next = result.items, -1, result.next
+	if result.err != nil {
+		it.err = result.err
+		it.finish()
+		return
+	}
+
+	if it.opts.prefetch && it.next != "" {
+		it.pending = make(chan pageResult[T], 1)
+		go func(token string, out chan<- pageResult[T]) {
+			out <- it.get(token)
+		}(it.next, it.pending)
+	}
+}
+
+func (it *Iterator[T]) get(token string) pageResult[T] {
+	items, next, err := it.fetch(it.ctx, token)
+	return pageResult[T]{items: items, next: next, err: err}
+}
+
+// pageNumber converts a page token to a 1-based page number.
+func pageNumber(token string) int {
+	page, err := strconv.Atoi(token)
+	if err != nil || page < 1 {
+		return 1
+	}
+	return page
+}
@@ -0,0 +1,91 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestIterator_PrefetchOverlapsProcessing(t *testing.T) {
+	const pages = 3
+	fetched := make(chan int, pages)
+	fetch := func(ctx context.Context, token string) ([]int, string, error) {
+		page := pageNumber(token)
+		fetched <- page
+
+		next := ""
+		if page < pages {
+			next = strconv.Itoa(page + 1)
+		}
+		return []int{page*10 + 1, page*10 + 2}, next, nil
+	}
+
+	it := NewIterator(context.Background(), fetch, WithPrefetch())
+	defer it.Close()
+
+	var got []int
+	for it.Next() {
+		item := it.Item()
+		got = append(got, item)
+		if item%10 != 1 {
+			continue
+		}
+
+		// While the first item of a page is being processed, the page
+		// itself and (except on the last page) its successor are fetched.
+		page := item / 10
+		if p := <-fetched; p != page {
+			t.Fatalf("Expected fetch of page %d, got %d", page, p)
+		}
+		if page < pages {
+			select {
+			case <-waitFor(fetched, page+1, t):
+			case <-time.After(time.Second):
+				t.Fatalf("Expected page %d to be prefetched", page+1)
+			}
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(got) != pages*2 || got[0] != 11 || got[len(got)-1] != 32 {
+		t.Errorf("Unexpected items: %v", got)
+	}
+}
+
+// waitFor peeks for the next fetched page without consuming it from the
+// point of view of later assertions.
+func waitFor(fetched chan int, page int, t *testing.T) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		p := <-fetched
+		if p != page {
+			t.Errorf("Expected prefetch of page %d, got %d", page, p)
+		}
+		fetched <- p
+		close(done)
+	}()
+	return done
+}
+
+func TestIterator_StopsOnError(t *testing.T) {
+	fetch := func(ctx context.Context, token string) ([]string, string, error) {
+		if token == "" {
+			return []string{"a"}, "2", nil
+		}
+		return nil, "", errors.New("boom")
+	}
+
+	it := NewIterator(context.Background(), fetch, WithPrefetch())
+	var got []string
+	for it.Next() {
+		got = append(got, it.Item())
+	}
+
+	if len(got) != 1 || it.Err() == nil {
+		t.Errorf("Expected one item then an error, got %v, %v", got, it.Err())
+	}
+}
@@ -0,0 +1,93 @@
+package kiket
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// projectsPath is the root of project-scoped extension resources.
+const projectsPath = apiPrefix + "/ext/projects"
+
+// pathSegment formats an ID or key as a single escaped path segment.
+func pathSegment(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return escapeSegment(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case float64:
+		return escapeSegment(strconv.FormatFloat(v, 'f', -1, 64))
+	case fmt.Stringer:
+		return escapeSegment(v.String())
+	default:
+		return escapeSegment(fmt.Sprint(v))
+	}
+}
+
+// escapeSegment escapes s as one path segment. The dot segments "." and
+// "..", which url.PathEscape leaves alone, are percent-encoded so that they
+// cannot be resolved against the rest of the path.
+func escapeSegment(s string) string {
+	switch s {
+	case ".":
+		return "%2E"
+	case "..":
+		return "%2E%2E"
+	}
+	return url.PathEscape(s)
+}
+
+// validatePath rejects API paths with empty segments, as left by an empty
+// ID or key, which servers collapse into a different route.
+func validatePath(path string) error {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	if strings.Contains(path, "//") || (len(path) > 1 && strings.HasSuffix(path, "/")) {
+		return fmt.Errorf("invalid API path %q: empty segment", path)
+	}
+	return nil
+}
+
+// joinPath appends each segment to base, escaping IDs and keys so that a
+// value containing "/", "?", or a dot segment cannot change the route. An
+// empty segment leaves an empty path segment, which the client rejects.
+func joinPath(base string, segments ...interface{}) string {
+	var b strings.Builder
+	n := len(base)
+	for range segments {
+		n += 16
+	}
+	b.Grow(n)
+	b.WriteString(base)
+	for _, segment := range segments {
+		b.WriteByte('/')
+		b.WriteString(pathSegment(segment))
+	}
+	return b.String()
+}
+
+// JoinPath builds an API path from base and escaped IDs or keys, for
+// requests made with the raw Client or queued on an Outbox:
+//
+//	kiket.JoinPath("/api/v1/ext/issues", issueID, "assign")
+func JoinPath(base string, segments ...interface{}) string {
+	return joinPath(base, segments...)
+}
+
+// extensionPath returns the API root for an extension's own resources.
+func extensionPath(extensionID string) string {
+	return joinPath(apiPrefix+"/extensions", extensionID)
+}
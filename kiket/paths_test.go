@@ -0,0 +1,72 @@
+package kiket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestJoinPath_EscapesSegments(t *testing.T) {
+	cases := map[string]string{
+		joinPath(issuesPath, 42, "labels", "needs review"): "/api/v1/ext/issues/42/labels/needs%20review",
+		joinPath(customDataPath, "crm", "contacts", 7.0):   "/api/v1/ext/custom_data/crm/contacts/7",
+		joinPath(worklogsPath, "../admin"):                 "/api/v1/ext/worklogs/..%2Fadmin",
+		joinPath(extensionPath("com.example.ext"), "kv"):   "/api/v1/extensions/com.example.ext/kv",
+		JoinPath("/api/v1/ext/issues", "a/b", "assign"):    "/api/v1/ext/issues/a%2Fb/assign",
+		joinPath(issuesPath, "..", "delete"):               "/api/v1/ext/issues/%2E%2E/delete",
+		joinPath(issuesPath, ".", 42):                      "/api/v1/ext/issues/%2E/42",
+		joinPath(issuesPath, "...", "a.b"):                 "/api/v1/ext/issues/.../a.b",
+	}
+	for got, want := range cases {
+		if got != want {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	}
+}
+
+func TestSecretManager_EscapesKeys(t *testing.T) {
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.EscapedPath()
+		w.Write([]byte(`{"data": {"value": "v"}}`))
+	}))
+	defer server.Close()
+
+	secrets := NewSecretManager(NewHTTPClient(WithBaseURL(server.URL)), "com.example.ext")
+	if _, err := secrets.Get(context.Background(), "team/token?x=1"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	want := "/api/v1/extensions/com.example.ext/secrets/team%2Ftoken%3Fx=1"
+	if requested != want {
+		t.Errorf("Expected %s, got %s", want, requested)
+	}
+}
+
+func TestHTTPClient_RejectsEmptyPathSegments(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(WithBaseURL(server.URL))
+	ctx := context.Background()
+	for _, path := range []string{
+		joinPath(issuesPath, "", "assign"),
+		joinPath(issuesPath, ""),
+		joinPath(issuesPath, "") + "?page=2",
+	} {
+		if _, err := client.Delete(ctx, path, nil); err == nil {
+			t.Errorf("Expected error for %s", path)
+		}
+	}
+	if _, err := client.Get(ctx, joinPath(issuesPath, 42), nil); err != nil {
+		t.Errorf("Get failed: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected only the valid request to be sent, got %d", requests)
+	}
+}
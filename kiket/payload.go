@@ -0,0 +1,54 @@
+package kiket
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Decode converts the payload value under key into out, typically one of
+// the models types:
+//
+//	var issue kiket.Issue
+//	err := payload.Decode("issue", &issue)
+func (p WebhookPayload) Decode(key string, out interface{}) error {
+	raw, ok := p[key]
+	if !ok {
+		return fmt.Errorf("payload has no %s", key)
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return nil
+}
+
+// Issue returns the issue of an issue.* webhook payload.
+func (p WebhookPayload) Issue() (*Issue, error) {
+	var issue Issue
+	if err := p.Decode("issue", &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// Comment returns the comment of a comment.* webhook payload.
+func (p WebhookPayload) Comment() (*Comment, error) {
+	var comment Comment
+	if err := p.Decode("comment", &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Project returns the project of a webhook payload.
+func (p WebhookPayload) Project() (*Project, error) {
+	var project Project
+	if err := p.Decode("project", &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
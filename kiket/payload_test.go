@@ -0,0 +1,68 @@
+package kiket
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestWebhookPayload_DecodesModels(t *testing.T) {
+	var payload WebhookPayload
+	if err := json.Unmarshal([]byte(`{
+		"event": "comment.created",
+		"issue": {"id": 42, "key": "OPS-42", "project_id": 7, "title": "Fix login", "state": "open",
+			"labels": ["bug"], "custom_fields": {"severity": "high"}, "due_date": "2024-03-01"},
+		"comment": {"id": 9, "issue_id": 42, "author_id": 3, "body": "On it", "internal": true},
+		"project": {"id": 7, "key": "OPS", "name": "Operations", "archived": true}
+	}`), &payload); err != nil {
+		t.Fatalf("failed to parse payload: %v", err)
+	}
+
+	issue, err := payload.Issue()
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if issue.Key != "OPS-42" || issue.ProjectID != float64(7) || issue.State != "open" || issue.CustomFields["severity"] != "high" {
+		t.Errorf("Unexpected issue %+v", issue)
+	}
+	if !reflect.DeepEqual(issue.Labels, []string{"bug"}) || issue.DueDate == nil || *issue.DueDate != "2024-03-01" {
+		t.Errorf("Unexpected labels or due date %v, %v", issue.Labels, issue.DueDate)
+	}
+
+	comment, err := payload.Comment()
+	if err != nil || comment.IssueID != float64(42) || comment.Body != "On it" || !comment.Internal {
+		t.Errorf("Unexpected comment %+v (%v)", comment, err)
+	}
+	project, err := payload.Project()
+	if err != nil || project.Key != "OPS" || project.Name != "Operations" || !project.Archived {
+		t.Errorf("Unexpected project %+v (%v)", project, err)
+	}
+
+	var user User
+	if err := payload.Decode("user", &user); err == nil {
+		t.Error("Expected error for a missing key")
+	}
+	if err := payload.Decode("event", &user); err == nil {
+		t.Error("Expected error for a value of the wrong shape")
+	}
+}
+
+func TestModels_EncodeWithAPIFieldNames(t *testing.T) {
+	data, err := json.Marshal(CustomField{ID: 1, Key: "severity", Name: "Severity", Type: "select", Options: []string{"low", "high"}})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var got map[string]interface{}
+	json.Unmarshal(data, &got)
+	expected := map[string]interface{}{
+		"id":      float64(1),
+		"key":     "severity",
+		"name":    "Severity",
+		"type":    "select",
+		"options": []interface{}{"low", "high"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,98 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PermissionCheck reports which of the requested scopes the API credential
+// holds.
+type PermissionCheck struct {
+	Granted []string `json:"granted"`
+	Missing []string `json:"missing"`
+}
+
+// OK reports whether every requested scope was granted.
+func (p *PermissionCheck) OK() bool {
+	return len(p.Missing) == 0
+}
+
+// MissingScopesError is returned by SDK.VerifyScopes when the credential
+// lacks scopes the manifest declares.
+type MissingScopesError struct {
+	Missing []string
+}
+
+func (e *MissingScopesError) Error() string {
+	return fmt.Sprintf("API credential is missing scopes declared in the manifest: %s", strings.Join(e.Missing, ", "))
+}
+
+// CheckPermissions asks the API which of the given scopes (e.g.
+// "issues:write") the configured credential holds.
+func (e *Endpoints) CheckPermissions(ctx context.Context, scopes []string) (*PermissionCheck, error) {
+	if len(scopes) == 0 {
+		return &PermissionCheck{}, nil
+	}
+
+	path := apiPrefix + "/ext/permissions/check"
+	resp, err := e.client.Post(ctx, path, map[string][]string{"scopes": scopes}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeData[PermissionCheck](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Can reports whether the credential may perform action on resource, e.g.
+// Can(ctx, "delete", "issue:42"). Use it before destructive operations.
+func (e *Endpoints) Can(ctx context.Context, action, resource string) (bool, error) {
+	if action == "" || resource == "" {
+		return false, errors.New("action and resource are required")
+	}
+
+	path := apiPrefix + "/ext/permissions/can"
+	resp, err := e.client.Post(ctx, path, map[string]string{
+		"action":   action,
+		"resource": resource,
+	}, nil)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := decodeData[struct {
+		Allowed bool `json:"allowed"`
+	}](resp)
+	if err != nil {
+		return false, err
+	}
+
+	return result.Allowed, nil
+}
+
+// VerifyScopes checks that the API credential holds every scope the manifest
+// declares, returning a *MissingScopesError if not. Call it at startup.
+func (s *SDK) VerifyScopes(ctx context.Context) error {
+	s.settingsMu.RLock()
+	manifest := s.manifest
+	s.settingsMu.RUnlock()
+
+	if manifest == nil || len(manifest.Scopes) == 0 {
+		return nil
+	}
+
+	check, err := s.endpoints.CheckPermissions(ctx, manifest.Scopes)
+	if err != nil {
+		return fmt.Errorf("failed to check permissions: %w", err)
+	}
+	if !check.OK() {
+		return &MissingScopesError{Missing: check.Missing}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestEndpoints_PermissionChecks(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body map[string]interface{}
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+		bodies = append(bodies, body)
+
+		if r.URL.Path == "/api/v1/ext/permissions/can" {
+			w.Write([]byte(`{"data": {"allowed": true}}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"granted": ["issues:read"], "missing": ["issues:write"]}}`))
+	}))
+	defer server.Close()
+
+	endpoints := NewEndpoints(NewHTTPClient(WithBaseURL(server.URL)), "com.example.ext", "v1")
+	ctx := context.Background()
+
+	check, err := endpoints.CheckPermissions(ctx, []string{"issues:read", "issues:write"})
+	if err != nil {
+		t.Fatalf("CheckPermissions failed: %v", err)
+	}
+	if check.OK() || !reflect.DeepEqual(check.Missing, []string{"issues:write"}) {
+		t.Errorf("Expected issues:write to be missing, got %+v", check)
+	}
+
+	allowed, err := endpoints.Can(ctx, "delete", "issue:42")
+	if err != nil || !allowed {
+		t.Errorf("Expected delete to be allowed, got %v (%v)", allowed, err)
+	}
+
+	expected := []string{"POST /api/v1/ext/permissions/check", "POST /api/v1/ext/permissions/can"}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+	if scopes, _ := bodies[0]["scopes"].([]interface{}); len(scopes) != 2 {
+		t.Errorf("Expected requested scopes in the body, got %v", bodies[0])
+	}
+	if bodies[1]["action"] != "delete" || bodies[1]["resource"] != "issue:42" {
+		t.Errorf("Expected action and resource in the body, got %v", bodies[1])
+	}
+
+	if check, err := endpoints.CheckPermissions(ctx, nil); err != nil || !check.OK() || len(requests) != 2 {
+		t.Errorf("Expected no request for an empty scope list, got %+v (%v)", check, err)
+	}
+	if _, err := endpoints.Can(ctx, "", "issue:42"); err == nil {
+		t.Error("Expected error for a missing action")
+	}
+}
+
+func TestSDK_VerifyScopesReportsMissingScopes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"granted": ["issues:read"], "missing": ["issues:write"]}}`))
+	}))
+	defer server.Close()
+
+	path := writeTestManifest(t, "extension.yaml", `
+id: com.example.ext
+version: 1.0.0
+scopes:
+  - issues:read
+  - issues:write
+`)
+	sdk, err := New(Config{
+		ManifestPath:    path,
+		ExtensionAPIKey: "key",
+		BaseURL:         server.URL,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	var missing *MissingScopesError
+	if err := sdk.VerifyScopes(context.Background()); !errors.As(err, &missing) || !reflect.DeepEqual(missing.Missing, []string{"issues:write"}) {
+		t.Errorf("Expected *MissingScopesError for issues:write, got %v", err)
+	}
+}
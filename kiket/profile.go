@@ -0,0 +1,150 @@
+package kiket
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultConfigFile is the file name LoadConfigFile looks for when no path
+// is given.
+const DefaultConfigFile = "kiket.config.yaml"
+
+// Secrets sources a profile can select.
+const (
+	// Read secret settings from KIKET_SECRET_* variables (AutoEnvSecrets)
+	SecretsSourceEnv = "env"
+	// Fetch settings configured in the Kiket UI at startup (RemoteSettings)
+	SecretsSourceAPI = "api"
+	// Use only the manifest and Config.Settings
+	SecretsSourceNone = "none"
+)
+
+// ConfigFile is the structure of kiket.config.yaml:
+//
+//	default_profile: dev
+//	profiles:
+//	  dev:
+//	    base_url: http://localhost:3000
+//	    secrets: env
+//	  prod:
+//	    base_url: https://kiket.dev
+//	    secrets: api
+//	    telemetry_enabled: true
+//	    heartbeat_interval: 30s
+type ConfigFile struct {
+	// Profile used when KIKET_ENV is not set
+	DefaultProfile string `yaml:"default_profile,omitempty"`
+	// Named profiles, e.g. "dev", "staging", "prod"
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// Profile holds per-environment configuration.
+type Profile struct {
+	BaseURL      string `yaml:"base_url,omitempty"`
+	ManifestPath string `yaml:"manifest_path,omitempty"`
+	// Secrets source: "env", "api", or "none"
+	Secrets           string   `yaml:"secrets,omitempty"`
+	TelemetryEnabled  bool     `yaml:"telemetry_enabled,omitempty"`
+	TelemetryURL      string   `yaml:"telemetry_url,omitempty"`
+	HeartbeatInterval string   `yaml:"heartbeat_interval,omitempty"`
+	Settings          Settings `yaml:"settings,omitempty"`
+}
+
+// LoadConfigFile loads a kiket.config.yaml file. With an empty path it looks
+// for DefaultConfigFile in the current directory. It returns nil without an
+// error when the file does not exist.
+func LoadConfigFile(path string) (*ConfigFile, error) {
+	if path == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(cwd, DefaultConfigFile)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var file ConfigFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &file, nil
+}
+
+// Profile returns the named profile, or the default profile when name is
+// empty. It returns nil without an error if neither is set.
+func (f *ConfigFile) Profile(name string) (*Profile, error) {
+	if name == "" {
+		name = f.DefaultProfile
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	profile, ok := f.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(f.Profiles))
+		for n := range f.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(names, ", "))
+	}
+	return &profile, nil
+}
+
+// ApplyProfile fills zero-valued Config fields from a profile, leaving fields
+// that are already set untouched.
+func ApplyProfile(config Config, profile *Profile) (Config, error) {
+	if profile == nil {
+		return config, nil
+	}
+
+	if config.BaseURL == "" {
+		config.BaseURL = profile.BaseURL
+	}
+	if config.ManifestPath == "" {
+		config.ManifestPath = profile.ManifestPath
+	}
+	if config.TelemetryURL == "" {
+		config.TelemetryURL = profile.TelemetryURL
+	}
+	if profile.TelemetryEnabled {
+		config.TelemetryEnabled = true
+	}
+	if config.Settings == nil && profile.Settings != nil {
+		config.Settings = profile.Settings
+	}
+
+	switch profile.Secrets {
+	case "", SecretsSourceNone:
+	case SecretsSourceEnv:
+		config.AutoEnvSecrets = true
+	case SecretsSourceAPI:
+		config.RemoteSettings = true
+	default:
+		return config, fmt.Errorf("invalid profile secrets source %q (want env, api, or none)", profile.Secrets)
+	}
+
+	if profile.HeartbeatInterval != "" && config.HeartbeatInterval == 0 {
+		interval, err := time.ParseDuration(profile.HeartbeatInterval)
+		if err != nil {
+			return config, fmt.Errorf("invalid profile heartbeat_interval: %w", err)
+		}
+		config.HeartbeatInterval = interval
+	}
+
+	return config, nil
+}
@@ -0,0 +1,137 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultAsyncWorkers    = 4
+	defaultAsyncRetryAfter = 5 * time.Second
+)
+
+// ErrQueueFull is returned when an async delivery is rejected because the
+// webhook queue is at its size or byte limit.
+var ErrQueueFull = errors.New("webhook queue is full")
+
+// ErrQueueClosed is returned for deliveries that arrive after Shutdown.
+var ErrQueueClosed = errors.New("webhook queue is closed")
+
+type queuedDelivery struct {
+	body    []byte
+	headers Headers
+}
+
+// webhookQueue is a bounded queue of verified deliveries drained by a fixed
+// pool of workers. It is bounded both by count and, optionally, by the
+// total size of the queued bodies.
+type webhookQueue struct {
+	deliveries chan queuedDelivery
+	maxBytes   int64
+	retryAfter time.Duration
+
+	mu     sync.Mutex
+	bytes  int64
+	closed bool
+
+	workers sync.WaitGroup
+	stopped chan struct{}
+}
+
+func newWebhookQueue(config Config, handle func(queuedDelivery)) *webhookQueue {
+	workers := config.AsyncWorkers
+	if workers <= 0 {
+		workers = defaultAsyncWorkers
+	}
+	retryAfter := config.AsyncRetryAfter
+	if retryAfter <= 0 {
+		retryAfter = defaultAsyncRetryAfter
+	}
+
+	q := &webhookQueue{
+		deliveries: make(chan queuedDelivery, config.AsyncQueueSize),
+		maxBytes:   config.AsyncQueueBytes,
+		retryAfter: retryAfter,
+		stopped:    make(chan struct{}),
+	}
+
+	q.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer q.workers.Done()
+			for d := range q.deliveries {
+				q.release(len(d.body))
+				handle(d)
+			}
+		}()
+	}
+	go func() {
+		q.workers.Wait()
+		close(q.stopped)
+	}()
+
+	return q
+}
+
+// push enqueues d without blocking.
+func (q *webhookQueue) push(d queuedDelivery) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+	size := int64(len(d.body))
+	if q.maxBytes > 0 && q.bytes+size > q.maxBytes {
+		return ErrQueueFull
+	}
+
+	select {
+	case q.deliveries <- d:
+		q.bytes += size
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+func (q *webhookQueue) release(size int) {
+	q.mu.Lock()
+	q.bytes -= int64(size)
+	q.mu.Unlock()
+}
+
+// depth returns the number of deliveries waiting for a worker.
+func (q *webhookQueue) depth() int {
+	return len(q.deliveries)
+}
+
+// retryAfterSeconds formats the Retry-After header value.
+func (q *webhookQueue) retryAfterSeconds() string {
+	seconds := int(q.retryAfter.Round(time.Second) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.Itoa(seconds)
+}
+
+// close stops accepting deliveries and waits for the queued ones to be
+// processed, or for ctx to end.
+func (q *webhookQueue) close(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.deliveries)
+	}
+	q.mu.Unlock()
+
+	select {
+	case <-q.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
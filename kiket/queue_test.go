@@ -0,0 +1,123 @@
+package kiket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSDK_AsyncQueueBackpressure(t *testing.T) {
+	sdk, err := New(Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+		AsyncQueueSize:  1,
+		AsyncWorkers:    1,
+		AsyncRetryAfter: 3 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		started <- struct{}{}
+		<-release
+		return nil, nil
+	})
+
+	deliver := func() *httptest.ResponseRecorder {
+		body := `{"event":"issue.created","issue":{"id":1}}`
+		signature, timestamp := GenerateSignature("secret", body, nil)
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+		req.Header.Set("X-Kiket-Signature", signature)
+		req.Header.Set("X-Kiket-Timestamp", timestamp)
+		rec := httptest.NewRecorder()
+		sdk.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := deliver(); rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d", rec.Code)
+	}
+	<-started // the worker holds the first delivery
+
+	if rec := deliver(); rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d", rec.Code)
+	}
+	if depth := sdk.heartbeatInfo().QueueDepth; depth != 1 {
+		t.Errorf("Expected queue depth 1, got %d", depth)
+	}
+
+	rec := deliver()
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "3" {
+		t.Errorf("Expected Retry-After 3, got %q", got)
+	}
+
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := sdk.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if len(started) != 1 {
+		t.Errorf("Expected the queued delivery to be processed, got %d pending signals", len(started))
+	}
+}
+
+func TestSDK_ShutdownClosesEverythingWhenQueueTimesOut(t *testing.T) {
+	sdk, err := New(Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+		AsyncQueueSize:  1,
+		AsyncWorkers:    1,
+		HealthInterval:  time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	sdk.On("issue.created", func(ctx context.Context, payload WebhookPayload, hctx *HandlerContext) (interface{}, error) {
+		close(started)
+		<-release
+		return nil, nil
+	})
+
+	body := `{"event":"issue.created","issue":{"id":1}}`
+	signature, timestamp := GenerateSignature("secret", body, nil)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set("X-Kiket-Signature", signature)
+	req.Header.Set("X-Kiket-Timestamp", timestamp)
+	sdk.ServeHTTP(httptest.NewRecorder(), req)
+	<-started
+
+	healthStopped := sdk.healthStopped
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := sdk.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected the queue timeout, got %v", err)
+	}
+
+	select {
+	case <-healthStopped:
+	case <-time.After(time.Second):
+		t.Error("Expected the health reporter to be stopped")
+	}
+	sdk.telemetry.mu.Lock()
+	closed := sdk.telemetry.closed
+	sdk.telemetry.mu.Unlock()
+	if !closed {
+		t.Error("Expected telemetry to be closed")
+	}
+}
@@ -0,0 +1,7 @@
+//go:build race
+
+package kiket
+
+// raceEnabled reports whether the tests run under the race detector, which
+// instruments allocations.
+const raceEnabled = true
@@ -0,0 +1,239 @@
+package kiket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rate limit response headers.
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+	rateLimitWindowHeader    = "X-RateLimit-Window"
+)
+
+const (
+	maxRateLimitRetries        = 3
+	maxRateLimitWait           = time.Minute
+	defaultRateLimitRetryAfter = time.Second
+)
+
+// RateLimitMode selects what the client does when the API answers 429.
+type RateLimitMode int
+
+const (
+	// RateLimitModeError returns a *RateLimitError (the default).
+	RateLimitModeError RateLimitMode = iota
+	// RateLimitModeWait sleeps until RetryAfter and retries, up to 3 times;
+	// waits longer than a minute return the error instead.
+	RateLimitModeWait
+	// RateLimitModeCallback asks the RateLimitCallback whether to wait and
+	// retry.
+	RateLimitModeCallback
+)
+
+// RateLimitCallback decides whether a throttled call waits err.RetryAfter
+// and is retried (true) or returns err (false). attempt counts from 1.
+type RateLimitCallback func(ctx context.Context, err *RateLimitError, attempt int) bool
+
+// RateLimitError is returned when the API throttles a call with 429. It
+// wraps the *APIError, so errors.As finds either.
+type RateLimitError struct {
+	// How long to wait, from Retry-After or else X-RateLimit-Reset
+	RetryAfter time.Duration
+	// Budget from the X-RateLimit headers, zero when absent
+	RateLimit RateLimitInfo
+	Err       *APIError
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("API rate limit reached, retry after %s", e.RetryAfter)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// WithRateLimitHandling sets what the client does when a call is throttled.
+// RateLimitModeCallback needs WithRateLimitCallback; without it calls
+// return the error.
+func WithRateLimitHandling(mode RateLimitMode) ClientOption {
+	return func(c *HTTPClient) {
+		c.rateLimit.mode = mode
+	}
+}
+
+// WithRateLimitCallback lets callback decide whether throttled calls wait
+// and retry, and selects RateLimitModeCallback.
+func WithRateLimitCallback(callback RateLimitCallback) ClientOption {
+	return func(c *HTTPClient) {
+		c.rateLimit.mode = RateLimitModeCallback
+		c.rateLimit.callback = callback
+	}
+}
+
+// RateLimitState is the rate limit budget last reported by the API.
+type RateLimitState struct {
+	RateLimitInfo
+	// When the window resets
+	ResetAt time.Time
+	// When the response reporting it was received (zero until one was)
+	UpdatedAt time.Time
+}
+
+// Known reports whether any response carried rate limit headers yet.
+func (s RateLimitState) Known() bool {
+	return !s.UpdatedAt.IsZero()
+}
+
+// RateLimitHandler is called when the remaining budget drops below the
+// threshold set with WithRateLimitThreshold.
+type RateLimitHandler func(RateLimitState)
+
+// rateLimitTracker records the budget reported by responses.
+type rateLimitTracker struct {
+	mu        sync.Mutex
+	state     RateLimitState
+	threshold int
+	handler   RateLimitHandler
+	low       bool // remaining is below threshold; handler already called
+
+	mode     RateLimitMode
+	callback RateLimitCallback
+}
+
+// WithRateLimitThreshold calls handler when an API response reports fewer
+// than threshold calls remaining in the window, so the extension can shed
+// non-critical work before requests are throttled. handler is called once
+// each time the budget drops below threshold, not on every call, and must
+// not block.
+func WithRateLimitThreshold(threshold int, handler RateLimitHandler) ClientOption {
+	return func(c *HTTPClient) {
+		c.rateLimit.threshold = threshold
+		c.rateLimit.handler = handler
+	}
+}
+
+// RateLimitState returns the rate limit budget reported by the most recent
+// response that carried X-RateLimit headers.
+func (c *HTTPClient) RateLimitState() RateLimitState {
+	c.rateLimit.mu.Lock()
+	defer c.rateLimit.mu.Unlock()
+	return c.rateLimit.state
+}
+
+// note records the response's rate limit headers, if any, and calls the
+// handler when the remaining budget crosses the threshold.
+func (t *rateLimitTracker) note(header http.Header) {
+	info, ok := parseRateLimit(header)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	state := RateLimitState{
+		RateLimitInfo: info,
+		ResetAt:       now.Add(time.Duration(info.ResetIn) * time.Second),
+		UpdatedAt:     now,
+	}
+
+	t.mu.Lock()
+	t.state = state
+	fire := false
+	if t.handler != nil {
+		below := info.Remaining < t.threshold
+		fire = below && !t.low
+		t.low = below
+	}
+	handler := t.handler
+	t.mu.Unlock()
+
+	if fire {
+		handler(state)
+	}
+}
+
+// parseRateLimit reads the X-RateLimit headers. It reports false when the
+// response has no remaining count.
+func parseRateLimit(header http.Header) (RateLimitInfo, bool) {
+	remaining, err := strconv.Atoi(strings.TrimSpace(header.Get(rateLimitRemainingHeader)))
+	if err != nil {
+		return RateLimitInfo{}, false
+	}
+	info := RateLimitInfo{Remaining: remaining}
+	info.Limit, _ = strconv.Atoi(strings.TrimSpace(header.Get(rateLimitLimitHeader)))
+	info.ResetIn, _ = strconv.Atoi(strings.TrimSpace(header.Get(rateLimitResetHeader)))
+	info.WindowSeconds, _ = strconv.Atoi(strings.TrimSpace(header.Get(rateLimitWindowHeader)))
+	return info, true
+}
+
+// newRateLimitError describes a 429 response.
+func newRateLimitError(header http.Header, body []byte) *RateLimitError {
+	info, _ := parseRateLimit(header)
+	return &RateLimitError{
+		RetryAfter: parseRetryAfter(header, time.Now()),
+		RateLimit:  info,
+		Err:        newAPIError(http.StatusTooManyRequests, body, header),
+	}
+}
+
+// parseRetryAfter reads Retry-After, in seconds or as an HTTP date, falling
+// back to X-RateLimit-Reset and then to one second.
+func parseRetryAfter(header http.Header, now time.Time) time.Duration {
+	if value := strings.TrimSpace(header.Get("Retry-After")); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			if wait := at.Sub(now); wait > 0 {
+				return wait
+			}
+			return 0
+		}
+	}
+	if seconds, err := strconv.Atoi(strings.TrimSpace(header.Get(rateLimitResetHeader))); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultRateLimitRetryAfter
+}
+
+// waitRateLimited reports whether a throttled call should be retried, after
+// sleeping until err.RetryAfter.
+func (t *rateLimitTracker) waitRateLimited(ctx context.Context, err *RateLimitError, attempt int) bool {
+	switch t.mode {
+	case RateLimitModeWait:
+		if attempt > maxRateLimitRetries || err.RetryAfter > maxRateLimitWait {
+			return false
+		}
+	case RateLimitModeCallback:
+		if t.callback == nil || !t.callback(ctx, err, attempt) {
+			return false
+		}
+	default:
+		return false
+	}
+
+	timer := time.NewTimer(err.RetryAfter)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// RateLimitState returns the rate limit budget last reported to the SDK's
+// client. It is zero when the client is not an *HTTPClient.
+func (s *SDK) RateLimitState() RateLimitState {
+	if client, ok := s.client.(*HTTPClient); ok {
+		return client.RateLimitState()
+	}
+	return RateLimitState{}
+}
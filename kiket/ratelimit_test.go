@@ -0,0 +1,105 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHTTPClient_RateLimitThreshold(t *testing.T) {
+	var remaining atomic.Int32
+	remaining.Store(12)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(int(remaining.Add(-1))))
+		w.Header().Set("X-RateLimit-Reset", "30")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var calls []RateLimitState
+	client := NewHTTPClient(WithBaseURL(server.URL), WithRateLimitThreshold(10, func(state RateLimitState) {
+		calls = append(calls, state)
+	}))
+	if client.RateLimitState().Known() {
+		t.Error("Expected no rate limit state before the first call")
+	}
+
+	for i := 0; i < 4; i++ {
+		if _, err := client.Get(context.Background(), "/api/v1/ext/issues", nil); err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+	}
+
+	state := client.RateLimitState()
+	if state.Limit != 100 || state.Remaining != 8 || state.ResetIn != 30 {
+		t.Errorf("Expected limit 100, remaining 8, reset 30, got %+v", state.RateLimitInfo)
+	}
+	if len(calls) != 1 || calls[0].Remaining != 9 {
+		t.Errorf("Expected one callback at 9 remaining, got %+v", calls)
+	}
+}
+
+func TestHTTPClient_RateLimitHandling(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1)%2 == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"rate limited"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	_, err := NewHTTPClient(WithBaseURL(server.URL)).Get(ctx, "/api/v1/ext/issues", nil)
+	var rateErr *RateLimitError
+	var apiErr *APIError
+	if !errors.As(err, &rateErr) || !errors.As(err, &apiErr) || apiErr.StatusCode != 429 {
+		t.Fatalf("Expected *RateLimitError wrapping a 429 *APIError, got %v", err)
+	}
+	if rateErr.RetryAfter != 0 {
+		t.Errorf("Expected RetryAfter 0, got %s", rateErr.RetryAfter)
+	}
+
+	requests.Store(0)
+	body, err := NewHTTPClient(WithBaseURL(server.URL), WithRateLimitHandling(RateLimitModeWait)).Get(ctx, "/api/v1/ext/issues", nil)
+	if err != nil || string(body) != `{"ok":true}` || requests.Load() != 2 {
+		t.Errorf("Expected a transparent retry, got %s, %v after %d requests", body, err, requests.Load())
+	}
+
+	requests.Store(0)
+	var attempts []int
+	_, err = NewHTTPClient(WithBaseURL(server.URL), WithRateLimitCallback(func(ctx context.Context, err *RateLimitError, attempt int) bool {
+		attempts = append(attempts, attempt)
+		return false
+	})).Get(ctx, "/api/v1/ext/issues", nil)
+	if !errors.As(err, &rateErr) || len(attempts) != 1 || requests.Load() != 1 {
+		t.Errorf("Expected the callback to decline the retry, got %v with attempts %v", err, attempts)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		header   http.Header
+		expected time.Duration
+	}{
+		{http.Header{"Retry-After": {"7"}}, 7 * time.Second},
+		{http.Header{"Retry-After": {now.Add(90 * time.Second).Format(http.TimeFormat)}}, 90 * time.Second},
+		{http.Header{"X-Ratelimit-Reset": {"12"}}, 12 * time.Second},
+		{http.Header{}, time.Second},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.header, now); got != tt.expected {
+			t.Errorf("Expected %s for %v, got %s", tt.expected, tt.header, got)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// RawPayload is a webhook payload whose top-level fields are kept as raw
+// JSON and decoded on demand, so handlers that need one or two typed values
+// skip building a map[string]interface{} of the whole delivery.
+//
+// Decoded values are cached per field and target type, so middleware,
+// filters, and the handler share one parse of each value. Cached maps and
+// slices are shared between callers and should be treated as read-only.
+type RawPayload struct {
+	// Event is the event name from the envelope.
+	Event string
+
+	body   json.RawMessage
+	fields map[string]json.RawMessage
+
+	mu      sync.Mutex
+	decoded map[decodeKey]reflect.Value
+	payload WebhookPayload
+}
+
+// decodeKey identifies a cached decode: a top-level field ("" for the whole
+// payload) and the type it was decoded into.
+type decodeKey struct {
+	field string
+	typ   reflect.Type
+}
+
+type payloadContextKey struct{}
+
+// PayloadFromContext returns the payload of the delivery being handled, or
+// nil outside HandleWebhook.
+func PayloadFromContext(ctx context.Context) *RawPayload {
+	p, _ := ctx.Value(payloadContextKey{}).(*RawPayload)
+	return p
+}
+
+func contextWithPayload(ctx context.Context, p *RawPayload) context.Context {
+	return context.WithValue(ctx, payloadContextKey{}, p)
+}
+
+// parseRawPayload splits body into its top-level fields and reads the event
+// name. Nested values are not decoded.
+func parseRawPayload(body []byte) (*RawPayload, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+
+	p := &RawPayload{body: body, fields: fields}
+	if raw, ok := fields["event"]; ok {
+		// A non-string event is treated like a missing one.
+		_ = json.Unmarshal(raw, &p.Event)
+	}
+	return p, nil
+}
+
+// Bytes returns the full payload as received.
+func (p *RawPayload) Bytes() json.RawMessage {
+	return p.body
+}
+
+// Field returns the raw JSON of a top-level field.
+func (p *RawPayload) Field(key string) (json.RawMessage, bool) {
+	raw, ok := p.fields[key]
+	return raw, ok
+}
+
+// Decode decodes the top-level field key into out, which must be a
+// pointer.
+func (p *RawPayload) Decode(key string, out interface{}) error {
+	raw, ok := p.fields[key]
+	if !ok {
+		return fmt.Errorf("payload has no %s", key)
+	}
+	if err := p.decodeCached(key, raw, out); err != nil {
+		return fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return nil
+}
+
+// DecodeAll decodes the whole payload into out, typically a pointer to a
+// struct with the fields the handler needs.
+func (p *RawPayload) DecodeAll(out interface{}) error {
+	if err := p.decodeCached("", p.body, out); err != nil {
+		return fmt.Errorf("failed to parse webhook payload: %w", err)
+	}
+	return nil
+}
+
+// Map returns the payload as a WebhookPayload. The map is decoded once and
+// shared with the handler registered through SDK.On.
+func (p *RawPayload) Map() (WebhookPayload, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.payload == nil {
+		var payload WebhookPayload
+		if err := json.Unmarshal(p.body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to parse webhook payload: %w", err)
+		}
+		p.payload = payload
+	}
+	return p.payload, nil
+}
+
+// decodeCached unmarshals raw into out, reusing an earlier decode of the
+// same field into the same type.
+func (p *RawPayload) decodeCached(field string, raw json.RawMessage, out interface{}) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return json.Unmarshal(raw, out) // reports the invalid target
+	}
+	key := decodeKey{field: field, typ: target.Type().Elem()}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if cached, ok := p.decoded[key]; ok {
+		target.Elem().Set(cached)
+		return nil
+	}
+
+	value := reflect.New(key.typ)
+	if err := json.Unmarshal(raw, value.Interface()); err != nil {
+		return err
+	}
+	if p.decoded == nil {
+		p.decoded = make(map[decodeKey]reflect.Value)
+	}
+	p.decoded[key] = value.Elem()
+	target.Elem().Set(value.Elem())
+	return nil
+}
+
+// Issue returns the issue of an issue.* webhook payload.
+func (p *RawPayload) Issue() (*Issue, error) {
+	var issue Issue
+	if err := p.Decode("issue", &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// Comment returns the comment of a comment.* webhook payload.
+func (p *RawPayload) Comment() (*Comment, error) {
+	var comment Comment
+	if err := p.Decode("comment", &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Project returns the project of a webhook payload.
+func (p *RawPayload) Project() (*Project, error) {
+	var project Project
+	if err := p.Decode("project", &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// secrets returns the string values of the payload's "secrets" object, or
+// nil if there is none.
+func (p *RawPayload) secrets() map[string]string {
+	raw, ok := p.fields["secrets"]
+	if !ok {
+		return nil
+	}
+
+	var secrets map[string]interface{}
+	if err := json.Unmarshal(raw, &secrets); err != nil || secrets == nil {
+		return nil
+	}
+	return stringValues(secrets)
+}
@@ -0,0 +1,269 @@
+package kiket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRelayAttempts = 5
+	defaultRelayBackoff  = 500 * time.Millisecond
+	maxRelayBackoff      = 30 * time.Second
+)
+
+// Relay forwards events to a third-party endpoint, signing each request
+// with the destination's secret in the same scheme Kiket uses
+// (X-Kiket-Signature and X-Kiket-Timestamp over "<timestamp>.<body>").
+// Failed attempts are retried with exponential backoff, and each delivery
+// is recorded in telemetry.
+type Relay struct {
+	url         string
+	secret      string
+	httpClient  *http.Client
+	attempts    int
+	backoff     time.Duration
+	headers     Headers
+	telemetry   *TelemetryReporter
+	clock       Clock
+	destination string
+}
+
+// RelayOption configures a Relay.
+type RelayOption func(*Relay)
+
+// WithRelayHTTPClient sets the HTTP client used for deliveries.
+func WithRelayHTTPClient(client *http.Client) RelayOption {
+	return func(r *Relay) {
+		if client != nil {
+			r.httpClient = client
+		}
+	}
+}
+
+// WithRelayRetries sets the maximum number of attempts and the initial
+// backoff, which doubles after each failure (defaults to 5 and 500ms).
+func WithRelayRetries(attempts int, backoff time.Duration) RelayOption {
+	return func(r *Relay) {
+		if attempts > 0 {
+			r.attempts = attempts
+		}
+		if backoff > 0 {
+			r.backoff = backoff
+		}
+	}
+}
+
+// WithRelayHeaders adds static headers to every delivery.
+func WithRelayHeaders(headers Headers) RelayOption {
+	return func(r *Relay) {
+		for k, v := range headers {
+			r.headers[k] = v
+		}
+	}
+}
+
+// WithRelayTelemetry records each delivery with reporter.
+func WithRelayTelemetry(reporter *TelemetryReporter) RelayOption {
+	return func(r *Relay) {
+		r.telemetry = reporter
+	}
+}
+
+// WithRelayClock signs timestamps against clock.
+func WithRelayClock(clock Clock) RelayOption {
+	return func(r *Relay) {
+		if clock != nil {
+			r.clock = clock
+		}
+	}
+}
+
+// NewRelay creates a relay to destinationURL signing with secret. An empty
+// secret sends unsigned requests.
+func NewRelay(destinationURL, secret string, opts ...RelayOption) *Relay {
+	r := &Relay{
+		url:        destinationURL,
+		secret:     secret,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		attempts:   defaultRelayAttempts,
+		backoff:    defaultRelayBackoff,
+		headers:    Headers{},
+		clock:      SystemClock,
+	}
+	if u, err := url.Parse(destinationURL); err == nil {
+		r.destination = u.Host
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// NewRelay creates a relay that records deliveries in the SDK's telemetry.
+func (s *SDK) NewRelay(destinationURL, secret string, opts ...RelayOption) *Relay {
+	base := []RelayOption{WithRelayTelemetry(s.telemetry), WithRelayClock(s.config.Clock)}
+	return NewRelay(destinationURL, secret, append(base, opts...)...)
+}
+
+// RelayResult describes a completed delivery.
+type RelayResult struct {
+	StatusCode int
+	Attempts   int
+	Body       []byte
+}
+
+// RelayError is returned when a delivery fails permanently, either on a
+// non-retryable response or after the last attempt.
+type RelayError struct {
+	Destination string
+	StatusCode  int // 0 when no response was received
+	Attempts    int
+	Err         error
+}
+
+func (e *RelayError) Error() string {
+	if e.StatusCode > 0 {
+		return fmt.Sprintf("relay to %s failed after %d attempt(s): status %d", e.Destination, e.Attempts, e.StatusCode)
+	}
+	return fmt.Sprintf("relay to %s failed after %d attempt(s): %v", e.Destination, e.Attempts, e.Err)
+}
+
+func (e *RelayError) Unwrap() error {
+	return e.Err
+}
+
+// Send delivers payload as JSON, labelled with event in the X-Kiket-Event
+// header. json.RawMessage and []byte payloads are sent as is, so a
+// received webhook body can be forwarded unchanged.
+//
+// Network errors, 429, and 5xx responses are retried, honouring
+// Retry-After; other 4xx responses fail immediately.
+func (r *Relay) Send(ctx context.Context, event string, payload interface{}) (*RelayResult, error) {
+	var body []byte
+	switch p := payload.(type) {
+	case json.RawMessage:
+		body = p
+	case []byte:
+		body = p
+	default:
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, fmt.Errorf("failed to marshal relay payload: %w", err)
+		}
+	}
+
+	start := time.Now()
+	result, err := r.deliver(ctx, event, body)
+
+	status := "ok"
+	extras := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"relayDestination": r.destination,
+			"relayAttempts":    result.Attempts,
+		},
+	}
+	if err != nil {
+		status = "error"
+		extras["errorMessage"] = err.Error()
+		extras["errorClass"] = fmt.Sprintf("%T", err)
+	}
+	if r.telemetry != nil {
+		_ = r.telemetry.Record(ctx, "relay:"+event, "", status, time.Since(start).Milliseconds(), extras)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *Relay) deliver(ctx context.Context, event string, body []byte) (*RelayResult, error) {
+	result := &RelayResult{}
+	backoff := r.backoff
+
+	for {
+		result.Attempts++
+		resp, respBody, err := r.attempt(ctx, event, body)
+
+		wait := backoff
+		if err == nil {
+			result.StatusCode = resp.StatusCode
+			result.Body = respBody
+			if resp.StatusCode < 400 {
+				return result, nil
+			}
+			if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+				return result, &RelayError{Destination: r.destination, StatusCode: resp.StatusCode, Attempts: result.Attempts}
+			}
+			if seconds, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && seconds > 0 {
+				wait = time.Duration(seconds) * time.Second
+			}
+		}
+
+		if result.Attempts >= r.attempts || ctx.Err() != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil && err == nil {
+				err = ctxErr
+			}
+			return result, &RelayError{Destination: r.destination, StatusCode: result.StatusCode, Attempts: result.Attempts, Err: err}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, &RelayError{Destination: r.destination, StatusCode: result.StatusCode, Attempts: result.Attempts, Err: ctx.Err()}
+		case <-timer.C:
+		}
+		if backoff *= 2; backoff > maxRelayBackoff {
+			backoff = maxRelayBackoff
+		}
+	}
+}
+
+// attempt performs one signed POST.
+func (r *Relay) attempt(ctx context.Context, event string, body []byte) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range r.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if event != "" {
+		req.Header.Set("X-Kiket-Event", event)
+	}
+	if r.secret != "" {
+		// Sign per attempt so retries carry a fresh timestamp.
+		ts := r.clock.Now().Unix()
+		signature, timestamp := GenerateSignature(r.secret, string(body), &ts)
+		req.Header.Set("X-Kiket-Signature", signature)
+		req.Header.Set("X-Kiket-Timestamp", timestamp)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, respBody, nil
+}
+
+// IsRelayError reports whether err is a permanent relay failure.
+func IsRelayError(err error) bool {
+	var relayErr *RelayError
+	return errors.As(err, &relayErr)
+}
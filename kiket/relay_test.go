@@ -0,0 +1,64 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRelay_SignsAndRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		headers := Headers{
+			"X-Kiket-Signature": r.Header.Get("X-Kiket-Signature"),
+			"X-Kiket-Timestamp": r.Header.Get("X-Kiket-Timestamp"),
+		}
+		if err := VerifySignature("dest-secret", body, headers); err != nil {
+			t.Errorf("Expected a valid destination signature, got %v", err)
+		}
+		if r.Header.Get("X-Kiket-Event") != "issue.created" || r.Header.Get("X-Team") != "ops" {
+			t.Errorf("Missing relay headers: %v", r.Header)
+		}
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	relay := NewRelay(server.URL, "dest-secret",
+		WithRelayRetries(3, time.Millisecond),
+		WithRelayHeaders(Headers{"X-Team": "ops"}),
+	)
+	result, err := relay.Send(context.Background(), "issue.created", json.RawMessage(`{"issue":{"id":1}}`))
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if result.Attempts != 3 || result.StatusCode != http.StatusOK || string(result.Body) != `{"ok":true}` {
+		t.Errorf("Unexpected result %+v", result)
+	}
+}
+
+func TestRelay_DoesNotRetryClientErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}))
+	defer server.Close()
+
+	_, err := NewRelay(server.URL, "", WithRelayRetries(5, time.Millisecond)).Send(context.Background(), "issue.created", map[string]int{"id": 1})
+	if !IsRelayError(err) {
+		t.Fatalf("Expected RelayError, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+}
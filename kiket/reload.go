@@ -0,0 +1,259 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultReloadInterval = 2 * time.Second
+	remoteSettingsTimeout = 10 * time.Second
+)
+
+// SettingsChangeFunc is called after a reload with the previous and the new
+// settings.
+type SettingsChangeFunc func(old, new Settings)
+
+// OnSettingsChange registers a callback invoked after each successful Reload
+// or SyncSettings.
+func (s *SDK) OnSettingsChange(fn SettingsChangeFunc) {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+
+	s.settingsCallbacks = append(s.settingsCallbacks, fn)
+}
+
+// Reload re-reads the manifest and recomputes the effective settings. If the
+// new manifest or settings fail validation, the previous state is kept and a
+// *ValidationError is returned.
+func (s *SDK) Reload() error {
+	s.reloadMu.Lock()
+	old, settings, err := s.reloadLocked()
+	s.reloadMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	s.notifySettingsChange(old, settings)
+	return nil
+}
+
+// reloadLocked loads the manifest and commits the new settings. The caller
+// holds reloadMu.
+func (s *SDK) reloadLocked() (old, settings Settings, err error) {
+	config := s.config
+	if s.manifestFile != "" {
+		// Re-read the file found at startup rather than searching again
+		config.ManifestPath = s.manifestFile
+	}
+
+	manifest, manifestFile, err := loadConfiguredManifest(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("failed to reload manifest: %w", ErrManifestNotFound)
+	}
+
+	errs, warnings := splitIssues(ValidateManifest(manifest))
+	if len(errs) > 0 {
+		return nil, nil, &ValidationError{Issues: errs}
+	}
+	for _, warning := range warnings {
+		s.config.Logger.Printf("kiket: manifest %s: %s", warning.Field, warning.Message)
+	}
+
+	settings = mergeSettings(resolveSettings(manifest, s.baseSettings, s.config.AutoEnvSecrets), s.remoteSettings)
+	if errs, _ := splitIssues(ValidateSettings(manifest, settings)); len(errs) > 0 {
+		return nil, nil, &ValidationError{Issues: errs}
+	}
+
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+	old = s.config.Settings
+	s.manifest = manifest
+	s.manifestFile = manifestFile
+	s.config.Settings = settings
+	return old, settings, nil
+}
+
+// SyncSettings fetches the values admins configured in the Kiket UI and
+// merges them into the effective settings. Precedence, lowest first: manifest
+// defaults (or Config.Settings), KIKET_SECRET_* overrides, remote values.
+// Remote values are kept across Reload. If the merged settings fail
+// validation, the previous settings are kept and a *ValidationError is
+// returned.
+func (s *SDK) SyncSettings(ctx context.Context) error {
+	remote, err := s.endpoints.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to sync settings: %w", err)
+	}
+
+	s.reloadMu.Lock()
+	old, settings, err := s.syncSettingsLocked(remote)
+	s.reloadMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	s.notifySettingsChange(old, settings)
+	return nil
+}
+
+// syncSettingsLocked merges remote into the settings and commits them. The
+// caller holds reloadMu.
+func (s *SDK) syncSettingsLocked(remote Settings) (old, settings Settings, err error) {
+	settings = mergeSettings(resolveSettings(s.manifest, s.baseSettings, s.config.AutoEnvSecrets), remote)
+	if s.manifest != nil {
+		if errs, _ := splitIssues(ValidateSettings(s.manifest, settings)); len(errs) > 0 {
+			return nil, nil, &ValidationError{Issues: errs}
+		}
+	}
+
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+	old = s.config.Settings
+	s.remoteSettings = remote
+	s.config.Settings = settings
+	return old, settings, nil
+}
+
+// notifySettingsChange runs the OnSettingsChange callbacks.
+func (s *SDK) notifySettingsChange(old, settings Settings) {
+	s.settingsMu.RLock()
+	callbacks := append([]SettingsChangeFunc(nil), s.settingsCallbacks...)
+	s.settingsMu.RUnlock()
+
+	for _, fn := range callbacks {
+		fn(old, settings)
+	}
+}
+
+// WatchReload reloads the manifest and settings when the process receives
+// SIGHUP or when the manifest file changes on disk, polling every interval
+// (defaults to 2s). It blocks until ctx is done. Reload errors are logged and
+// the previous settings stay in effect.
+func (s *SDK) WatchReload(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = defaultReloadInterval
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastMod := s.manifestModTime()
+
+	reload := func() {
+		if err := s.Reload(); err != nil {
+			s.config.Logger.Printf("kiket: reload failed: %v", err)
+		}
+		lastMod = s.manifestModTime()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-signals:
+			reload()
+		case <-ticker.C:
+			if mod := s.manifestModTime(); !mod.Equal(lastMod) {
+				reload()
+			}
+		}
+	}
+}
+
+// manifestModTime returns the modification time of the loaded manifest file,
+// or the zero time for embedded or missing manifests.
+func (s *SDK) manifestModTime() time.Time {
+	s.settingsMu.RLock()
+	path := s.manifestFile
+	s.settingsMu.RUnlock()
+
+	if path == "" {
+		return time.Time{}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// loadConfiguredManifest loads the manifest the configuration points at:
+// from ManifestFS, from ManifestPath, or by auto-discovery when neither an
+// extension ID nor a webhook secret is set. Auto-discovery finding nothing
+// returns a nil manifest. The returned path is empty for embedded manifests.
+func loadConfiguredManifest(config Config) (*Manifest, string, error) {
+	var opts []ManifestOption
+	if config.StrictManifest {
+		opts = append(opts, WithStrictManifest())
+	}
+
+	// A missing manifest is only an error when a path was given explicitly
+	if config.ManifestFS != nil {
+		manifest, err := LoadManifestFS(config.ManifestFS, config.ManifestPath, opts...)
+		if err != nil {
+			if config.ManifestPath == "" && errors.Is(err, ErrManifestNotFound) {
+				return nil, "", nil
+			}
+			return nil, "", fmt.Errorf("failed to load manifest: %w", err)
+		}
+		return manifest, "", nil
+	}
+
+	if config.ManifestPath != "" || (config.ExtensionID == "" && config.WebhookSecret == "") {
+		manifest, path, err := loadManifestFile(config.ManifestPath, opts...)
+		if err != nil {
+			if config.ManifestPath == "" && errors.Is(err, ErrManifestNotFound) {
+				return nil, "", nil
+			}
+			return nil, "", fmt.Errorf("failed to load manifest: %w", err)
+		}
+		return manifest, path, nil
+	}
+
+	return nil, "", nil
+}
+
+// resolveSettings computes the effective settings: the user-provided base
+// settings or the manifest defaults, with KIKET_SECRET_* overrides applied
+// when autoEnv is set.
+func resolveSettings(manifest *Manifest, base Settings, autoEnv bool) Settings {
+	settings := base
+	if settings == nil {
+		settings = SettingsDefaults(manifest)
+	}
+
+	// Apply environment variable overrides for secrets
+	if autoEnv {
+		settings = ApplySecretEnvOverrides(settings, SecretKeys(manifest))
+	}
+	return settings
+}
+
+// mergeSettings returns a copy of local with the remote values laid over it.
+func mergeSettings(local, remote Settings) Settings {
+	if len(remote) == 0 {
+		return local
+	}
+
+	merged := make(Settings, len(local)+len(remote))
+	for key, value := range local {
+		merged[key] = value
+	}
+	for key, value := range remote {
+		merged[key] = value
+	}
+	return merged
+}
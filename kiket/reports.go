@@ -0,0 +1,136 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	reportsPath          = "/api/v1/ext/reports/exports"
+	defaultReportPolling = 2 * time.Second
+)
+
+// ReportFormat is the file format of a report export.
+type ReportFormat string
+
+const (
+	ReportCSV  ReportFormat = "csv"
+	ReportJSON ReportFormat = "json"
+)
+
+// Report export statuses.
+const (
+	ReportPending   = "pending"
+	ReportRunning   = "running"
+	ReportCompleted = "completed"
+	ReportFailed    = "failed"
+)
+
+// reportsClient implements the ReportsClient interface.
+type reportsClient struct {
+	client Client
+}
+
+// NewReportsClient creates a new reports client.
+func NewReportsClient(client Client) ReportsClient {
+	return &reportsClient{client: client}
+}
+
+func parseReportExport(resp []byte) (*ReportExport, error) {
+	result, err := decodeData[ReportExport](resp)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *reportsClient) Export(ctx context.Context, req ReportRequest) (*ReportExport, error) {
+	if req.Report == "" {
+		return nil, errors.New("report name is required")
+	}
+	if req.Format == "" {
+		req.Format = ReportCSV
+	}
+
+	body := map[string]interface{}{
+		"report": req.Report,
+		"format": req.Format,
+	}
+	if req.ProjectID != nil {
+		body["project_id"] = req.ProjectID
+	}
+	if !req.From.IsZero() {
+		body["from"] = req.From.UTC().Format(time.RFC3339)
+	}
+	if !req.To.IsZero() {
+		body["to"] = req.To.UTC().Format(time.RFC3339)
+	}
+	if len(req.Filters) > 0 {
+		body["filters"] = req.Filters
+	}
+
+	resp, err := c.client.Post(ctx, reportsPath, map[string]interface{}{"export": body}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseReportExport(resp)
+}
+
+func (c *reportsClient) Get(ctx context.Context, exportID interface{}) (*ReportExport, error) {
+	if exportID == nil || exportID == "" {
+		return nil, errors.New("export ID is required")
+	}
+
+	resp, err := c.client.Get(ctx, joinPath(reportsPath, exportID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseReportExport(resp)
+}
+
+func (c *reportsClient) Wait(ctx context.Context, exportID interface{}, interval time.Duration) (*ReportExport, error) {
+	if interval <= 0 {
+		interval = defaultReportPolling
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		export, err := c.Get(ctx, exportID)
+		if err != nil {
+			return nil, err
+		}
+		switch export.Status {
+		case ReportCompleted:
+			return export, nil
+		case ReportFailed:
+			return export, fmt.Errorf("report export %v failed: %s", exportID, export.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return export, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *reportsClient) Download(ctx context.Context, exportID interface{}, w io.Writer) error {
+	if exportID == nil || exportID == "" {
+		return errors.New("export ID is required")
+	}
+
+	resp, err := c.client.Get(ctx, joinPath(reportsPath, exportID, "download"), nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(resp); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package kiket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReportsClient_ExportWaitAndDownload(t *testing.T) {
+	var export map[string]interface{}
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/ext/reports/exports":
+			data, _ := io.ReadAll(r.Body)
+			json.Unmarshal(data, &export)
+			w.Write([]byte(`{"data": {"id": "exp_1", "report": "worklogs", "format": "csv", "status": "pending"}}`))
+		case r.URL.Path == "/api/v1/ext/reports/exports/exp_1":
+			if polls.Add(1) < 3 {
+				w.Write([]byte(`{"data": {"id": "exp_1", "status": "running"}}`))
+				return
+			}
+			w.Write([]byte(`{"data": {"id": "exp_1", "status": "completed", "row_count": 2}}`))
+		case r.URL.Path == "/api/v1/ext/reports/exports/exp_1/download":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Write([]byte("issue,hours\nOPS-1,2\n"))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	reports := NewReportsClient(NewHTTPClient(WithBaseURL(server.URL)))
+	ctx := context.Background()
+
+	started, err := reports.Export(ctx, ReportRequest{
+		Report:    "worklogs",
+		ProjectID: 7,
+		From:      time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Filters:   map[string]interface{}{"billable": true},
+	})
+	if err != nil || started.Status != ReportPending {
+		t.Fatalf("Unexpected export %+v (%v)", started, err)
+	}
+	expected := map[string]interface{}{"export": map[string]interface{}{
+		"report":     "worklogs",
+		"format":     "csv",
+		"project_id": float64(7),
+		"from":       "2024-03-01T00:00:00Z",
+		"filters":    map[string]interface{}{"billable": true},
+	}}
+	if !reflect.DeepEqual(export, expected) {
+		t.Errorf("Expected body %v, got %v", expected, export)
+	}
+
+	done, err := reports.Wait(ctx, started.ID, time.Millisecond)
+	if err != nil || done.Status != ReportCompleted || done.RowCount != 2 || polls.Load() != 3 {
+		t.Fatalf("Expected completion after 3 polls, got %+v after %d (%v)", done, polls.Load(), err)
+	}
+
+	var file bytes.Buffer
+	if err := reports.Download(ctx, started.ID, &file); err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	if file.String() != "issue,hours\nOPS-1,2\n" {
+		t.Errorf("Unexpected file %q", file.String())
+	}
+}
+
+func TestReportsClient_WaitReportsFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"id": "exp_1", "status": "failed", "error": "range too large"}}`))
+	}))
+	defer server.Close()
+
+	reports := NewReportsClient(NewHTTPClient(WithBaseURL(server.URL)))
+	export, err := reports.Wait(context.Background(), "exp_1", time.Millisecond)
+	if err == nil || export == nil || export.Error != "range too large" {
+		t.Errorf("Expected the failed export and an error, got %+v (%v)", export, err)
+	}
+}
@@ -0,0 +1,72 @@
+package kiket
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// Response signature headers set by ServeHTTP with Config.SignResponses.
+const (
+	ResponseSignatureHeader = "X-Kiket-Response-Signature"
+	ResponseTimestampHeader = "X-Kiket-Response-Timestamp"
+)
+
+// responseSignatureDomain prefixes what responses sign, so a signed
+// response never verifies as a signed delivery of the same body.
+const responseSignatureDomain = "response."
+
+// SignResponse signs a webhook response body: the hex HMAC-SHA256 of
+// "response.<timestamp>.<body>" under the webhook secret. It returns the
+// values of the ResponseSignatureHeader and ResponseTimestampHeader headers.
+func SignResponse(secret string, body []byte, opts ...SignatureOption) (signature, timestamp string) {
+	timestamp = strconv.FormatInt(signatureClock(opts).Now().Unix(), 10)
+
+	s := acquireSigner(secret)
+	defer releaseSigner(s)
+
+	s.signDomain(responseSignatureDomain, timestamp, body)
+	return string(s.hexSum[:]), timestamp
+}
+
+// VerifyResponseSignature verifies a webhook response signed with
+// SignResponse, as the platform does. Use it to test extensions that set
+// Config.SignResponses.
+func VerifyResponseSignature(secret string, body []byte, headers Headers, opts ...SignatureOption) error {
+	if secret == "" {
+		return &AuthenticationError{Message: "webhook secret not configured"}
+	}
+	signature := headerValue(headers, ResponseSignatureHeader)
+	if signature == "" {
+		return &AuthenticationError{Message: "missing " + ResponseSignatureHeader + " header"}
+	}
+	timestamp := headerValue(headers, ResponseTimestampHeader)
+	if timestamp == "" {
+		return &AuthenticationError{Message: "missing " + ResponseTimestampHeader + " header"}
+	}
+
+	o := newSignatureOptions(opts)
+	return checkSignature(responseSignatureDomain, secret, body, signature, timestamp, ResponseTimestampHeader, o.clock, o.tolerance)
+}
+
+// signResponse sets the response signature headers on w for body, signed
+// with the secret of the delivery's workspace. It does nothing unless
+// Config.SignResponses is set.
+func (s *SDK) signResponse(ctx context.Context, w http.ResponseWriter, headers Headers, body []byte) {
+	if !s.config.SignResponses {
+		return
+	}
+
+	secret, err := s.webhookSecret(ctx, headers)
+	if err != nil || secret == "" {
+		return
+	}
+
+	clock := s.config.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	signature, timestamp := SignResponse(secret, body, WithSignatureClock(clock))
+	w.Header().Set(ResponseSignatureHeader, signature)
+	w.Header().Set(ResponseTimestampHeader, timestamp)
+}
@@ -0,0 +1,37 @@
+package kiket
+
+import (
+	"runtime"
+	"time"
+)
+
+// RuntimeStats is a snapshot of Go runtime health attached to heartbeat
+// telemetry.
+type RuntimeStats struct {
+	Goroutines     int     `json:"goroutines"`
+	HeapInUseBytes uint64  `json:"heap_inuse_bytes"`
+	HeapObjects    uint64  `json:"heap_objects"`
+	NumGC          uint32  `json:"num_gc"`
+	GCPauseTotalMs float64 `json:"gc_pause_total_ms"`
+	LastGCPauseMs  float64 `json:"last_gc_pause_ms"`
+}
+
+// CollectRuntimeStats reads the current runtime statistics.
+func CollectRuntimeStats() RuntimeStats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := RuntimeStats{
+		Goroutines:     runtime.NumGoroutine(),
+		HeapInUseBytes: mem.HeapInuse,
+		HeapObjects:    mem.HeapObjects,
+		NumGC:          mem.NumGC,
+		GCPauseTotalMs: float64(mem.PauseTotalNs) / float64(time.Millisecond),
+	}
+	if mem.NumGC > 0 {
+		last := mem.PauseNs[(mem.NumGC+255)%256]
+		stats.LastGCPauseMs = float64(last) / float64(time.Millisecond)
+	}
+
+	return stats
+}
@@ -0,0 +1,71 @@
+package kiket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEndpoints_ForProjectAndWorkspace(t *testing.T) {
+	var got *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	resolver := func(ctx context.Context, workspaceID string) (Credentials, error) {
+		return Credentials{ExtensionAPIKey: workspaceID + "-key"}, nil
+	}
+	client := NewHTTPClient(WithBaseURL(server.URL), WithAPIKey("static"), WithCredentialResolver(resolver))
+	endpoints := NewEndpoints(client, "com.example.ext", "1.0.0")
+
+	project := endpoints.ForWorkspace("acme").ForProject(42)
+	if _, err := project.Labels(nil).List(context.Background()); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if got.URL.Query().Get("project_id") != "42" || got.Header.Get(ProjectIDHeader) != "42" {
+		t.Errorf("Expected project 42, got %s %s", got.URL.RawQuery, got.Header.Get(ProjectIDHeader))
+	}
+	if got.Header.Get(WorkspaceIDHeader) != "acme" || got.Header.Get(apiKeyHeader) != "acme-key" {
+		t.Errorf("Expected acme workspace credentials, got %s %s", got.Header.Get(WorkspaceIDHeader), got.Header.Get(apiKeyHeader))
+	}
+
+	// The parent endpoints and client are unchanged.
+	if _, err := endpoints.Labels(7).List(context.Background()); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if got.Header.Get(ProjectIDHeader) != "" || got.Header.Get(apiKeyHeader) != "static" {
+		t.Errorf("Expected unscoped request, got %v", got.Header)
+	}
+
+	scoped := client.WithHeaders(Headers{"X-Trace": "abc"})
+	if _, err := scoped.Get(context.Background(), "/api/v1/ext/ping", &RequestOptions{Headers: Headers{"X-Other": "1"}}); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Header.Get("X-Trace") != "abc" || got.Header.Get("X-Other") != "1" {
+		t.Errorf("Expected merged headers, got %v", got.Header)
+	}
+}
+
+func TestEndpoints_ActAs(t *testing.T) {
+	var actAs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actAs = append(actAs, r.Header.Get(ActAsHeader))
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	endpoints := NewEndpoints(NewHTTPClient(WithBaseURL(server.URL)), "com.example.ext", "1.0.0")
+	ctx := context.Background()
+	endpoints.ActAs(17).Issues().Transition(ctx, 1, "approved")
+	endpoints.Issues().Transition(ctx, 1, "approved")
+
+	client := NewHTTPClient(WithBaseURL(server.URL), WithActAs("u-9"))
+	client.Get(ctx, "/api/v1/ext/ping", nil)
+
+	if len(actAs) != 3 || actAs[0] != "17" || actAs[1] != "" || actAs[2] != "u-9" {
+		t.Errorf("Unexpected %s headers %q", ActAsHeader, actAs)
+	}
+}
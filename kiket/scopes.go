@@ -0,0 +1,182 @@
+package kiket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const scopePreflightTimeout = 10 * time.Second
+
+// scopeRoute annotates the API paths under pattern with the scope calls to
+// them require. "{...}" segments match any value and "*" any method.
+type scopeRoute struct {
+	method  string
+	pattern []string
+	scope   string
+}
+
+// scopeRoutes annotates the typed clients' endpoints; the first match wins.
+// Extension-owned resources (secrets, KV, jobs, UI, logged events) and
+// permission checks need no scope.
+var scopeRoutes = compileScopeRoutes([][3]string{
+	{http.MethodGet, "/api/v1/ext/issues/{issue_id}/labels", "labels:read"},
+	{"*", "/api/v1/ext/issues/{issue_id}/labels", "labels:write"},
+	{http.MethodGet, "/api/v1/ext/issues", "issues:read"},
+	{"*", "/api/v1/ext/issues", "issues:write"},
+	{http.MethodGet, "/api/v1/ext/projects/{project_id}/labels", "labels:read"},
+	{"*", "/api/v1/ext/projects/{project_id}/labels", "labels:write"},
+	{http.MethodGet, "/api/v1/ext/projects", "projects:read"},
+	{http.MethodGet, "/api/v1/ext/labels", "labels:read"},
+	{"*", "/api/v1/ext/labels", "labels:write"},
+	{http.MethodGet, "/api/v1/ext/custom_data", "custom_data:read"},
+	{"*", "/api/v1/ext/custom_data", "custom_data:write"},
+	{http.MethodGet, "/api/v1/ext/worklogs", "worklogs:read"},
+	{"*", "/api/v1/ext/worklogs", "worklogs:write"},
+	{"*", "/api/v1/ext/filters", "filters:read"},
+	{"*", "/api/v1/ext/search", "search:read"},
+	{http.MethodGet, "/api/v1/ext/sla", "sla:read"},
+	{"*", "/api/v1/ext/sla", "sla:write"},
+	{"*", "/api/v1/ext/reports", "reports:read"},
+	{"*", "/api/v1/ext/email", "email:send"},
+	{"*", "/api/v1/ext/messages", "messages:write"},
+	{http.MethodGet, "/api/v1/ext/events", "events:read"},
+	{http.MethodGet, "/api/v1/ext/workspace", "workspace:read"},
+	{http.MethodGet, "/api/v1/audit", "audit:read"},
+})
+
+func compileScopeRoutes(defs [][3]string) []scopeRoute {
+	routes := make([]scopeRoute, len(defs))
+	for i, def := range defs {
+		routes[i] = scopeRoute{
+			method:  def[0],
+			pattern: strings.Split(strings.TrimPrefix(def[1], "/"), "/"),
+			scope:   def[2],
+		}
+	}
+	return routes
+}
+
+// RequiredScope returns the scope an API call needs, e.g. "issues:write"
+// for POST /api/v1/ext/issues, or "" when the call needs none.
+func RequiredScope(method, path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for _, route := range scopeRoutes {
+		if (route.method != "*" && route.method != method) || len(segments) < len(route.pattern) {
+			continue
+		}
+		matched := true
+		for i, part := range route.pattern {
+			if !strings.HasPrefix(part, "{") && part != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return route.scope
+		}
+	}
+	return ""
+}
+
+// KnownScopes returns every scope the typed clients can require, sorted.
+func KnownScopes() []string {
+	seen := map[string]bool{ImpersonateScope: true}
+	for _, route := range scopeRoutes {
+		seen[route.scope] = true
+	}
+	scopes := make([]string, 0, len(seen))
+	for scope := range seen {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// MissingScopeError is returned, once scopes were preflighted, for a call
+// the credential lacks the scope for. The call is not sent.
+type MissingScopeError struct {
+	Scope  string
+	Method string
+	Path   string
+}
+
+func (e *MissingScopeError) Error() string {
+	return fmt.Sprintf("missing scope: %s (required by %s %s)", e.Scope, e.Method, e.Path)
+}
+
+// grantedScopes is the set recorded by a scope preflight.
+type grantedScopes map[string]bool
+
+// checkScopes fails a call whose scope the preflight found missing. Calls
+// are not checked before a preflight, nor when a CredentialResolver
+// authenticates them for a workspace, since the preflight only saw the
+// default credential.
+func (c *HTTPClient) checkScopes(ctx context.Context, method, path string, opts *RequestOptions) error {
+	granted := c.granted.Load()
+	if granted == nil || (c.credentials != nil && WorkspaceFromContext(ctx) != "") {
+		return nil
+	}
+
+	required := []string{RequiredScope(method, path)}
+	if c.actAs != "" || (opts != nil && opts.Headers[ActAsHeader] != "") {
+		required = append(required, ImpersonateScope)
+	}
+	for _, scope := range required {
+		if scope != "" && !(*granted)[scope] {
+			return &MissingScopeError{Scope: scope, Method: method, Path: path}
+		}
+	}
+	return nil
+}
+
+// PreflightScopes asks the API which scopes the credential holds and
+// remembers them, so later calls needing a missing scope fail right away
+// with a *MissingScopeError instead of an intermittent 403. It returns a
+// *MissingScopesError when scopes the manifest declares are missing. Set
+// Config.ScopePreflight to run it from New.
+//
+// With a CredentialResolver, the scopes of the default credential are
+// checked; calls with a workspace on their context, including those from
+// handlers, are resolved per workspace and not checked.
+func (s *SDK) PreflightScopes(ctx context.Context) error {
+	s.settingsMu.RLock()
+	manifest := s.manifest
+	s.settingsMu.RUnlock()
+
+	scopes := KnownScopes()
+	if manifest != nil {
+		scopes = append(scopes, manifest.Scopes...)
+	}
+	check, err := s.endpoints.CheckPermissions(ctx, scopes)
+	if err != nil {
+		return fmt.Errorf("failed to check permissions: %w", err)
+	}
+
+	granted := make(grantedScopes, len(check.Granted))
+	for _, scope := range check.Granted {
+		granted[scope] = true
+	}
+	if client, ok := s.client.(*HTTPClient); ok {
+		client.granted.Store(&granted)
+	}
+
+	if manifest != nil {
+		var missing []string
+		for _, scope := range manifest.Scopes {
+			if !granted[scope] {
+				missing = append(missing, scope)
+			}
+		}
+		if len(missing) > 0 {
+			return &MissingScopesError{Missing: missing}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		method, path, scope string
+	}{
+		{http.MethodGet, "/api/v1/ext/issues/42", "issues:read"},
+		{http.MethodPost, "/api/v1/ext/issues/42/transitions/done", "issues:write"},
+		{http.MethodPost, "/api/v1/ext/issues/42/labels", "labels:write"},
+		{http.MethodGet, "/api/v1/ext/projects/7/activity?limit=10", "projects:read"},
+		{http.MethodPost, "/api/v1/ext/custom_data/crm/contacts", "custom_data:write"},
+		{http.MethodGet, "/api/v1/ext/secrets/token", ""},
+		{http.MethodPost, "/api/v1/ext/permissions/check", ""},
+	}
+	for _, tt := range tests {
+		if got := RequiredScope(tt.method, tt.path); got != tt.scope {
+			t.Errorf("RequiredScope(%s %s): expected %q, got %q", tt.method, tt.path, tt.scope, got)
+		}
+	}
+}
+
+func TestSDK_ScopePreflight(t *testing.T) {
+	var writes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/ext/permissions/check" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": PermissionCheck{Granted: []string{"custom_data:read"}},
+			})
+			return
+		}
+		if r.Method != http.MethodGet {
+			writes.Add(1)
+		}
+		w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	defer server.Close()
+
+	sdk, err := New(Config{
+		WebhookSecret:   "secret",
+		ExtensionAPIKey: "key",
+		BaseURL:         server.URL,
+		ScopePreflight:  true,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	records := sdk.Endpoints().CustomData(1)
+	if _, err := records.Get(context.Background(), "crm", "contacts", 1); err != nil {
+		t.Errorf("Expected the granted read to succeed, got %v", err)
+	}
+
+	_, err = records.Create(context.Background(), "crm", "contacts", map[string]interface{}{"name": "Ada"})
+	var scopeErr *MissingScopeError
+	if !errors.As(err, &scopeErr) || scopeErr.Scope != "custom_data:write" {
+		t.Fatalf("Expected missing custom_data:write, got %v", err)
+	}
+	if writes.Load() != 0 {
+		t.Errorf("Expected the call not to be sent, got %d writes", writes.Load())
+	}
+}
+
+func TestSDK_ScopePreflightSkipsResolvedWorkspaces(t *testing.T) {
+	var writes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/ext/permissions/check" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": PermissionCheck{Granted: []string{"custom_data:read"}},
+			})
+			return
+		}
+		if r.Method != http.MethodGet {
+			writes.Add(1)
+		}
+		w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	defer server.Close()
+
+	sdk, err := New(Config{
+		WebhookSecret:   "secret",
+		ExtensionAPIKey: "key",
+		BaseURL:         server.URL,
+		ScopePreflight:  true,
+		CredentialResolver: func(ctx context.Context, workspaceID string) (Credentials, error) {
+			return Credentials{ExtensionAPIKey: "key-" + workspaceID}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	records := sdk.Endpoints().CustomData(1)
+	record := map[string]interface{}{"name": "Ada"}
+	ctx := ContextWithWorkspace(context.Background(), "ws_2")
+	if _, err := records.Create(ctx, "crm", "contacts", record); err != nil {
+		t.Errorf("Expected the tenant call to be sent, got %v", err)
+	}
+	var scopeErr *MissingScopeError
+	if _, err := records.Create(context.Background(), "crm", "contacts", record); !errors.As(err, &scopeErr) {
+		t.Errorf("Expected the default credential's call to be checked, got %v", err)
+	}
+	if writes.Load() != 1 {
+		t.Errorf("Expected one write sent, got %d", writes.Load())
+	}
+}
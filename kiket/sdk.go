@@ -5,11 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
+const defaultShutdownTimeout = 5 * time.Second
+
 // SDK is the main entry point for the Kiket Extension SDK.
 type SDK struct {
 	config     Config
@@ -17,22 +23,59 @@ type SDK struct {
 	endpoints  *Endpoints
 	handlers   map[string]*HandlerMetadata
 	handlersMu sync.RWMutex
-	telemetry  *TelemetryReporter
-	manifest   *Manifest
+	// handlers registered for version ranges, in registration order
+	versionRanges []*HandlerMetadata
+	telemetry     *TelemetryReporter
+	queue         *webhookQueue // nil unless Config.AsyncQueueSize is set
+	outbox        *outboxRelay  // nil unless Config.OutboxStore is set
+
+	// handler outcomes and the reporter started by Config.HealthInterval
+	deliveryStats deliveryStats
+	healthDone    chan struct{}
+	healthStopped chan struct{}
+
+	// job.due handlers by job name (see OnJob)
+	jobHandlers map[string]WebhookHandler
+	// message.received handlers by topic (see OnMessage)
+	messageHandlers map[string]WebhookHandler
+
+	// reloadMu serializes Reload and SyncSettings from read to commit, so
+	// neither overwrites the other's result with stale state
+	reloadMu sync.Mutex
+	// manifest and config.Settings are swapped by Reload under settingsMu
+	settingsMu        sync.RWMutex
+	manifest          *Manifest
+	manifestFile      string
+	baseSettings      Settings
+	remoteSettings    Settings
+	settingsCallbacks []SettingsChangeFunc
 }
 
 // New creates a new SDK instance.
 func New(config Config) (*SDK, error) {
 	// Load manifest if not provided
-	var manifest *Manifest
-	if config.ManifestPath != "" || (config.ExtensionID == "" && config.WebhookSecret == "") {
-		var err error
-		manifest, err = LoadManifest(config.ManifestPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load manifest: %w", err)
+	manifest, manifestFile, err := loadConfiguredManifest(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Logger == nil {
+		config.Logger = log.Default()
+	}
+
+	// Validate manifest: fail on errors, log warnings
+	if manifest != nil {
+		errs, warnings := splitIssues(ValidateManifest(manifest))
+		if len(errs) > 0 {
+			return nil, &ValidationError{Issues: errs}
+		}
+		for _, warning := range warnings {
+			config.Logger.Printf("kiket: manifest %s: %s", warning.Field, warning.Message)
 		}
 	}
 
+	baseSettings := config.Settings
+
 	// Apply manifest defaults
 	if manifest != nil {
 		if config.ExtensionID == "" {
@@ -44,14 +87,10 @@ func New(config Config) (*SDK, error) {
 		if config.WebhookSecret == "" {
 			config.WebhookSecret = manifest.DeliverySecret
 		}
-		if config.Settings == nil {
-			config.Settings = SettingsDefaults(manifest)
-		}
 
-		// Apply environment variable overrides for secrets
-		if config.AutoEnvSecrets {
-			secretKeys := SecretKeys(manifest)
-			config.Settings = ApplySecretEnvOverrides(config.Settings, secretKeys)
+		config.Settings = resolveSettings(manifest, baseSettings, config.AutoEnvSecrets)
+		if errs, _ := splitIssues(ValidateSettings(manifest, config.Settings)); len(errs) > 0 {
+			return nil, &ValidationError{Issues: errs}
 		}
 	}
 
@@ -60,6 +99,14 @@ func New(config Config) (*SDK, error) {
 		config.BaseURL = defaultBaseURL
 	}
 
+	errs, warnings := splitIssues(validateConfig(config))
+	if len(errs) > 0 {
+		return nil, &ValidationError{Issues: errs}
+	}
+	for _, warning := range warnings {
+		config.Logger.Printf("kiket: config %s: %s", warning.Field, warning.Message)
+	}
+
 	// Create HTTP client
 	clientOpts := []ClientOption{
 		WithBaseURL(config.BaseURL),
@@ -69,11 +116,41 @@ func New(config Config) (*SDK, error) {
 	} else if config.WorkspaceToken != "" {
 		clientOpts = append(clientOpts, WithToken(config.WorkspaceToken))
 	}
+	var sdk *SDK
+	clientOpts = append(clientOpts, WithDeprecationHandler(func(d Deprecation) { sdk.deprecated(d) }))
+	if config.CredentialResolver != nil {
+		clientOpts = append(clientOpts, WithCredentialResolver(config.CredentialResolver))
+	}
+	if len(config.PayloadFormats) > 0 {
+		clientOpts = append(clientOpts, WithPayloadFormats(config.PayloadFormats...))
+	}
+	if config.AuditLog != nil {
+		clientOpts = append(clientOpts, WithAuditLog(config.AuditLog))
+	}
+	if config.OnRateLimitLow != nil {
+		clientOpts = append(clientOpts, WithRateLimitThreshold(config.RateLimitThreshold, config.OnRateLimitLow))
+	}
+	if config.OnRateLimited != nil {
+		clientOpts = append(clientOpts, WithRateLimitCallback(config.OnRateLimited))
+	} else if config.RateLimitHandling != RateLimitModeError {
+		clientOpts = append(clientOpts, WithRateLimitHandling(config.RateLimitHandling))
+	}
+	clientOpts = append(clientOpts, config.ClientOptions...)
 	httpClient := NewHTTPClient(clientOpts...)
 
 	// Create endpoints
 	endpoints := NewEndpoints(httpClient, config.ExtensionID, config.ExtensionVersion)
 
+	sdk = &SDK{
+		config:       config,
+		client:       httpClient,
+		endpoints:    endpoints,
+		handlers:     make(map[string]*HandlerMetadata),
+		manifest:     manifest,
+		manifestFile: manifestFile,
+		baseSettings: baseSettings,
+	}
+
 	// Create telemetry reporter
 	telemetryOpts := []TelemetryOption{
 		WithTelemetryExtension(config.ExtensionID, config.ExtensionVersion),
@@ -83,45 +160,135 @@ func New(config Config) (*SDK, error) {
 	}
 	if config.ExtensionAPIKey != "" {
 		telemetryOpts = append(telemetryOpts, WithTelemetryAPIKey(config.ExtensionAPIKey))
+	} else if config.WorkspaceToken != "" {
+		telemetryOpts = append(telemetryOpts, WithTelemetryToken(config.WorkspaceToken))
+	}
+	if config.Clock != nil {
+		telemetryOpts = append(telemetryOpts, WithTelemetryClock(config.Clock))
+	}
+	if config.HeartbeatInterval > 0 {
+		telemetryOpts = append(telemetryOpts, WithTelemetryHeartbeat(config.HeartbeatInterval, sdk.heartbeatInfo))
+	}
+	telemetryOpts = append(telemetryOpts, config.TelemetryOptions...)
+	sdk.telemetry = NewTelemetryReporter(config.TelemetryEnabled, telemetryOpts...)
+
+	if config.RemoteSettings {
+		ctx, cancel := context.WithTimeout(context.Background(), remoteSettingsTimeout)
+		defer cancel()
+		if err := sdk.SyncSettings(ctx); err != nil {
+			sdk.Close()
+			return nil, err
+		}
+		sdk.On(SettingsUpdatedEvent, sdk.handleSettingsUpdated)
+	}
+
+	if config.ScopePreflight {
+		ctx, cancel := context.WithTimeout(context.Background(), scopePreflightTimeout)
+		defer cancel()
+		if err := sdk.PreflightScopes(ctx); err != nil {
+			sdk.Close()
+			return nil, err
+		}
+	}
+
+	if config.OutboxStore != nil {
+		sdk.outbox = newOutboxRelay(config, httpClient)
+	}
+
+	if config.AsyncQueueSize > 0 {
+		sdk.queue = newWebhookQueue(config, sdk.processQueued)
+	}
+
+	if config.EventBatchSize > 0 {
+		endpoints.events = NewEventBatcher(endpoints,
+			WithEventBatchSize(config.EventBatchSize),
+			WithEventFlushInterval(config.EventFlushInterval),
+			WithEventErrorHandler(func(err error) {
+				config.Logger.Printf("kiket: %v", err)
+			}),
+		)
+	}
+
+	if config.HealthInterval > 0 {
+		sdk.healthDone = make(chan struct{})
+		sdk.healthStopped = make(chan struct{})
+		go sdk.healthLoop(config.HealthInterval, sdk.healthDone, sdk.healthStopped)
 	}
-	telemetry := NewTelemetryReporter(config.TelemetryEnabled, telemetryOpts...)
 
-	return &SDK{
-		config:    config,
-		client:    httpClient,
-		endpoints: endpoints,
-		handlers:  make(map[string]*HandlerMetadata),
-		telemetry: telemetry,
-		manifest:  manifest,
-	}, nil
+	return sdk, nil
 }
 
-// On registers a webhook handler for an event.
+// On registers a webhook handler for an event. Pass versions to handle
+// (default "v1"): each may be a version such as "v2" or a range such as
+// ">=v2" or ">=v2,<v4". A delivery goes to the handler registered for its
+// exact version, else to the first registered range containing it; the
+// handler reads the version from HandlerContext.EventVersion.
 func (s *SDK) On(event string, handler WebhookHandler, versions ...string) {
-	version := "v1"
-	if len(versions) > 0 {
-		version = versions[0]
+	for _, version := range handlerVersions(versions) {
+		s.register(&HandlerMetadata{
+			Event:   event,
+			Version: version,
+			Handler: handler,
+		})
 	}
+}
 
-	key := event + ":" + version
+// OnRaw registers a handler that receives the payload undecoded. Use it on
+// hot paths to decode only the fields the handler needs:
+//
+//	sdk.OnRaw("issue.updated", func(ctx context.Context, payload *kiket.RawPayload, hctx *kiket.HandlerContext) (interface{}, error) {
+//		issue, err := payload.Issue()
+//		...
+//	})
+func (s *SDK) OnRaw(event string, handler RawWebhookHandler, versions ...string) {
+	for _, version := range handlerVersions(versions) {
+		s.register(&HandlerMetadata{
+			Event:      event,
+			Version:    version,
+			RawHandler: handler,
+		})
+	}
+}
+
+// register adds or replaces the handler for meta's event and version.
+func (s *SDK) register(meta *HandlerMetadata) {
+	key := meta.Event + ":" + meta.Version
 
 	s.handlersMu.Lock()
-	s.handlers[key] = &HandlerMetadata{
-		Event:   event,
-		Version: version,
-		Handler: handler,
+	defer s.handlersMu.Unlock()
+
+	if isVersionRange(meta.Version) {
+		if previous, ok := s.handlers[key]; ok {
+			for i, h := range s.versionRanges {
+				if h == previous {
+					s.versionRanges = append(s.versionRanges[:i], s.versionRanges[i+1:]...)
+					break
+				}
+			}
+		}
+		s.versionRanges = append(s.versionRanges, meta)
 	}
-	s.handlersMu.Unlock()
+	s.handlers[key] = meta
 }
 
-// GetHandler returns the handler for an event and version.
+// GetHandler returns the handler for an event and version: the one
+// registered for exactly that version, else the first registered version
+// range containing it.
 func (s *SDK) GetHandler(event, version string) *HandlerMetadata {
 	key := event + ":" + version
 
 	s.handlersMu.RLock()
 	defer s.handlersMu.RUnlock()
 
-	return s.handlers[key]
+	if h, ok := s.handlers[key]; ok {
+		return h
+	}
+	for _, h := range s.versionRanges {
+		if h.Event == event && versionInRange(version, h.Version) {
+			return h
+		}
+	}
+	return nil
 }
 
 // EventNames returns all registered event names.
@@ -142,25 +309,118 @@ func (s *SDK) EventNames() []string {
 	return names
 }
 
-// HandleWebhook processes an incoming webhook request.
+// HandleWebhook processes an incoming webhook request. With
+// Config.ProcessingBudget set, the handler's context expires when the
+// budget does.
 func (s *SDK) HandleWebhook(ctx context.Context, body []byte, headers Headers) (interface{}, error) {
 	// Verify signature
-	if err := VerifySignature(s.config.WebhookSecret, body, headers); err != nil {
+	if err := s.verify(ctx, body, headers); err != nil {
+		return nil, err
+	}
+
+	if s.config.ProcessingBudget > 0 {
+		clock := s.config.Clock
+		if clock == nil {
+			clock = SystemClock
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = withProcessingBudget(ctx, s.config.ProcessingBudget, s.config.BudgetHeadroom, clock)
+		defer cancel()
+	}
+	return s.dispatch(ctx, body, headers)
+}
+
+// errorStatus maps a HandleWebhook error to the HTTP status answered for
+// the delivery.
+func (s *SDK) errorStatus(err error) int {
+	switch {
+	case IsAuthenticationError(err):
+		return http.StatusUnauthorized
+	case s.config.ProcessingBudget > 0 && isBudgetError(err):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// verify checks a delivery's signature against the SDK clock and the
+// configured tolerance, using the workspace's resolved webhook secret when a
+// CredentialResolver is configured.
+func (s *SDK) verify(ctx context.Context, body []byte, headers Headers) error {
+	secret, err := s.webhookSecret(ctx, headers)
+	if err != nil {
+		return err
+	}
+
+	clock := s.config.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	tolerance := s.config.SignatureTolerance
+	if tolerance <= 0 {
+		tolerance = DefaultSignatureTolerance
+	}
+	return verifySignature(secret, body, headers, clock, tolerance)
+}
+
+// webhookSecret returns the secret deliveries to the workspace in headers
+// are signed with.
+func (s *SDK) webhookSecret(ctx context.Context, headers Headers) (string, error) {
+	credentials, err := resolveCredentials(ctx, s.config.CredentialResolver, headerValue(headers, WorkspaceIDHeader))
+	if err != nil {
+		return "", err
+	}
+	if credentials.WebhookSecret != "" {
+		return credentials.WebhookSecret, nil
+	}
+	return s.config.WebhookSecret, nil
+}
+
+// processQueued handles an async delivery whose signature was verified
+// when it was accepted.
+func (s *SDK) processQueued(d queuedDelivery) {
+	if _, err := s.dispatch(context.Background(), d.body, d.headers); err != nil {
+		s.config.Logger.Printf("kiket: async delivery failed: %v", err)
+	}
+}
+
+// dispatch parses a verified delivery and runs its handler.
+func (s *SDK) dispatch(ctx context.Context, body []byte, headers Headers) (interface{}, error) {
+	// Transcode binary payload formats to JSON
+	if format := findFormat(s.config.PayloadFormats, headerValue(headers, "Content-Type")); format != nil {
+		var err error
+		if body, err = transcode(format, body); err != nil {
+			return nil, err
+		}
+	}
+
+	// Unwrap CloudEvents deliveries to the Kiket payload they carry
+	cloudEvent, data, err := parseCloudEvent(body, headers)
+	if err != nil {
 		return nil, err
 	}
 
-	// Parse payload
-	var payload WebhookPayload
-	if err := json.Unmarshal(body, &payload); err != nil {
+	// Parse the envelope; nested values are decoded on demand
+	raw, err := parseRawPayload(data)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse webhook payload: %w", err)
 	}
 
 	// Extract event info
-	event, _ := payload["event"].(string)
+	event := raw.Event
 	version := headers["X-Kiket-Event-Version"]
 	if version == "" {
 		version = headers["x-kiket-event-version"]
 	}
+	deliveryID := headerValue(headers, "X-Kiket-Delivery-Id")
+	if cloudEvent != nil {
+		ceEvent, ceVersion := cloudEvent.Event()
+		event, raw.Event = ceEvent, ceEvent
+		if version == "" {
+			version = ceVersion
+		}
+		deliveryID = cloudEvent.ID
+	}
 	if version == "" {
 		version = "v1"
 	}
@@ -172,26 +432,45 @@ func (s *SDK) HandleWebhook(ctx context.Context, body []byte, headers Headers) (
 	}
 
 	// Extract payload secrets for the secret helper
-	payloadSecrets := extractPayloadSecrets(payload)
+	payloadSecrets := raw.secrets()
 
 	// Build handler context
 	handlerCtx := &HandlerContext{
 		Event:            event,
 		EventVersion:     version,
+		DeliveryID:       deliveryID,
+		WorkspaceID:      headerValue(headers, WorkspaceIDHeader),
+		CloudEvent:       cloudEvent,
 		Headers:          headers,
 		Client:           s.client,
 		Endpoints:        s.endpoints,
-		Settings:         s.config.Settings,
+		Settings:         s.Settings(),
 		ExtensionID:      s.config.ExtensionID,
 		ExtensionVersion: s.config.ExtensionVersion,
 		Secrets:          s.endpoints.Secrets,
+		Metrics:          NewMetrics(),
+		Payload:          raw,
 		payloadSecrets:   payloadSecrets,
 	}
+	if budget, ok := ctx.Value(budgetContextKey{}).(processingBudget); ok {
+		handlerCtx.Deadline = budget.deadline
+	}
+	if s.outbox != nil {
+		handlerCtx.Outbox = &Outbox{event: event, deliveryID: deliveryID, workspaceID: handlerCtx.WorkspaceID}
+	}
 
-	// Execute handler with telemetry
+	// Execute handler with telemetry; API calls on ctx act for the workspace
+	ctx = contextWithHandler(contextWithPayload(ctx, raw), handlerCtx)
+	if handlerCtx.WorkspaceID != "" {
+		ctx = ContextWithWorkspace(ctx, handlerCtx.WorkspaceID)
+	}
 	start := time.Now()
-	result, err := handler.Handler(ctx, payload, handlerCtx)
+	result, err := runHandler(ctx, handler, raw, handlerCtx)
+	if err == nil && handlerCtx.Outbox.Len() > 0 {
+		err = s.outbox.commit(ctx, handlerCtx.Outbox)
+	}
 	duration := time.Since(start).Milliseconds()
+	s.deliveryStats.record(err, time.Now())
 
 	// Record telemetry
 	status := "ok"
@@ -200,6 +479,10 @@ func (s *SDK) HandleWebhook(ctx context.Context, body []byte, headers Headers) (
 		status = "error"
 		extras["errorMessage"] = err.Error()
 		extras["errorClass"] = fmt.Sprintf("%T", err)
+		s.reportError(ctx, err, raw, handlerCtx)
+	}
+	if metrics := handlerCtx.Metrics.Snapshot(); len(metrics) > 0 {
+		extras["metrics"] = metrics
 	}
 	_ = s.telemetry.Record(ctx, event, version, status, duration, extras)
 
@@ -228,23 +511,141 @@ func (s *SDK) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if len(s.config.PayloadFormats) > 0 {
+		// Advertise the accepted body formats to the sender.
+		w.Header().Set("Accept", acceptHeader(s.config.PayloadFormats))
+	}
+
+	if s.queue != nil {
+		s.enqueue(r.Context(), w, body, headers)
+		return
+	}
+
 	result, err := s.HandleWebhook(r.Context(), body, headers)
 	if err != nil {
-		if IsAuthenticationError(err) {
-			http.Error(w, err.Error(), http.StatusUnauthorized)
-			return
+		status := s.errorStatus(err)
+		if status != http.StatusUnauthorized {
+			s.signResponse(r.Context(), w, headers, []byte(err.Error()+"\n"))
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), status)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	respBuf := getBuffer()
+	defer putBuffer(respBuf)
 	if result != nil {
-		json.NewEncoder(w).Encode(result)
+		if err := json.NewEncoder(respBuf).Encode(result); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+			return
+		}
 	} else {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("{}"))
+		respBuf.WriteString("{}")
+	}
+
+	s.signResponse(r.Context(), w, headers, respBuf.Bytes())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(respBuf.Bytes())
+}
+
+// enqueue verifies an async delivery and queues it, answering 202, or 503
+// with Retry-After when the queue is full.
+func (s *SDK) enqueue(ctx context.Context, w http.ResponseWriter, body []byte, headers Headers) {
+	if err := s.verify(ctx, body, headers); err != nil {
+		status := http.StatusInternalServerError
+		if IsAuthenticationError(err) {
+			status = http.StatusUnauthorized
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	if err := s.queue.push(queuedDelivery{body: body, headers: headers}); err != nil {
+		w.Header().Set("Retry-After", s.queue.retryAfterSeconds())
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	accepted := []byte(`{"status":"accepted"}`)
+	s.signResponse(ctx, w, headers, accepted)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	w.Write(accepted)
+}
+
+// Validate checks the configuration against the registered handlers. Call
+// it after registering handlers and before serving webhooks.
+func (s *SDK) Validate() error {
+	issues := validateConfig(s.config)
+
+	s.handlersMu.RLock()
+	handlerCount := len(s.handlers)
+	s.handlersMu.RUnlock()
+
+	if handlerCount > 0 && s.config.WebhookSecret == "" && s.config.CredentialResolver == nil {
+		issues = append(issues, ValidationIssue{
+			Severity: SeverityError,
+			Field:    "WebhookSecret",
+			Message:  fmt.Sprintf("is empty but %d handler(s) are registered; deliveries would fail signature verification", handlerCount),
+		})
 	}
+
+	if errs, _ := splitIssues(issues); len(errs) > 0 {
+		return &ValidationError{Issues: errs}
+	}
+	return nil
+}
+
+// GenerateManifest writes an extension.yaml skeleton describing the events
+// registered via On, the settings of the loaded manifest, and the SDK
+// version. The delivery secret is never written.
+func (s *SDK) GenerateManifest(w io.Writer) error {
+	s.settingsMu.RLock()
+	manifest := s.manifest
+	s.settingsMu.RUnlock()
+
+	generated := Manifest{
+		ID:         s.config.ExtensionID,
+		Version:    s.config.ExtensionVersion,
+		SDK:        "go",
+		SDKVersion: SDKVersion,
+	}
+	if manifest != nil {
+		generated.Name = manifest.Name
+		generated.Description = manifest.Description
+		generated.Settings = manifest.Settings
+		generated.Scopes = manifest.Scopes
+		generated.Endpoints = manifest.Endpoints
+		generated.UI = manifest.UI
+	}
+
+	s.handlersMu.RLock()
+	versions := make(map[string][]string)
+	for _, h := range s.handlers {
+		versions[h.Event] = append(versions[h.Event], h.Version)
+	}
+	s.handlersMu.RUnlock()
+
+	events := make([]string, 0, len(versions))
+	for event := range versions {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+	for _, event := range events {
+		sort.Strings(versions[event])
+		generated.Events = append(generated.Events, ManifestEvent{Name: event, Versions: versions[event]})
+	}
+
+	if _, err := fmt.Fprintf(w, "# Generated by the Kiket Go SDK %s\n", SDKVersion); err != nil {
+		return err
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&generated); err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	return enc.Close()
 }
 
 // Client returns the underlying HTTP client.
@@ -257,31 +658,90 @@ func (s *SDK) Endpoints() *Endpoints {
 	return s.endpoints
 }
 
-// Config returns the SDK configuration.
+// Config returns the SDK configuration, including the current settings.
 func (s *SDK) Config() Config {
-	return s.config
+	config := s.config
+	config.Settings = s.Settings()
+	return config
+}
+
+// Settings returns the current extension settings.
+func (s *SDK) Settings() Settings {
+	s.settingsMu.RLock()
+	defer s.settingsMu.RUnlock()
+
+	return s.config.Settings
 }
 
-// Close closes the SDK and releases resources.
+// Close closes the SDK and releases resources, waiting up to
+// defaultShutdownTimeout for buffered telemetry to be delivered.
 func (s *SDK) Close() error {
-	return s.client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), defaultShutdownTimeout)
+	defer cancel()
+
+	return s.Shutdown(ctx)
 }
 
-// extractPayloadSecrets extracts the secrets map from a webhook payload.
-// Returns nil if no secrets are present.
-func extractPayloadSecrets(payload WebhookPayload) map[string]string {
-	secretsRaw, ok := payload["secrets"]
-	if !ok {
-		return nil
+// Shutdown drains the async webhook queue, flushes batched events, the
+// outbox, and buffered telemetry, and closes the SDK. Call it from a
+// SIGTERM handler so the records of the last deliveries are not lost.
+// Every component is closed even if an earlier one fails; the first error
+// is returned.
+func (s *SDK) Shutdown(ctx context.Context) error {
+	var firstErr error
+	if s.queue != nil {
+		firstErr = s.queue.close(ctx)
+	}
+	if s.healthDone != nil {
+		close(s.healthDone)
+		s.healthDone = nil
+		select {
+		case <-s.healthStopped:
+		case <-ctx.Done():
+			if firstErr == nil {
+				firstErr = ctx.Err()
+			}
+		}
+	}
+
+	if s.endpoints.events != nil {
+		if err := s.endpoints.events.Close(ctx); err != nil {
+			s.config.Logger.Printf("kiket: %v", err)
+		}
+	}
+	if s.outbox != nil {
+		if err := s.outbox.close(ctx); err != nil {
+			s.config.Logger.Printf("kiket: %v", err)
+		}
 	}
 
-	secretsMap, ok := secretsRaw.(map[string]interface{})
-	if !ok {
-		return nil
+	if err := s.telemetry.Close(ctx); err != nil && firstErr == nil {
+		firstErr = err
 	}
+	if err := s.client.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+// heartbeatInfo reports SDK liveness details for telemetry heartbeats.
+func (s *SDK) heartbeatInfo() HeartbeatInfo {
+	s.handlersMu.RLock()
+	defer s.handlersMu.RUnlock()
+
+	info := HeartbeatInfo{
+		HandlerCount: len(s.handlers),
+	}
+	if s.queue != nil {
+		info.QueueDepth = s.queue.depth()
+	}
+	return info
+}
 
+// stringValues returns the string-valued entries of m.
+func stringValues(m map[string]interface{}) map[string]string {
 	result := make(map[string]string)
-	for k, v := range secretsMap {
+	for k, v := range m {
 		if strVal, ok := v.(string); ok {
 			result[k] = strVal
 		}
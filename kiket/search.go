@@ -0,0 +1,146 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const searchPath = "/api/v1/ext/search"
+
+// SearchOperator is a comparison used in a search filter.
+type SearchOperator string
+
+const (
+	OpEq       SearchOperator = "eq"
+	OpNotEq    SearchOperator = "neq"
+	OpContains SearchOperator = "contains"
+	OpIn       SearchOperator = "in"
+	OpGt       SearchOperator = "gt"
+	OpGte      SearchOperator = "gte"
+	OpLt       SearchOperator = "lt"
+	OpLte      SearchOperator = "lte"
+	OpExists   SearchOperator = "exists"
+)
+
+var knownSearchOperators = map[SearchOperator]bool{
+	OpEq: true, OpNotEq: true, OpContains: true, OpIn: true,
+	OpGt: true, OpGte: true, OpLt: true, OpLte: true, OpExists: true,
+}
+
+// SearchFilter restricts results by a field value.
+type SearchFilter struct {
+	Field    string         `json:"field"`
+	Operator SearchOperator `json:"op"`
+	Value    interface{}    `json:"value,omitempty"`
+}
+
+// SearchQuery describes a platform search. Build it with NewSearchQuery:
+//
+//	q := kiket.NewSearchQuery("issue").
+//	    Text("login crash").
+//	    Where("state", kiket.OpEq, "open").
+//	    Where("priority", kiket.OpIn, []string{"high", "urgent"}).
+//	    Limit(10)
+type SearchQuery struct {
+	EntityTypes []string       `json:"entity_types,omitempty"`
+	Query       string         `json:"q,omitempty"`
+	Filters     []SearchFilter `json:"filters,omitempty"`
+	Fields      []string       `json:"fields,omitempty"`
+	Sort        string         `json:"sort,omitempty"`
+	PerPage     int            `json:"limit,omitempty"`
+	PageNumber  int            `json:"page,omitempty"`
+}
+
+// NewSearchQuery starts a query over the given entity types ("issue",
+// "comment", "project", ...). No types searches everything.
+func NewSearchQuery(entityTypes ...string) *SearchQuery {
+	return &SearchQuery{EntityTypes: entityTypes}
+}
+
+// Text sets the full-text search terms.
+func (q *SearchQuery) Text(text string) *SearchQuery {
+	q.Query = text
+	return q
+}
+
+// Where adds a field filter. All filters must match.
+func (q *SearchQuery) Where(field string, op SearchOperator, value interface{}) *SearchQuery {
+	q.Filters = append(q.Filters, SearchFilter{Field: field, Operator: op, Value: value})
+	return q
+}
+
+// Select limits the fields returned with each hit.
+func (q *SearchQuery) Select(fields ...string) *SearchQuery {
+	q.Fields = append(q.Fields, fields...)
+	return q
+}
+
+// OrderBy sorts results by a field; prefix with "-" for descending order.
+func (q *SearchQuery) OrderBy(field string) *SearchQuery {
+	q.Sort = field
+	return q
+}
+
+// Limit sets the page size.
+func (q *SearchQuery) Limit(n int) *SearchQuery {
+	q.PerPage = n
+	return q
+}
+
+// Page selects a result page, starting at 1.
+func (q *SearchQuery) Page(n int) *SearchQuery {
+	q.PageNumber = n
+	return q
+}
+
+// Validate checks the query for empty fields and unknown operators.
+func (q *SearchQuery) Validate() error {
+	if q.Query == "" && len(q.Filters) == 0 {
+		return errors.New("search query needs text or at least one filter")
+	}
+	for _, filter := range q.Filters {
+		if filter.Field == "" {
+			return errors.New("search filter field is required")
+		}
+		if !knownSearchOperators[filter.Operator] {
+			return fmt.Errorf("unknown search operator %q for field %s", filter.Operator, filter.Field)
+		}
+	}
+	if q.PerPage < 0 || q.PageNumber < 0 {
+		return errors.New("search limit and page must not be negative")
+	}
+	return nil
+}
+
+// searchClient implements the SearchClient interface.
+type searchClient struct {
+	client Client
+}
+
+// NewSearchClient creates a new search client.
+func NewSearchClient(client Client) SearchClient {
+	return &searchClient{client: client}
+}
+
+func (c *searchClient) Query(ctx context.Context, query *SearchQuery) (*SearchResult, error) {
+	if query == nil {
+		return nil, errors.New("search query is required")
+	}
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Post(ctx, searchPath, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SearchResult
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
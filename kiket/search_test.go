@@ -0,0 +1,79 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestSearchClient_PostsQueryAndParsesHits(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/ext/search" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+		w.Write([]byte(`{"data": [{"type": "issue", "id": 42, "title": "Login crash", "score": 0.9, "highlights": {"title": ["<em>Login</em> crash"]}}], "meta": {"total": 11, "page": 1, "next_page": 2}}`))
+	}))
+	defer server.Close()
+
+	search := NewSearchClient(NewHTTPClient(WithBaseURL(server.URL)))
+	query := NewSearchQuery("issue").
+		Text("login crash").
+		Where("state", OpEq, "open").
+		Where("priority", OpIn, []string{"high", "urgent"}).
+		Select("title").
+		OrderBy("-updated_at").
+		Limit(10).
+		Page(1)
+
+	result, err := search.Query(context.Background(), query)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(result.Hits) != 1 || result.Hits[0].Type != "issue" || result.Hits[0].Title != "Login crash" || result.Hits[0].Highlights["title"][0] != "<em>Login</em> crash" {
+		t.Errorf("Unexpected hits %+v", result.Hits)
+	}
+	if result.Meta.Total != 11 || result.Meta.NextPage != 2 {
+		t.Errorf("Expected total 11 and next page 2, got %+v", result.Meta)
+	}
+
+	expected := map[string]interface{}{
+		"entity_types": []interface{}{"issue"},
+		"q":            "login crash",
+		"filters": []interface{}{
+			map[string]interface{}{"field": "state", "op": "eq", "value": "open"},
+			map[string]interface{}{"field": "priority", "op": "in", "value": []interface{}{"high", "urgent"}},
+		},
+		"fields": []interface{}{"title"},
+		"sort":   "-updated_at",
+		"limit":  float64(10),
+		"page":   float64(1),
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("Expected body %v, got %v", expected, body)
+	}
+}
+
+func TestSearchQuery_Validate(t *testing.T) {
+	cases := map[string]*SearchQuery{
+		"empty":            NewSearchQuery("issue"),
+		"missing field":    NewSearchQuery().Where("", OpEq, "x"),
+		"unknown operator": NewSearchQuery().Where("state", "like", "x"),
+		"negative limit":   NewSearchQuery().Text("x").Limit(-1),
+	}
+	for name, query := range cases {
+		if err := query.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+
+	if _, err := NewSearchClient(NewHTTPClient()).Query(context.Background(), NewSearchQuery()); err == nil {
+		t.Error("Expected Query to reject an invalid query before sending it")
+	}
+}
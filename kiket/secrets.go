@@ -2,9 +2,7 @@ package kiket
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
 )
 
 const apiPrefix = "/api/v1"
@@ -13,6 +11,7 @@ const apiPrefix = "/api/v1"
 type secretManager struct {
 	client      Client
 	extensionID string
+	basePath    string
 }
 
 // NewSecretManager creates a new secret manager.
@@ -20,6 +19,7 @@ func NewSecretManager(client Client, extensionID string) SecretManager {
 	return &secretManager{
 		client:      client,
 		extensionID: extensionID,
+		basePath:    extensionPath(extensionID) + "/secrets",
 	}
 }
 
@@ -28,7 +28,7 @@ func (s *secretManager) Get(ctx context.Context, key string) (string, error) {
 		return "", errors.New("extension ID required for secret operations")
 	}
 
-	path := fmt.Sprintf("%s/extensions/%s/secrets/%s", apiPrefix, s.extensionID, key)
+	path := joinPath(s.basePath, key)
 	resp, err := s.client.Get(ctx, path, nil)
 	if err != nil {
 		var apiErr *APIError
@@ -38,14 +38,12 @@ func (s *secretManager) Get(ctx context.Context, key string) (string, error) {
 		return "", err
 	}
 
-	var result struct {
-		Value string `json:"value"`
-	}
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+	result, err := decodeEnvelope[string](resp, "value")
+	if err != nil {
+		return "", err
 	}
 
-	return result.Value, nil
+	return result, nil
 }
 
 func (s *secretManager) Set(ctx context.Context, key string, value string) error {
@@ -53,7 +51,7 @@ func (s *secretManager) Set(ctx context.Context, key string, value string) error
 		return errors.New("extension ID required for secret operations")
 	}
 
-	path := fmt.Sprintf("%s/extensions/%s/secrets/%s", apiPrefix, s.extensionID, key)
+	path := joinPath(s.basePath, key)
 	_, err := s.client.Post(ctx, path, map[string]string{"value": value}, nil)
 	return err
 }
@@ -63,7 +61,7 @@ func (s *secretManager) Delete(ctx context.Context, key string) error {
 		return errors.New("extension ID required for secret operations")
 	}
 
-	path := fmt.Sprintf("%s/extensions/%s/secrets/%s", apiPrefix, s.extensionID, key)
+	path := joinPath(s.basePath, key)
 	_, err := s.client.Delete(ctx, path, nil)
 	return err
 }
@@ -73,20 +71,18 @@ func (s *secretManager) List(ctx context.Context) ([]string, error) {
 		return nil, errors.New("extension ID required for secret operations")
 	}
 
-	path := fmt.Sprintf("%s/extensions/%s/secrets", apiPrefix, s.extensionID)
+	path := s.basePath
 	resp, err := s.client.Get(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var result struct {
-		Keys []string `json:"keys"`
-	}
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	result, err := decodeEnvelope[[]string](resp, "keys")
+	if err != nil {
+		return nil, err
 	}
 
-	return result.Keys, nil
+	return result, nil
 }
 
 func (s *secretManager) Rotate(ctx context.Context, key string, newValue string) error {
@@ -0,0 +1,180 @@
+package kiket
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetString returns a setting as a string. Non-string scalars are formatted;
+// missing or nil values return def.
+func (s Settings) GetString(key, def string) string {
+	v, ok := s[key]
+	if !ok || v == nil {
+		return def
+	}
+
+	switch val := v.(type) {
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case fmt.Stringer:
+		return val.String()
+	case bool, int, int64, uint64:
+		return fmt.Sprint(val)
+	}
+	return def
+}
+
+// GetInt returns a setting as an int. JSON numbers (float64) without a
+// fractional part and numeric strings are converted; anything else returns def.
+func (s Settings) GetInt(key string, def int) int {
+	v, ok := s[key]
+	if !ok || v == nil {
+		return def
+	}
+
+	if n, ok := toInt64(v); ok {
+		return int(n)
+	}
+	return def
+}
+
+// GetFloat returns a setting as a float64, converting numeric strings.
+func (s Settings) GetFloat(key string, def float64) float64 {
+	v, ok := s[key]
+	if !ok || v == nil {
+		return def
+	}
+
+	if f, ok := toFloat64(v); ok {
+		return f
+	}
+	return def
+}
+
+// GetBool returns a setting as a bool. Strings such as "true", "1", "yes",
+// and "on" (and their negatives) are converted, as are numbers (non-zero is
+// true).
+func (s Settings) GetBool(key string, def bool) bool {
+	v, ok := s[key]
+	if !ok || v == nil {
+		return def
+	}
+
+	switch val := v.(type) {
+	case bool:
+		return val
+	case string:
+		switch strings.ToLower(strings.TrimSpace(val)) {
+		case "true", "1", "yes", "y", "on":
+			return true
+		case "false", "0", "no", "n", "off":
+			return false
+		}
+		return def
+	}
+
+	if f, ok := toFloat64(v); ok {
+		return f != 0
+	}
+	return def
+}
+
+// GetDuration returns a setting as a time.Duration. Strings are parsed with
+// time.ParseDuration ("30s", "5m"); bare numbers are treated as seconds.
+func (s Settings) GetDuration(key string, def time.Duration) time.Duration {
+	v, ok := s[key]
+	if !ok || v == nil {
+		return def
+	}
+
+	switch val := v.(type) {
+	case time.Duration:
+		return val
+	case string:
+		if d, err := time.ParseDuration(strings.TrimSpace(val)); err == nil {
+			return d
+		}
+	}
+
+	if f, ok := toFloat64(v); ok {
+		return time.Duration(f * float64(time.Second))
+	}
+	return def
+}
+
+// GetStringSlice returns a setting as a []string. Lists have each element
+// formatted; strings are split on commas with whitespace trimmed.
+func (s Settings) GetStringSlice(key string, def []string) []string {
+	v, ok := s[key]
+	if !ok || v == nil {
+		return def
+	}
+
+	switch val := v.(type) {
+	case []string:
+		return val
+	case []interface{}:
+		result := make([]string, 0, len(val))
+		for _, item := range val {
+			result = append(result, fmt.Sprint(item))
+		}
+		return result
+	case string:
+		if strings.TrimSpace(val) == "" {
+			return []string{}
+		}
+		parts := strings.Split(val, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		return parts
+	}
+	return def
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case float32:
+		return float64(val), true
+	case int:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case int32:
+		return float64(val), true
+	case uint64:
+		return float64(val), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch val := v.(type) {
+	case int:
+		return int64(val), true
+	case int64:
+		return val, true
+	case int32:
+		return int64(val), true
+	case string:
+		if n, err := strconv.ParseInt(strings.TrimSpace(val), 10, 64); err == nil {
+			return n, true
+		}
+	}
+
+	f, ok := toFloat64(v)
+	if !ok || f != math.Trunc(f) {
+		return 0, false
+	}
+	return int64(f), true
+}
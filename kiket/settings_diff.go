@@ -0,0 +1,120 @@
+package kiket
+
+import (
+	"context"
+	"reflect"
+	"sort"
+)
+
+// SettingsUpdatedEvent is the webhook event delivered when an admin changes
+// the extension's settings in the Kiket UI. With Config.RemoteSettings, the
+// SDK handles it by calling SyncSettings.
+const SettingsUpdatedEvent = "extension.settings_updated"
+
+// SettingChange is one setting that differs between two settings maps.
+// Values of secret settings are replaced with "[redacted]".
+type SettingChange struct {
+	Key string
+	Old interface{} // nil for added settings
+	New interface{} // nil for removed settings
+}
+
+// SettingsDiff lists the settings a reload added, removed, or changed, each
+// sorted by key.
+type SettingsDiff struct {
+	Added   []SettingChange
+	Removed []SettingChange
+	Changed []SettingChange
+}
+
+// Empty reports whether no setting differs.
+func (d SettingsDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Has reports whether key was added, removed, or changed.
+func (d SettingsDiff) Has(key string) bool {
+	for _, changes := range [][]SettingChange{d.Added, d.Removed, d.Changed} {
+		for _, change := range changes {
+			if change.Key == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Keys returns the keys of all differing settings, sorted.
+func (d SettingsDiff) Keys() []string {
+	var keys []string
+	for _, changes := range [][]SettingChange{d.Added, d.Removed, d.Changed} {
+		for _, change := range changes {
+			keys = append(keys, change.Key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DiffSettings compares two settings maps. Values of secretKeys (see
+// SecretKeys) and of credential-like keys are redacted; a changed secret is
+// still reported as changed.
+func DiffSettings(old, new Settings, secretKeys []string) SettingsDiff {
+	mask := func(key string, value interface{}) interface{} {
+		if value != nil && sensitiveKey(key, secretKeys) {
+			return redacted
+		}
+		return value
+	}
+
+	var diff SettingsDiff
+	for key, newValue := range new {
+		oldValue, ok := old[key]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, SettingChange{Key: key, New: mask(key, newValue)})
+		case !reflect.DeepEqual(oldValue, newValue):
+			diff.Changed = append(diff.Changed, SettingChange{Key: key, Old: mask(key, oldValue), New: mask(key, newValue)})
+		}
+	}
+	for key, oldValue := range old {
+		if _, ok := new[key]; !ok {
+			diff.Removed = append(diff.Removed, SettingChange{Key: key, Old: mask(key, oldValue)})
+		}
+	}
+
+	for _, changes := range [][]SettingChange{diff.Added, diff.Removed, diff.Changed} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	}
+	return diff
+}
+
+// SettingsDiffFunc is called after a reload with the settings it changed.
+type SettingsDiffFunc func(diff SettingsDiff, settings Settings)
+
+// OnSettingsDiff registers a callback invoked after each Reload or
+// SyncSettings that changed at least one setting, with the difference and
+// the new settings. Use it to rebuild only what a change affects:
+//
+//	sdk.OnSettingsDiff(func(diff kiket.SettingsDiff, settings kiket.Settings) {
+//		if diff.Has("SLACK_BOT_TOKEN") {
+//			slack = newSlackClient(settings.GetString("SLACK_BOT_TOKEN", ""))
+//		}
+//	})
+func (s *SDK) OnSettingsDiff(fn SettingsDiffFunc) {
+	s.OnSettingsChange(func(old, new Settings) {
+		s.settingsMu.RLock()
+		secretKeys := SecretKeys(s.manifest)
+		s.settingsMu.RUnlock()
+
+		if diff := DiffSettings(old, new, secretKeys); !diff.Empty() {
+			fn(diff, new)
+		}
+	})
+}
+
+// handleSettingsUpdated refetches the remote settings when an admin changed
+// them.
+func (s *SDK) handleSettingsUpdated(ctx context.Context, payload WebhookPayload, handlerCtx *HandlerContext) (interface{}, error) {
+	return nil, s.SyncSettings(ctx)
+}
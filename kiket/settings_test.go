@@ -0,0 +1,317 @@
+package kiket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSettings_TypedAccessorsCoerceValues(t *testing.T) {
+	settings := Settings{
+		"name":      "triage",
+		"limit":     float64(25),
+		"limit_env": "40",
+		"ratio":     "0.5",
+		"enabled":   "yes",
+		"debug":     float64(0),
+		"timeout":   "45s",
+		"interval":  float64(2),
+		"labels":    []interface{}{"bug", "urgent"},
+		"teams":     "core, platform",
+		"fraction":  1.5,
+	}
+
+	if got := settings.GetString("name", ""); got != "triage" {
+		t.Errorf("GetString: expected triage, got %q", got)
+	}
+	if got := settings.GetString("limit", ""); got != "25" {
+		t.Errorf("GetString: expected 25, got %q", got)
+	}
+	if got := settings.GetInt("limit", 0); got != 25 {
+		t.Errorf("GetInt: expected 25, got %d", got)
+	}
+	if got := settings.GetInt("limit_env", 0); got != 40 {
+		t.Errorf("GetInt: expected 40 from string, got %d", got)
+	}
+	if got := settings.GetInt("fraction", 7); got != 7 {
+		t.Errorf("GetInt: expected default for fractional value, got %d", got)
+	}
+	if got := settings.GetFloat("ratio", 0); got != 0.5 {
+		t.Errorf("GetFloat: expected 0.5, got %v", got)
+	}
+	if !settings.GetBool("enabled", false) || settings.GetBool("debug", true) {
+		t.Error("GetBool: unexpected conversion")
+	}
+	if got := settings.GetDuration("timeout", 0); got != 45*time.Second {
+		t.Errorf("GetDuration: expected 45s, got %v", got)
+	}
+	if got := settings.GetDuration("interval", 0); got != 2*time.Second {
+		t.Errorf("GetDuration: expected 2s from number, got %v", got)
+	}
+	if got := settings.GetStringSlice("labels", nil); !reflect.DeepEqual(got, []string{"bug", "urgent"}) {
+		t.Errorf("GetStringSlice: expected [bug urgent], got %v", got)
+	}
+	if got := settings.GetStringSlice("teams", nil); !reflect.DeepEqual(got, []string{"core", "platform"}) {
+		t.Errorf("GetStringSlice: expected [core platform], got %v", got)
+	}
+	if got := settings.GetString("missing", "fallback"); got != "fallback" {
+		t.Errorf("Expected default for missing key, got %q", got)
+	}
+}
+
+func TestValidateSettings_EnforcesSchema(t *testing.T) {
+	min, max := 1.0, 100.0
+	manifest := &Manifest{
+		ID:      "com.example.ext",
+		Version: "1.0.0",
+		Settings: []ManifestSetting{
+			{Key: "project_key", Type: "string", Required: true},
+			{Key: "priority", Type: "string", Enum: []interface{}{"low", "medium", "high"}},
+			{Key: "batch_size", Type: "integer", Min: &min, Max: &max},
+			{Key: "timeout", Type: "duration"},
+			{Key: "api_token", Secret: true, Required: true},
+		},
+	}
+
+	issues := ValidateSettings(manifest, Settings{
+		"priority":   "urgent",
+		"batch_size": "500",
+		"timeout":    "soon",
+	})
+
+	fields := make(map[string]bool)
+	for _, issue := range issues {
+		fields[issue.Field] = true
+	}
+	for _, field := range []string{"settings.project_key", "settings.priority", "settings.batch_size", "settings.timeout"} {
+		if !fields[field] {
+			t.Errorf("Expected issue for %s, got %v", field, issues)
+		}
+	}
+	if fields["settings.api_token"] {
+		t.Error("Expected required secrets to be skipped")
+	}
+
+	valid := ValidateSettings(manifest, Settings{
+		"project_key": "OPS",
+		"priority":    "high",
+		"batch_size":  float64(50),
+		"timeout":     "30s",
+	})
+	if len(valid) != 0 {
+		t.Errorf("Expected no issues, got %v", valid)
+	}
+}
+
+func TestSDK_ReloadSwapsSettingsAndNotifies(t *testing.T) {
+	manifestYAML := `
+id: com.example.ext
+version: 1.0.0
+settings:
+  - key: batch_size
+    type: integer
+    default: 10
+`
+	path := writeTestManifest(t, "extension.yaml", manifestYAML)
+
+	sdk, err := New(Config{
+		ManifestPath:   path,
+		WorkspaceToken: "token",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer sdk.Close()
+
+	var oldSize, newSize int
+	sdk.OnSettingsChange(func(old, new Settings) {
+		oldSize = old.GetInt("batch_size", 0)
+		newSize = new.GetInt("batch_size", 0)
+	})
+
+	if err := os.WriteFile(path, []byte(strings.Replace(manifestYAML, "default: 10", "default: 25", 1)), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	if err := sdk.Reload(); err != nil {
+		t.Fatalf("Expected reload to succeed, got %v", err)
+	}
+	if oldSize != 10 || newSize != 25 {
+		t.Errorf("Expected callback with 10 -> 25, got %d -> %d", oldSize, newSize)
+	}
+	if got := sdk.Settings().GetInt("batch_size", 0); got != 25 {
+		t.Errorf("Expected reloaded batch_size 25, got %d", got)
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Replace(manifestYAML, "type: integer", "type: matrix", 1)), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	if _, ok := sdk.Reload().(*ValidationError); !ok {
+		t.Error("Expected *ValidationError for an invalid manifest")
+	}
+	if got := sdk.Settings().GetInt("batch_size", 0); got != 25 {
+		t.Errorf("Expected previous settings to be kept, got %d", got)
+	}
+}
+
+func TestSDK_SyncSettingsLaysRemoteValuesOverDefaults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/extensions/com.example.ext/settings" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"settings": {"batch_size": 50, "priority": null}}`))
+	}))
+	defer server.Close()
+
+	path := writeTestManifest(t, "extension.yaml", `
+id: com.example.ext
+version: 1.0.0
+settings:
+  - key: batch_size
+    type: integer
+    default: 10
+  - key: priority
+    default: low
+`)
+
+	sdk, err := New(Config{
+		ManifestPath:   path,
+		WorkspaceToken: "token",
+		BaseURL:        server.URL,
+		RemoteSettings: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer sdk.Close()
+
+	settings := sdk.Settings()
+	if got := settings.GetInt("batch_size", 0); got != 50 {
+		t.Errorf("Expected remote batch_size 50, got %d", got)
+	}
+	if got := settings.GetString("priority", ""); got != "low" {
+		t.Errorf("Expected manifest default for unset remote value, got %q", got)
+	}
+
+	if err := sdk.Reload(); err != nil {
+		t.Fatalf("Expected reload to succeed, got %v", err)
+	}
+	if got := sdk.Settings().GetInt("batch_size", 0); got != 50 {
+		t.Errorf("Expected remote values to survive reload, got %d", got)
+	}
+}
+
+func TestSDK_ConcurrentReloadAndSyncSettingsKeepLatestRemoteValues(t *testing.T) {
+	var syncs atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"settings": {"batch_size": %d}}`, syncs.Add(1))
+	}))
+	defer server.Close()
+
+	path := writeTestManifest(t, "extension.yaml", `
+id: com.example.ext
+version: 1.0.0
+settings:
+  - key: batch_size
+    type: integer
+    default: 10
+`)
+
+	sdk, err := New(Config{
+		ManifestPath:   path,
+		WorkspaceToken: "token",
+		BaseURL:        server.URL,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer sdk.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := sdk.Reload(); err != nil {
+				t.Errorf("Reload failed: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if err := sdk.SyncSettings(context.Background()); err != nil {
+				t.Errorf("SyncSettings failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := sdk.remoteSettings.GetInt("batch_size", 0)
+	if got := sdk.Settings().GetInt("batch_size", 0); got != want {
+		t.Errorf("Expected the last synced batch_size %d, got %d", want, got)
+	}
+}
+
+func TestSDK_SettingsUpdatedEventReportsDiff(t *testing.T) {
+	var syncs atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if syncs.Add(1) == 1 {
+			w.Write([]byte(`{"settings": {"api_token": "old-secret", "channel": "#ops", "batch_size": 10}}`))
+			return
+		}
+		w.Write([]byte(`{"settings": {"api_token": "new-secret", "channel": "#ops", "region": "eu"}}`))
+	}))
+	defer server.Close()
+
+	path := writeTestManifest(t, "extension.yaml", `
+id: com.example.ext
+version: 1.0.0
+settings:
+  - key: api_token
+    secret: true
+`)
+
+	sdk, err := New(Config{
+		ManifestPath:   path,
+		WebhookSecret:  "secret",
+		WorkspaceToken: "token",
+		BaseURL:        server.URL,
+		RemoteSettings: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer sdk.Close()
+
+	var diff SettingsDiff
+	sdk.OnSettingsDiff(func(d SettingsDiff, settings Settings) {
+		diff = d
+	})
+
+	body := `{"event":"extension.settings_updated"}`
+	signature, timestamp := GenerateSignature("secret", body, nil)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set("X-Kiket-Signature", signature)
+	req.Header.Set("X-Kiket-Timestamp", timestamp)
+	rec := httptest.NewRecorder()
+	sdk.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if got := strings.Join(diff.Keys(), ","); got != "api_token,batch_size,region" {
+		t.Errorf("Expected api_token, batch_size, and region to differ, got %s", got)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Old != "[redacted]" || diff.Changed[0].New != "[redacted]" {
+		t.Errorf("Expected the secret change to be redacted, got %+v", diff.Changed)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Old != float64(10) {
+		t.Errorf("Expected batch_size to be removed with its old value, got %+v", diff.Removed)
+	}
+}
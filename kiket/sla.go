@@ -8,7 +8,10 @@ import (
 	"strconv"
 )
 
-const slaPath = "/api/v1/ext/sla/events"
+const (
+	slaPath       = "/api/v1/ext/sla/events"
+	slaTimersPath = "/api/v1/ext/sla/timers"
+)
 
 // slaEventsClient implements the SLAEventsClient interface.
 type slaEventsClient struct {
@@ -63,3 +66,36 @@ func (c *slaEventsClient) List(ctx context.Context, opts *SLAEventsListOptions)
 
 	return &result, nil
 }
+
+func (c *slaEventsClient) Pause(ctx context.Context, issueID interface{}, reason string) (*SLATimer, error) {
+	if reason == "" {
+		return nil, errors.New("reason is required to pause an SLA timer")
+	}
+	return c.setTimer(ctx, "pause", issueID, map[string]interface{}{"issue_id": issueID, "reason": reason})
+}
+
+func (c *slaEventsClient) Resume(ctx context.Context, issueID interface{}) (*SLATimer, error) {
+	return c.setTimer(ctx, "resume", issueID, map[string]interface{}{"issue_id": issueID})
+}
+
+func (c *slaEventsClient) setTimer(ctx context.Context, action string, issueID interface{}, body map[string]interface{}) (*SLATimer, error) {
+	if c.projectID == nil || c.projectID == "" {
+		return nil, errors.New("projectID is required for SLA timers")
+	}
+	if issueID == nil || issueID == "" {
+		return nil, errors.New("issueID is required for SLA timers")
+	}
+
+	resp, err := c.client.Post(ctx, slaTimersPath+"/"+action, body, &RequestOptions{
+		Params: map[string]string{"project_id": fmt.Sprintf("%v", c.projectID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeData[SLATimer](resp)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
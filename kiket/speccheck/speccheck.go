@@ -0,0 +1,460 @@
+// Package speccheck compares the API endpoints and response fields the SDK
+// relies on against a Kiket instance's OpenAPI document, so version drift
+// on self-hosted instances is caught in CI rather than in production.
+//
+//	doc, err := speccheck.Fetch(ctx, "https://kiket.internal", nil)
+//	report := speccheck.Check(doc, speccheck.Endpoints)
+//	for _, finding := range report.Findings {
+//		fmt.Println(finding)
+//	}
+//
+// The same check runs as "kiket-ext speccheck".
+package speccheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+// DefaultSpecPath is where Kiket serves its OpenAPI document.
+const DefaultSpecPath = "/api/v1/openapi.json"
+
+// maxDepth bounds how deep nested response objects are compared.
+const maxDepth = 4
+
+// Endpoint is an API call the SDK makes and the type its response decodes
+// into.
+type Endpoint struct {
+	Method string
+	// Path template; "{...}" segments match any parameter name
+	Path string
+	// Top-level response key holding the resource ("" for the whole body)
+	Key string
+	// Type the resource decodes into (nil to check only the endpoint)
+	Model reflect.Type
+	// Whether the resource is an array of Model
+	List bool
+}
+
+// Endpoints lists the calls the SDK's typed clients make.
+var Endpoints = []Endpoint{
+	{Method: http.MethodGet, Path: "/api/v1/ext/workspace", Key: "data", Model: reflect.TypeOf(kiket.Workspace{})},
+	{Method: http.MethodGet, Path: "/api/v1/ext/rate_limit", Key: "rate_limit", Model: reflect.TypeOf(kiket.RateLimitInfo{})},
+	{Method: http.MethodGet, Path: "/api/v1/ext/issues", Key: "data", Model: reflect.TypeOf(kiket.Issue{}), List: true},
+	{Method: http.MethodPost, Path: "/api/v1/ext/issues", Key: "data", Model: reflect.TypeOf(kiket.Issue{})},
+	{Method: http.MethodGet, Path: "/api/v1/ext/issues/{id}", Key: "data", Model: reflect.TypeOf(kiket.Issue{})},
+	{Method: http.MethodPatch, Path: "/api/v1/ext/issues/{id}", Key: "data", Model: reflect.TypeOf(kiket.Issue{})},
+	{Method: http.MethodGet, Path: "/api/v1/ext/issues/{id}/links", Key: "data", Model: reflect.TypeOf(kiket.IssueLink{}), List: true},
+	{Method: http.MethodGet, Path: "/api/v1/ext/issues/{id}/workflow", Key: "data", Model: reflect.TypeOf(kiket.IssueWorkflow{})},
+	{Method: http.MethodGet, Path: "/api/v1/ext/issues/{id}/activity", Key: "data", Model: reflect.TypeOf(kiket.ActivityEntry{}), List: true},
+	{Method: http.MethodGet, Path: "/api/v1/ext/labels", Key: "data", Model: reflect.TypeOf(kiket.Label{}), List: true},
+	{Method: http.MethodGet, Path: "/api/v1/ext/filters", Key: "data", Model: reflect.TypeOf(kiket.SavedFilter{}), List: true},
+	{Method: http.MethodGet, Path: "/api/v1/ext/filters/{id}", Key: "data", Model: reflect.TypeOf(kiket.SavedFilter{})},
+	{Method: http.MethodGet, Path: "/api/v1/ext/worklogs", Key: "data", Model: reflect.TypeOf(kiket.Worklog{}), List: true},
+	{Method: http.MethodGet, Path: "/api/v1/ext/worklogs/{id}", Key: "data", Model: reflect.TypeOf(kiket.Worklog{})},
+	{Method: http.MethodGet, Path: "/api/v1/ext/sla/events", Key: "data", Model: reflect.TypeOf(kiket.SLAEventRecord{}), List: true},
+	{Method: http.MethodPost, Path: "/api/v1/ext/sla/timers/pause", Key: "data", Model: reflect.TypeOf(kiket.SLATimer{})},
+	{Method: http.MethodPost, Path: "/api/v1/ext/sla/timers/resume", Key: "data", Model: reflect.TypeOf(kiket.SLATimer{})},
+	{Method: http.MethodPost, Path: "/api/v1/ext/search", Model: reflect.TypeOf(kiket.SearchResult{})},
+	{Method: http.MethodPost, Path: "/api/v1/ext/reports/exports", Key: "data", Model: reflect.TypeOf(kiket.ReportExport{})},
+	{Method: http.MethodPost, Path: "/api/v1/ext/email", Key: "data", Model: reflect.TypeOf(kiket.EmailReceipt{})},
+	{Method: http.MethodPost, Path: "/api/v1/ext/messages", Key: "data", Model: reflect.TypeOf(kiket.Message{})},
+	{Method: http.MethodGet, Path: "/api/v1/ext/custom_data/{module}/{table}"},
+	{Method: http.MethodGet, Path: "/api/v1/ext/custom_data/{module}/{table}/permissions", Key: "data", Model: reflect.TypeOf(kiket.CustomDataPermissions{})},
+	{Method: http.MethodGet, Path: "/api/v1/ext/custom_data/{module}/{table}/{id}/permissions", Key: "data", Model: reflect.TypeOf(kiket.CustomDataPermissions{})},
+	{Method: http.MethodPost, Path: "/api/v1/ext/permissions/check", Key: "data", Model: reflect.TypeOf(kiket.PermissionCheck{})},
+	{Method: http.MethodGet, Path: "/api/v1/extensions/{id}/settings"},
+	{Method: http.MethodGet, Path: "/api/v1/extensions/{id}/secrets"},
+}
+
+// Finding is one difference between the SDK and the OpenAPI document.
+type Finding struct {
+	Method string
+	Path   string
+	// Dotted response field, empty when the endpoint itself is missing
+	Field   string
+	Problem string
+}
+
+func (f Finding) String() string {
+	if f.Field == "" {
+		return fmt.Sprintf("%s %s: %s", f.Method, f.Path, f.Problem)
+	}
+	return fmt.Sprintf("%s %s: %s: %s", f.Method, f.Path, f.Field, f.Problem)
+}
+
+// Report is the result of Check.
+type Report struct {
+	// Number of endpoints compared
+	Checked  int
+	Findings []Finding
+}
+
+// OK reports whether no drift was found.
+func (r *Report) OK() bool {
+	return len(r.Findings) == 0
+}
+
+// Document is the subset of an OpenAPI 3 document the check reads.
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Operation is an OpenAPI operation.
+type Operation struct {
+	Responses map[string]struct {
+		Content map[string]struct {
+			Schema *Schema `json:"schema"`
+		} `json:"content"`
+	} `json:"responses"`
+}
+
+// Schema is an OpenAPI schema object.
+type Schema struct {
+	Ref        string             `json:"$ref"`
+	Type       interface{}        `json:"type"` // a string, or a list in OpenAPI 3.1
+	Properties map[string]*Schema `json:"properties"`
+	Items      *Schema            `json:"items"`
+	AllOf      []*Schema          `json:"allOf"`
+	OneOf      []*Schema          `json:"oneOf"`
+	AnyOf      []*Schema          `json:"anyOf"`
+	Nullable   bool               `json:"nullable"`
+}
+
+// Load reads an OpenAPI document from a JSON file.
+func Load(path string) (*Document, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return decode(f)
+}
+
+// Fetch downloads the OpenAPI document served at baseURL + DefaultSpecPath,
+// or at baseURL itself when it already names a document (".json"). A nil
+// client uses a client with a 30s timeout.
+func Fetch(ctx context.Context, baseURL string, client *http.Client) (*Document, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	specURL := strings.TrimSuffix(baseURL, "/")
+	if !strings.HasSuffix(specURL, ".json") {
+		specURL += DefaultSpecPath
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, specURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OpenAPI document: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch OpenAPI document: %s returned %s", specURL, resp.Status)
+	}
+	return decode(resp.Body)
+}
+
+func decode(r io.Reader) (*Document, error) {
+	var doc Document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if doc.Paths == nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: no paths")
+	}
+	return &doc, nil
+}
+
+// Check reports the endpoints missing from doc and, for each Model, the
+// fields without omitempty that the response schema lacks and the fields
+// whose schema type the Go type cannot decode.
+func Check(doc *Document, endpoints []Endpoint) *Report {
+	report := &Report{}
+	for _, endpoint := range endpoints {
+		report.Checked++
+		add := func(field, problem string) {
+			report.Findings = append(report.Findings, Finding{Method: endpoint.Method, Path: endpoint.Path, Field: field, Problem: problem})
+		}
+
+		op, ok := doc.operation(endpoint.Method, endpoint.Path)
+		if !ok {
+			add("", "endpoint not found")
+			continue
+		}
+		if endpoint.Model == nil {
+			continue
+		}
+		schema := doc.responseSchema(op)
+		if schema == nil {
+			continue // undocumented response; nothing to compare
+		}
+
+		field := endpoint.Key
+		if endpoint.Key != "" {
+			schema = doc.property(schema, endpoint.Key)
+			if schema == nil {
+				add(endpoint.Key, "missing from response")
+				continue
+			}
+		}
+		if endpoint.List {
+			if !doc.hasType(schema, "array") {
+				add(field, fmt.Sprintf("is %s, SDK expects array", doc.typeName(schema)))
+				continue
+			}
+			schema = doc.resolve(schema.Items)
+			field += "[]"
+		}
+		doc.compare(schema, endpoint.Model, field, 0, add)
+	}
+	return report
+}
+
+// operation finds the operation for method and path, matching template
+// parameters by position rather than name.
+func (d *Document) operation(method, path string) (Operation, bool) {
+	want := strings.Split(strings.Trim(path, "/"), "/")
+	for template, ops := range d.Paths {
+		got := strings.Split(strings.Trim(template, "/"), "/")
+		if len(got) != len(want) {
+			continue
+		}
+		matched := true
+		for i := range got {
+			if isParam(got[i]) != isParam(want[i]) || (!isParam(got[i]) && got[i] != want[i]) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		if op, ok := ops[strings.ToLower(method)]; ok {
+			return op, true
+		}
+	}
+	return Operation{}, false
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// responseSchema returns the JSON schema of the first documented 2xx
+// response.
+func (d *Document) responseSchema(op Operation) *Schema {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		for mediaType, content := range op.Responses[code].Content {
+			if strings.Contains(mediaType, "json") && content.Schema != nil {
+				return d.resolve(content.Schema)
+			}
+		}
+	}
+	return nil
+}
+
+// resolve follows $ref and merges allOf into one schema.
+func (d *Document) resolve(schema *Schema) *Schema {
+	for depth := 0; schema != nil && schema.Ref != "" && depth < 32; depth++ {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		schema = d.Components.Schemas[name]
+	}
+	if schema == nil || len(schema.AllOf) == 0 {
+		return schema
+	}
+
+	merged := *schema
+	merged.AllOf = nil
+	merged.Properties = make(map[string]*Schema, len(schema.Properties))
+	for name, property := range schema.Properties {
+		merged.Properties[name] = property
+	}
+	for _, part := range schema.AllOf {
+		part = d.resolve(part)
+		if part == nil {
+			continue
+		}
+		if merged.Type == nil {
+			merged.Type = part.Type
+		}
+		for name, property := range part.Properties {
+			merged.Properties[name] = property
+		}
+	}
+	return &merged
+}
+
+// property returns the resolved schema of an object property, or nil.
+func (d *Document) property(schema *Schema, name string) *Schema {
+	if schema == nil {
+		return nil
+	}
+	property, ok := schema.Properties[name]
+	if !ok {
+		return nil
+	}
+	return d.resolve(property)
+}
+
+// types returns the JSON types a schema allows; empty means any.
+func (d *Document) types(schema *Schema) []string {
+	switch t := schema.Type.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var types []string
+		for _, v := range t {
+			if s, ok := v.(string); ok && s != "null" {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+
+	var types []string
+	for _, variant := range append(append([]*Schema(nil), schema.OneOf...), schema.AnyOf...) {
+		if variant = d.resolve(variant); variant == nil {
+			continue
+		}
+		variantTypes := d.types(variant)
+		if len(variantTypes) == 0 {
+			return nil
+		}
+		types = append(types, variantTypes...)
+	}
+	return types
+}
+
+func (d *Document) hasType(schema *Schema, want string) bool {
+	types := d.types(schema)
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == want {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Document) typeName(schema *Schema) string {
+	types := d.types(schema)
+	if len(types) == 0 {
+		return "any"
+	}
+	return strings.Join(types, " or ")
+}
+
+// compare checks that values matching schema decode into t.
+func (d *Document) compare(schema *Schema, t reflect.Type, field string, depth int, add func(field, problem string)) {
+	if schema == nil {
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	want := jsonType(t)
+	if want == "" {
+		return // interface{} or a custom decoder accepts anything
+	}
+	if !d.hasType(schema, want) && !(want == "number" && d.hasType(schema, "integer")) {
+		add(field, fmt.Sprintf("is %s, SDK expects %s", d.typeName(schema), want))
+		return
+	}
+	if depth >= maxDepth {
+		return
+	}
+
+	switch want {
+	case "array":
+		if t.Kind() == reflect.Slice && schema.Items != nil {
+			d.compare(d.resolve(schema.Items), t.Elem(), field+"[]", depth+1, add)
+		}
+	case "object":
+		if t.Kind() != reflect.Struct || len(schema.Properties) == 0 {
+			return
+		}
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			tag := sf.Tag.Get("json")
+			if !sf.IsExported() || tag == "" || tag == "-" {
+				continue
+			}
+			name := strings.Split(tag, ",")[0]
+			path := name
+			if field != "" {
+				path = field + "." + name
+			}
+			property := d.property(schema, name)
+			if property == nil {
+				if !strings.Contains(tag, ",omitempty") {
+					add(path, "missing from response")
+				}
+				continue
+			}
+			d.compare(property, sf.Type, path, depth+1, add)
+		}
+	}
+}
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage(nil))
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// jsonType returns the JSON type values of t decode from, or "" when any
+// value may.
+func jsonType(t reflect.Type) string {
+	switch {
+	case t == timeType:
+		return "string"
+	case t == rawMessageType, reflect.PtrTo(t).Implements(unmarshalerType):
+		return ""
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,81 @@
+package speccheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket"
+)
+
+const testSpec = `{
+  "openapi": "3.1.0",
+  "paths": {
+    "/api/v1/ext/labels": {
+      "get": {"responses": {"200": {"content": {"application/json": {"schema": {
+        "type": "object",
+        "properties": {"data": {"type": "array", "items": {"$ref": "#/components/schemas/Label"}}}
+      }}}}}}
+    },
+    "/api/v1/ext/worklogs/{worklog_id}": {
+      "get": {"responses": {"200": {"content": {"application/json": {"schema": {
+        "type": "object",
+        "properties": {"data": {"$ref": "#/components/schemas/Worklog"}}
+      }}}}}}
+    }
+  },
+  "components": {"schemas": {
+    "Label": {"type": "object", "properties": {
+      "id": {"type": "integer"}, "name": {"type": "string"}, "color": {"type": "string"}
+    }},
+    "Worklog": {"allOf": [
+      {"type": "object", "properties": {"id": {"type": "integer"}}},
+      {"properties": {"minutes": {"type": ["string", "null"]}}}
+    ]}
+  }}
+}`
+
+func TestCheck_ReportsDrift(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != DefaultSpecPath {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(testSpec))
+	}))
+	defer server.Close()
+
+	doc, err := Fetch(context.Background(), server.URL, nil)
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+
+	report := Check(doc, []Endpoint{
+		{Method: http.MethodGet, Path: "/api/v1/ext/labels", Key: "data", Model: reflect.TypeOf(kiket.Label{}), List: true},
+		{Method: http.MethodGet, Path: "/api/v1/ext/worklogs/{id}", Key: "data", Model: reflect.TypeOf(struct {
+			ID      int    `json:"id"`
+			Minutes int    `json:"minutes"`
+			Note    string `json:"note"`
+			Billed  bool   `json:"billed,omitempty"`
+		}{})},
+		{Method: http.MethodDelete, Path: "/api/v1/ext/labels"},
+	})
+
+	var got []string
+	for _, finding := range report.Findings {
+		got = append(got, finding.String())
+	}
+	expected := []string{
+		"GET /api/v1/ext/worklogs/{id}: data.minutes: is string, SDK expects integer",
+		"GET /api/v1/ext/worklogs/{id}: data.note: missing from response",
+		"DELETE /api/v1/ext/labels: endpoint not found",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+	if report.Checked != 3 {
+		t.Errorf("Expected 3 endpoints checked, got %d", report.Checked)
+	}
+}
@@ -1,22 +1,71 @@
 package kiket
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"time"
 )
 
+const (
+	defaultTelemetryFlushInterval = 5 * time.Second
+	defaultTelemetryBatchSize     = 100
+	maxTelemetrySpoolRecords      = 10000
+	defaultTelemetryMaxRetries    = 2
+	telemetryRetryBackoff         = 200 * time.Millisecond
+
+	heartbeatEvent = "sdk.heartbeat"
+	histogramEvent = "sdk.histogram"
+)
+
 // TelemetryReporter handles telemetry reporting.
+//
+// Records are buffered in memory and delivered by a background loop every
+// flush interval, or sooner once the batch size is reached. Call Flush or
+// Close before the process exits so buffered records are not lost.
 type TelemetryReporter struct {
 	endpoint         string
 	enabled          bool
 	extensionID      string
 	extensionVersion string
 	httpClient       *http.Client
+	apiKey           string
+	token            string
+	maxRetries       int
+	flushInterval    time.Duration
+	batchSize        int
+	sampling         *TelemetrySampling
+	random           func() float64
+	spoolPath        string
+	enrichers        []TelemetryEnricher
+	exporters        []TelemetryExporter
+	heartbeatPeriod  time.Duration
+	heartbeatProbe   HeartbeatProbe
+	runtimeStats     bool
+	startedAt        time.Time
+	clock            Clock
+	histogramPeriod  time.Duration
+	histograms       *durationAggregator
+
+	flushMu sync.Mutex
+
+	mu          sync.Mutex
+	windowStart time.Time
+	windowCount int
+	buffer      []TelemetryRecord
+	closed      bool
+	flushCh     chan struct{}
+	done        chan struct{}
+	stopped     chan struct{}
+	closeOnce   sync.Once
 }
 
 // TelemetryOption configures the telemetry reporter.
@@ -43,6 +92,191 @@ func WithTelemetryExtension(id, version string) TelemetryOption {
 	}
 }
 
+// WithTelemetryAPIKey authenticates telemetry delivery with the extension
+// API key.
+func WithTelemetryAPIKey(apiKey string) TelemetryOption {
+	return func(r *TelemetryReporter) {
+		r.apiKey = apiKey
+	}
+}
+
+// WithTelemetryToken authenticates telemetry delivery with a bearer token.
+func WithTelemetryToken(token string) TelemetryOption {
+	return func(r *TelemetryReporter) {
+		r.token = token
+	}
+}
+
+// WithTelemetryRetries sets how many times a delivery failing with a
+// network error, 429, or 5xx is retried before the record is spooled or
+// dropped.
+func WithTelemetryRetries(retries int) TelemetryOption {
+	return func(r *TelemetryReporter) {
+		if retries >= 0 {
+			r.maxRetries = retries
+		}
+	}
+}
+
+// WithTelemetryFlushInterval sets how often buffered records are delivered.
+func WithTelemetryFlushInterval(interval time.Duration) TelemetryOption {
+	return func(r *TelemetryReporter) {
+		if interval > 0 {
+			r.flushInterval = interval
+		}
+	}
+}
+
+// WithTelemetryBatchSize sets the number of buffered records that triggers
+// an early flush.
+func WithTelemetryBatchSize(size int) TelemetryOption {
+	return func(r *TelemetryReporter) {
+		if size > 0 {
+			r.batchSize = size
+		}
+	}
+}
+
+// TelemetrySampling controls how many records the reporter keeps.
+//
+// Rates are fractions between 0 and 1. A zero rate keeps every record of that
+// kind; use a negative rate to drop them all.
+type TelemetrySampling struct {
+	// Fraction of successful ("ok") records to keep
+	SuccessRate float64
+	// Fraction of failed ("error") records to keep
+	ErrorRate float64
+	// Maximum number of records kept per minute (0 = unlimited)
+	MaxPerMinute int
+}
+
+// rate returns the effective sample rate for a status.
+func (s *TelemetrySampling) rate(status string) float64 {
+	rate := s.SuccessRate
+	if status == "error" {
+		rate = s.ErrorRate
+	}
+	if rate == 0 || rate > 1 {
+		return 1
+	}
+	if rate < 0 {
+		return 0
+	}
+	return rate
+}
+
+// WithTelemetrySampling enables sampling and rate limiting of records, e.g.
+// keeping every error but only 10% of successes. Records carrying custom
+// handler metrics are always kept.
+func WithTelemetrySampling(sampling TelemetrySampling) TelemetryOption {
+	return func(r *TelemetryReporter) {
+		r.sampling = &sampling
+	}
+}
+
+// WithTelemetrySpool appends records that could not be delivered to a local
+// file (one JSON record per line) and retries them on the next flush. The
+// spool keeps at most the newest 10000 records.
+func WithTelemetrySpool(path string) TelemetryOption {
+	return func(r *TelemetryReporter) {
+		r.spoolPath = path
+	}
+}
+
+// TelemetryEnricher modifies a record before it is queued for delivery, e.g.
+// to attach deployment metadata or scrub sensitive values.
+type TelemetryEnricher func(record *TelemetryRecord)
+
+// WithTelemetryEnricher registers an enricher. Enrichers run in registration
+// order, after sampling, for every record that will be delivered.
+func WithTelemetryEnricher(enricher TelemetryEnricher) TelemetryOption {
+	return func(r *TelemetryReporter) {
+		if enricher != nil {
+			r.enrichers = append(r.enrichers, enricher)
+		}
+	}
+}
+
+// TelemetryExporter delivers batches of telemetry records to an additional
+// backend, such as an OpenTelemetry collector.
+type TelemetryExporter interface {
+	Export(ctx context.Context, records []TelemetryRecord) error
+}
+
+// WithTelemetryExporter registers an exporter that receives every flushed
+// batch in addition to the Kiket telemetry endpoint. Exports are best effort:
+// failed batches are not retried or spooled.
+func WithTelemetryExporter(exporter TelemetryExporter) TelemetryOption {
+	return func(r *TelemetryReporter) {
+		if exporter != nil {
+			r.exporters = append(r.exporters, exporter)
+		}
+	}
+}
+
+// HeartbeatInfo describes extension liveness reported with each heartbeat.
+type HeartbeatInfo struct {
+	// Number of registered handlers
+	HandlerCount int `json:"handler_count"`
+	// Deliveries accepted but not yet processed
+	QueueDepth int `json:"queue_depth"`
+	// Telemetry records waiting to be delivered
+	TelemetryBacklog int `json:"telemetry_backlog"`
+	// Seconds since the reporter was created
+	UptimeSeconds float64 `json:"uptime_seconds"`
+}
+
+// HeartbeatProbe supplies the application side of a heartbeat. The reporter
+// fills in TelemetryBacklog and UptimeSeconds.
+type HeartbeatProbe func() HeartbeatInfo
+
+// WithTelemetryHeartbeat emits a heartbeat record every interval so the
+// platform can tell a running extension that receives no events from one
+// that is down. probe may be nil.
+func WithTelemetryHeartbeat(interval time.Duration, probe HeartbeatProbe) TelemetryOption {
+	return func(r *TelemetryReporter) {
+		r.heartbeatPeriod = interval
+		r.heartbeatProbe = probe
+	}
+}
+
+// WithTelemetryRuntimeStats attaches goroutine count, heap usage, and GC
+// pause statistics to heartbeat records, emitting heartbeats every interval
+// unless WithTelemetryHeartbeat configured a shorter one.
+func WithTelemetryRuntimeStats(interval time.Duration) TelemetryOption {
+	return func(r *TelemetryReporter) {
+		r.runtimeStats = interval > 0
+		if r.heartbeatPeriod <= 0 || (interval > 0 && interval < r.heartbeatPeriod) {
+			r.heartbeatPeriod = interval
+		}
+	}
+}
+
+// WithTelemetryHistograms aggregates handler durations per event and emits
+// p50/p95/p99 histogram records every interval, in addition to the
+// per-invocation records. Histograms observe every invocation, including
+// ones dropped by sampling.
+func WithTelemetryHistograms(interval time.Duration) TelemetryOption {
+	return func(r *TelemetryReporter) {
+		r.histogramPeriod = interval
+		if interval > 0 {
+			r.histograms = newDurationAggregator()
+		} else {
+			r.histograms = nil
+		}
+	}
+}
+
+// WithTelemetryClock timestamps records, uptime, and histogram windows with
+// clock instead of the system time.
+func WithTelemetryClock(clock Clock) TelemetryOption {
+	return func(r *TelemetryReporter) {
+		if clock != nil {
+			r.clock = clock
+		}
+	}
+}
+
 // NewTelemetryReporter creates a new telemetry reporter.
 func NewTelemetryReporter(enabled bool, opts ...TelemetryOption) *TelemetryReporter {
 	// Check opt-out environment variable
@@ -56,11 +290,29 @@ func NewTelemetryReporter(enabled bool, opts ...TelemetryOption) *TelemetryRepor
 		httpClient: &http.Client{
 			Timeout: 5 * time.Second,
 		},
+		maxRetries:    defaultTelemetryMaxRetries,
+		flushInterval: defaultTelemetryFlushInterval,
+		batchSize:     defaultTelemetryBatchSize,
+		random:        rand.Float64,
+		clock:         SystemClock,
+		flushCh:       make(chan struct{}, 1),
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
 	}
 
 	for _, opt := range opts {
 		opt(r)
 	}
+	r.startedAt = r.clock.Now()
+	if r.histograms != nil {
+		r.histograms.since = r.startedAt
+	}
+
+	if r.enabled {
+		go r.loop()
+	} else {
+		close(r.stopped)
+	}
 
 	return r
 }
@@ -78,7 +330,7 @@ func (r *TelemetryReporter) Record(ctx context.Context, event, version, status s
 		DurationMs:       durationMs,
 		ExtensionID:      r.extensionID,
 		ExtensionVersion: r.extensionVersion,
-		Timestamp:        time.Now().UTC(),
+		Timestamp:        r.clock.Now().UTC(),
 	}
 
 	if extras != nil {
@@ -91,12 +343,310 @@ func (r *TelemetryReporter) Record(ctx context.Context, event, version, status s
 		if meta, ok := extras["metadata"].(map[string]interface{}); ok {
 			record.Metadata = meta
 		}
+		if metrics, ok := extras["metrics"].([]Metric); ok {
+			record.Metrics = metrics
+		}
+	}
+
+	if r.endpoint == "" && len(r.exporters) == 0 {
+		return nil
+	}
+
+	if r.histograms != nil {
+		r.histograms.observe(event, version, status, durationMs)
+	}
+
+	// Records carrying custom metrics are exempt from sampling and the cap,
+	// since dropping them would lose the metrics
+	exempt := len(record.Metrics) > 0
+	if r.sampling != nil && !exempt {
+		rate := r.sampling.rate(status)
+		if rate < 1 && r.random() >= rate {
+			return nil
+		}
+		if rate < 1 {
+			record.SampleRate = rate
+		}
+	}
+
+	r.enqueue(record, !exempt)
+	return nil
+}
+
+// enqueue enriches and buffers a record. Periodic records bypass the
+// per-minute cap by passing limited=false.
+func (r *TelemetryReporter) enqueue(record TelemetryRecord, limited bool) {
+	for _, enrich := range r.enrichers {
+		enrich(&record)
+	}
+
+	r.mu.Lock()
+	if r.closed || (limited && !r.allowLocked(record.Timestamp)) {
+		r.mu.Unlock()
+		return
+	}
+	r.buffer = append(r.buffer, record)
+	full := len(r.buffer) >= r.batchSize
+	r.mu.Unlock()
+
+	if full {
+		select {
+		case r.flushCh <- struct{}{}:
+		default:
+		}
 	}
+}
+
+// Flush delivers all buffered records. Records that could not be attempted
+// before ctx is done are kept for the next flush. With a spool configured,
+// spooled records are retried first and undeliverable ones are written back.
+func (r *TelemetryReporter) Flush(ctx context.Context) error {
+	r.flushMu.Lock()
+	defer r.flushMu.Unlock()
 
+	r.mu.Lock()
+	pending := r.buffer
+	r.buffer = nil
+	r.mu.Unlock()
+
+	if len(pending) > 0 {
+		for _, exporter := range r.exporters {
+			_ = exporter.Export(ctx, pending)
+		}
+	}
 	if r.endpoint == "" {
 		return nil
 	}
 
+	if r.spoolPath != "" {
+		spooled, err := r.readSpool()
+		if err != nil {
+			return err
+		}
+		pending = append(spooled, pending...)
+	}
+
+	var failed []TelemetryRecord
+	var ctxErr error
+	for i, record := range pending {
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			if r.spoolPath == "" {
+				r.requeue(pending[i:])
+				return ctxErr
+			}
+			failed = append(failed, pending[i:]...)
+			break
+		}
+		if err := r.send(ctx, record); err != nil && isRetryableAPIError(err) {
+			failed = append(failed, record)
+		}
+	}
+
+	if r.spoolPath != "" {
+		if err := r.writeSpool(failed); err != nil {
+			return err
+		}
+	}
+
+	return ctxErr
+}
+
+// Close stops the background flush loop and delivers any buffered records.
+// Records passed to Record after Close are discarded.
+func (r *TelemetryReporter) Close(ctx context.Context) error {
+	r.closeOnce.Do(func() {
+		// Queue the last histogram window while enqueue still accepts it.
+		r.recordHistograms()
+		r.mu.Lock()
+		r.closed = true
+		r.mu.Unlock()
+		close(r.done)
+	})
+
+	select {
+	case <-r.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return r.Flush(ctx)
+}
+
+func (r *TelemetryReporter) loop() {
+	defer close(r.stopped)
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	var heartbeat <-chan time.Time
+	if r.heartbeatPeriod > 0 {
+		heartbeatTicker := time.NewTicker(r.heartbeatPeriod)
+		defer heartbeatTicker.Stop()
+		heartbeat = heartbeatTicker.C
+	}
+
+	var histograms <-chan time.Time
+	if r.histograms != nil {
+		histogramTicker := time.NewTicker(r.histogramPeriod)
+		defer histogramTicker.Stop()
+		histograms = histogramTicker.C
+	}
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-heartbeat:
+			r.recordHeartbeat()
+			continue
+		case <-histograms:
+			r.recordHistograms()
+			continue
+		case <-ticker.C:
+		case <-r.flushCh:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), r.httpClient.Timeout)
+		_ = r.Flush(ctx)
+		cancel()
+	}
+}
+
+// recordHeartbeat queues a periodic heartbeat record.
+func (r *TelemetryReporter) recordHeartbeat() {
+	if r.endpoint == "" && len(r.exporters) == 0 {
+		return
+	}
+
+	var info HeartbeatInfo
+	if r.heartbeatProbe != nil {
+		info = r.heartbeatProbe()
+	}
+	info.UptimeSeconds = r.clock.Now().Sub(r.startedAt).Seconds()
+
+	r.mu.Lock()
+	info.TelemetryBacklog = len(r.buffer)
+	r.mu.Unlock()
+
+	metadata := map[string]interface{}{
+		"heartbeat": info,
+	}
+	if r.runtimeStats {
+		metadata["runtime"] = CollectRuntimeStats()
+	}
+
+	r.enqueue(TelemetryRecord{
+		Event:            heartbeatEvent,
+		Version:          "v1",
+		Status:           "ok",
+		ExtensionID:      r.extensionID,
+		ExtensionVersion: r.extensionVersion,
+		Metadata:         metadata,
+		Timestamp:        r.clock.Now().UTC(),
+	}, false)
+}
+
+// recordHistograms queues one record per event with the durations observed
+// since the previous call.
+func (r *TelemetryReporter) recordHistograms() {
+	if r.histograms == nil {
+		return
+	}
+
+	now := r.clock.Now().UTC()
+	for _, h := range r.histograms.drain(now) {
+		h := h
+		r.enqueue(TelemetryRecord{
+			Event:            histogramEvent,
+			Version:          "v1",
+			Status:           "ok",
+			ExtensionID:      r.extensionID,
+			ExtensionVersion: r.extensionVersion,
+			Histogram:        &h,
+			Timestamp:        now,
+		}, false)
+	}
+}
+
+// allowLocked applies the per-minute cap. r.mu must be held.
+func (r *TelemetryReporter) allowLocked(now time.Time) bool {
+	if r.sampling == nil || r.sampling.MaxPerMinute <= 0 {
+		return true
+	}
+
+	if now.Sub(r.windowStart) >= time.Minute {
+		r.windowStart = now
+		r.windowCount = 0
+	}
+	if r.windowCount >= r.sampling.MaxPerMinute {
+		return false
+	}
+
+	r.windowCount++
+	return true
+}
+
+func (r *TelemetryReporter) requeue(records []TelemetryRecord) {
+	r.mu.Lock()
+	r.buffer = append(records, r.buffer...)
+	r.mu.Unlock()
+}
+
+func (r *TelemetryReporter) readSpool() ([]TelemetryRecord, error) {
+	f, err := os.Open(r.spoolPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []TelemetryRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record TelemetryRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// Skip lines truncated by a crash mid-write
+			continue
+		}
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
+
+// writeSpool replaces the spool contents with records, removing the file
+// when there is nothing left to retry.
+func (r *TelemetryReporter) writeSpool(records []TelemetryRecord) error {
+	if len(records) == 0 {
+		if err := os.Remove(r.spoolPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	if len(records) > maxTelemetrySpoolRecords {
+		records = records[len(records)-maxTelemetrySpoolRecords:]
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+
+	tmp := r.spoolPath + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.spoolPath)
+}
+
+func (r *TelemetryReporter) send(ctx context.Context, record TelemetryRecord) error {
 	payload := map[string]interface{}{
 		"event":             record.Event,
 		"version":           record.Version,
@@ -109,25 +659,63 @@ func (r *TelemetryReporter) Record(ctx context.Context, event, version, status s
 		"error_class":       record.ErrorClass,
 		"metadata":          record.Metadata,
 	}
+	if record.SampleRate > 0 {
+		payload["sample_rate"] = record.SampleRate
+	}
+	if len(record.Metrics) > 0 {
+		payload["metrics"] = record.Metrics
+	}
+	if record.Histogram != nil {
+		payload["histogram"] = record.Histogram
+	}
 
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return err
 	}
 
+	backoff := telemetryRetryBackoff
+	for attempt := 0; ; attempt++ {
+		err = r.post(ctx, body)
+		if err == nil || attempt >= r.maxRetries || !isRetryableAPIError(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (r *TelemetryReporter) post(ctx context.Context, body []byte) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if r.apiKey != "" {
+		req.Header.Set(apiKeyHeader, r.apiKey)
+	}
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
 
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
-		// Best effort - don't fail the handler
-		return nil
+		return err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return newAPIError(resp.StatusCode, respBody, resp.Header)
+	}
+
+	// Drain so the connection can be reused
+	io.Copy(io.Discard, resp.Body)
 	return nil
 }
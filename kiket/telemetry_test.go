@@ -0,0 +1,492 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type telemetryCollector struct {
+	mu      sync.Mutex
+	records []map[string]interface{}
+}
+
+func (c *telemetryCollector) handler(w http.ResponseWriter, r *http.Request) {
+	var payload map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&payload)
+
+	c.mu.Lock()
+	c.records = append(c.records, payload)
+	c.mu.Unlock()
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (c *telemetryCollector) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.records)
+}
+
+func newTestTelemetryServer(t *testing.T) (*telemetryCollector, *httptest.Server) {
+	collector := &telemetryCollector{}
+	server := httptest.NewServer(http.HandlerFunc(collector.handler))
+	t.Cleanup(server.Close)
+	return collector, server
+}
+
+func TestTelemetryReporter_FlushDeliversBufferedRecords(t *testing.T) {
+	collector, server := newTestTelemetryServer(t)
+
+	reporter := NewTelemetryReporter(true,
+		WithTelemetryEndpoint(server.URL),
+		WithTelemetryFlushInterval(time.Hour),
+	)
+	defer reporter.Close(context.Background())
+
+	reporter.Record(context.Background(), "issue.created", "v1", "ok", 12, nil)
+	reporter.Record(context.Background(), "issue.updated", "v1", "error", 30, nil)
+
+	if collector.count() != 0 {
+		t.Errorf("Expected records to be buffered, got %d delivered", collector.count())
+	}
+
+	if err := reporter.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	if collector.count() != 2 {
+		t.Errorf("Expected 2 records, got %d", collector.count())
+	}
+	if collector.records[0]["event"] != "issue.created" {
+		t.Errorf("Expected issue.created, got %v", collector.records[0]["event"])
+	}
+}
+
+func TestTelemetryReporter_CloseFlushesAndStopsRecording(t *testing.T) {
+	collector, server := newTestTelemetryServer(t)
+
+	reporter := NewTelemetryReporter(true,
+		WithTelemetryEndpoint(server.URL),
+		WithTelemetryFlushInterval(time.Hour),
+	)
+
+	reporter.Record(context.Background(), "issue.created", "v1", "ok", 5, nil)
+
+	if err := reporter.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if collector.count() != 1 {
+		t.Errorf("Expected 1 record after Close, got %d", collector.count())
+	}
+
+	reporter.Record(context.Background(), "issue.created", "v1", "ok", 5, nil)
+	reporter.Flush(context.Background())
+
+	if collector.count() != 1 {
+		t.Errorf("Expected records after Close to be discarded, got %d", collector.count())
+	}
+}
+
+func TestTelemetryReporter_BatchSizeTriggersFlush(t *testing.T) {
+	collector, server := newTestTelemetryServer(t)
+
+	reporter := NewTelemetryReporter(true,
+		WithTelemetryEndpoint(server.URL),
+		WithTelemetryFlushInterval(time.Hour),
+		WithTelemetryBatchSize(2),
+	)
+	defer reporter.Close(context.Background())
+
+	reporter.Record(context.Background(), "issue.created", "v1", "ok", 1, nil)
+	reporter.Record(context.Background(), "issue.created", "v1", "ok", 1, nil)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for collector.count() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if collector.count() != 2 {
+		t.Errorf("Expected batch to be flushed, got %d records", collector.count())
+	}
+}
+
+func TestTelemetryReporter_SamplingKeepsErrorsAndCapsRate(t *testing.T) {
+	collector, server := newTestTelemetryServer(t)
+
+	reporter := NewTelemetryReporter(true,
+		WithTelemetryEndpoint(server.URL),
+		WithTelemetryFlushInterval(time.Hour),
+		WithTelemetrySampling(TelemetrySampling{
+			SuccessRate:  0.5,
+			MaxPerMinute: 3,
+		}),
+	)
+	defer reporter.Close(context.Background())
+	reporter.random = func() float64 { return 0.75 }
+
+	reporter.Record(context.Background(), "issue.created", "v1", "ok", 1, nil)
+	for i := 0; i < 5; i++ {
+		reporter.Record(context.Background(), "issue.created", "v1", "error", 1, nil)
+	}
+	reporter.Flush(context.Background())
+
+	if collector.count() != 3 {
+		t.Fatalf("Expected 3 records (errors capped per minute), got %d", collector.count())
+	}
+	for _, record := range collector.records {
+		if record["status"] != "error" {
+			t.Errorf("Expected sampled-out success to be dropped, got %v", record["status"])
+		}
+	}
+}
+
+func TestTelemetryReporter_SamplingKeepsRecordsWithMetrics(t *testing.T) {
+	collector, server := newTestTelemetryServer(t)
+
+	reporter := NewTelemetryReporter(true,
+		WithTelemetryEndpoint(server.URL),
+		WithTelemetryFlushInterval(time.Hour),
+		WithTelemetrySampling(TelemetrySampling{
+			SuccessRate:  -1,
+			MaxPerMinute: 1,
+		}),
+	)
+	defer reporter.Close(context.Background())
+
+	metrics := []Metric{{Name: "crm.synced", Kind: MetricCounter, Value: 1, Count: 1}}
+	for i := 0; i < 3; i++ {
+		reporter.Record(context.Background(), "issue.created", "v1", "ok", 1, map[string]interface{}{"metrics": metrics})
+	}
+	reporter.Record(context.Background(), "issue.created", "v1", "ok", 1, nil)
+	reporter.Flush(context.Background())
+
+	if collector.count() != 3 {
+		t.Fatalf("Expected the 3 records with metrics to bypass sampling, got %d", collector.count())
+	}
+	for _, record := range collector.records {
+		if record["metrics"] == nil {
+			t.Errorf("Expected only records with metrics, got %v", record)
+		}
+	}
+}
+
+func TestTelemetryReporter_SpoolsUndeliveredRecords(t *testing.T) {
+	spool := filepath.Join(t.TempDir(), "telemetry.spool")
+
+	offline := NewTelemetryReporter(true,
+		WithTelemetryEndpoint("http://127.0.0.1:1"),
+		WithTelemetryFlushInterval(time.Hour),
+		WithTelemetryRetries(0),
+		WithTelemetrySpool(spool),
+	)
+	offline.Record(context.Background(), "issue.created", "v1", "error", 7, nil)
+	offline.Close(context.Background())
+
+	if _, err := os.Stat(spool); err != nil {
+		t.Fatalf("Expected spool file to exist: %v", err)
+	}
+
+	collector, server := newTestTelemetryServer(t)
+	online := NewTelemetryReporter(true,
+		WithTelemetryEndpoint(server.URL),
+		WithTelemetryFlushInterval(time.Hour),
+		WithTelemetrySpool(spool),
+	)
+	defer online.Close(context.Background())
+
+	if err := online.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if collector.count() != 1 {
+		t.Fatalf("Expected spooled record to be retried, got %d", collector.count())
+	}
+	if collector.records[0]["duration_ms"] != float64(7) {
+		t.Errorf("Expected duration_ms 7, got %v", collector.records[0]["duration_ms"])
+	}
+	if _, err := os.Stat(spool); !os.IsNotExist(err) {
+		t.Errorf("Expected spool file to be removed, got %v", err)
+	}
+}
+
+func TestTelemetryReporter_EnrichersRunBeforeDelivery(t *testing.T) {
+	collector, server := newTestTelemetryServer(t)
+
+	reporter := NewTelemetryReporter(true,
+		WithTelemetryEndpoint(server.URL),
+		WithTelemetryFlushInterval(time.Hour),
+		WithTelemetryEnricher(func(record *TelemetryRecord) {
+			if record.Metadata == nil {
+				record.Metadata = map[string]interface{}{}
+			}
+			record.Metadata["region"] = "eu-west-1"
+		}),
+		WithTelemetryEnricher(func(record *TelemetryRecord) {
+			record.ErrorMessage = ""
+		}),
+	)
+	defer reporter.Close(context.Background())
+
+	reporter.Record(context.Background(), "issue.created", "v1", "error", 1, map[string]interface{}{
+		"errorMessage": "token abc123 rejected",
+	})
+	reporter.Flush(context.Background())
+
+	if collector.count() != 1 {
+		t.Fatalf("Expected 1 record, got %d", collector.count())
+	}
+	record := collector.records[0]
+	if record["error_message"] != "" {
+		t.Errorf("Expected error message to be scrubbed, got %v", record["error_message"])
+	}
+	metadata, _ := record["metadata"].(map[string]interface{})
+	if metadata["region"] != "eu-west-1" {
+		t.Errorf("Expected region metadata, got %v", record["metadata"])
+	}
+}
+
+func TestMetrics_SnapshotAggregatesByKind(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.Incr("sync.records")
+	metrics.Add("sync.records", 2)
+	metrics.Gauge("backlog", 10)
+	metrics.Gauge("backlog", 4)
+	metrics.Timing("crm.request", 20*time.Millisecond)
+	metrics.Timing("crm.request", 40*time.Millisecond)
+
+	snapshot := metrics.Snapshot()
+	if len(snapshot) != 3 {
+		t.Fatalf("Expected 3 metrics, got %d", len(snapshot))
+	}
+	if snapshot[0].Value != 3 || snapshot[0].Count != 2 {
+		t.Errorf("Expected counter value 3 over 2 observations, got %+v", snapshot[0])
+	}
+	if snapshot[1].Value != 4 {
+		t.Errorf("Expected last gauge value 4, got %v", snapshot[1].Value)
+	}
+	if snapshot[2].Value != 60 || snapshot[2].MaxMs != 40 {
+		t.Errorf("Expected timer total 60ms max 40ms, got %+v", snapshot[2])
+	}
+
+	var nilMetrics *Metrics
+	nilMetrics.Incr("ignored")
+	if nilMetrics.Snapshot() != nil {
+		t.Error("Expected nil snapshot from nil Metrics")
+	}
+}
+
+func TestOTLPExporter_PostsLogsAndMetrics(t *testing.T) {
+	var mu sync.Mutex
+	bodies := make(map[string]map[string]interface{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		bodies[r.URL.Path] = payload
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	reporter := NewTelemetryReporter(true,
+		WithTelemetryExtension("com.example.ext", "1.2.0"),
+		WithTelemetryFlushInterval(time.Hour),
+		WithTelemetryExporter(NewOTLPExporter(server.URL)),
+	)
+	defer reporter.Close(context.Background())
+
+	reporter.Record(context.Background(), "issue.created", "v1", "ok", 10, map[string]interface{}{
+		"metrics": []Metric{{Name: "sync.records", Kind: MetricCounter, Value: 3, Count: 1}},
+	})
+	reporter.Record(context.Background(), "issue.created", "v1", "ok", 30, nil)
+	reporter.Record(context.Background(), "relay:issue.created", "", "ok", 500, nil)
+	reporter.Record(context.Background(), heartbeatEvent, "v1", "ok", 0, nil)
+	reporter.Flush(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := bodies["/v1/logs"]["resourceLogs"]; !ok {
+		t.Errorf("Expected resourceLogs payload, got %v", bodies["/v1/logs"])
+	}
+
+	resourceMetrics, _ := bodies["/v1/metrics"]["resourceMetrics"].([]interface{})
+	if len(resourceMetrics) != 1 {
+		t.Fatalf("Expected 1 resourceMetrics entry, got %v", bodies["/v1/metrics"])
+	}
+	scopeMetrics := resourceMetrics[0].(map[string]interface{})["scopeMetrics"].([]interface{})
+	metrics := scopeMetrics[0].(map[string]interface{})["metrics"].([]interface{})
+	if len(metrics) != 2 {
+		t.Fatalf("Expected duration summary and custom counter, got %d metrics", len(metrics))
+	}
+
+	duration := metrics[0].(map[string]interface{})
+	point := duration["summary"].(map[string]interface{})["dataPoints"].([]interface{})[0].(map[string]interface{})
+	points := duration["summary"].(map[string]interface{})["dataPoints"].([]interface{})
+	if len(points) != 1 || point["count"] != "2" || point["sum"] != float64(40) {
+		t.Errorf("Expected only handler durations aggregated, count=2 sum=40, got %v", points)
+	}
+}
+
+func TestTelemetryReporter_HeartbeatWithRuntimeStats(t *testing.T) {
+	collector, server := newTestTelemetryServer(t)
+
+	reporter := NewTelemetryReporter(true,
+		WithTelemetryEndpoint(server.URL),
+		WithTelemetryFlushInterval(time.Hour),
+		WithTelemetryHeartbeat(time.Hour, func() HeartbeatInfo {
+			return HeartbeatInfo{HandlerCount: 3}
+		}),
+		WithTelemetryRuntimeStats(time.Hour),
+	)
+	defer reporter.Close(context.Background())
+
+	reporter.recordHeartbeat()
+	reporter.Flush(context.Background())
+
+	if collector.count() != 1 {
+		t.Fatalf("Expected 1 heartbeat, got %d", collector.count())
+	}
+	record := collector.records[0]
+	if record["event"] != heartbeatEvent {
+		t.Errorf("Expected %s, got %v", heartbeatEvent, record["event"])
+	}
+	metadata, _ := record["metadata"].(map[string]interface{})
+	runtimeStats, _ := metadata["runtime"].(map[string]interface{})
+	if goroutines, _ := runtimeStats["goroutines"].(float64); goroutines < 1 {
+		t.Errorf("Expected goroutine count, got %v", metadata["runtime"])
+	}
+	heartbeat, _ := metadata["heartbeat"].(map[string]interface{})
+	if heartbeat["handler_count"] != float64(3) {
+		t.Errorf("Expected handler_count 3, got %v", metadata["heartbeat"])
+	}
+}
+
+func TestTelemetryReporter_AuthenticatesAndRetriesServerErrors(t *testing.T) {
+	var mu sync.Mutex
+	var attempts int
+	var apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		apiKey = r.Header.Get("X-Kiket-API-Key")
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	reporter := NewTelemetryReporter(true,
+		WithTelemetryEndpoint(server.URL),
+		WithTelemetryAPIKey("ext-key"),
+		WithTelemetryFlushInterval(time.Hour),
+	)
+	defer reporter.Close(context.Background())
+
+	reporter.Record(context.Background(), "issue.created", "v1", "ok", 1, nil)
+	reporter.Flush(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 2 {
+		t.Errorf("Expected 503 to be retried once, got %d attempts", attempts)
+	}
+	if apiKey != "ext-key" {
+		t.Errorf("Expected API key header, got %q", apiKey)
+	}
+}
+
+func TestTelemetryReporter_DoesNotSpoolRejectedRecords(t *testing.T) {
+	spool := filepath.Join(t.TempDir(), "telemetry.spool")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	reporter := NewTelemetryReporter(true,
+		WithTelemetryEndpoint(server.URL),
+		WithTelemetryFlushInterval(time.Hour),
+		WithTelemetrySpool(spool),
+	)
+	defer reporter.Close(context.Background())
+
+	reporter.Record(context.Background(), "issue.created", "v1", "ok", 1, nil)
+	reporter.Flush(context.Background())
+
+	if _, err := os.Stat(spool); !os.IsNotExist(err) {
+		t.Errorf("Expected rejected record not to be spooled, got %v", err)
+	}
+}
+
+func TestTelemetryReporter_HistogramsSummarizeDurations(t *testing.T) {
+	collector, server := newTestTelemetryServer(t)
+
+	reporter := NewTelemetryReporter(true,
+		WithTelemetryEndpoint(server.URL),
+		WithTelemetryFlushInterval(time.Hour),
+		WithTelemetryHistograms(time.Hour),
+		WithTelemetrySampling(TelemetrySampling{SuccessRate: -1}),
+	)
+	defer reporter.Close(context.Background())
+
+	for i := int64(1); i <= 100; i++ {
+		reporter.Record(context.Background(), "issue.created", "v1", "ok", i, nil)
+	}
+	reporter.recordHistograms()
+	reporter.Flush(context.Background())
+
+	if collector.count() != 1 {
+		t.Fatalf("Expected only the histogram record, got %d", collector.count())
+	}
+	histogram, _ := collector.records[0]["histogram"].(map[string]interface{})
+	if histogram["count"] != float64(100) {
+		t.Errorf("Expected count 100, got %v", histogram["count"])
+	}
+	if histogram["p50_ms"] != float64(50) || histogram["p95_ms"] != float64(95) || histogram["p99_ms"] != float64(99) {
+		t.Errorf("Unexpected percentiles: %v", histogram)
+	}
+}
+
+type recordingTelemetryExporter struct {
+	mu      sync.Mutex
+	records []TelemetryRecord
+}
+
+func (e *recordingTelemetryExporter) Export(ctx context.Context, records []TelemetryRecord) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.records = append(e.records, records...)
+	return nil
+}
+
+func TestTelemetryReporter_CloseExportsLastHistogramWindow(t *testing.T) {
+	exporter := &recordingTelemetryExporter{}
+	reporter := NewTelemetryReporter(true,
+		WithTelemetryFlushInterval(time.Hour),
+		WithTelemetryHistograms(time.Hour),
+		WithTelemetrySampling(TelemetrySampling{SuccessRate: -1}),
+		WithTelemetryExporter(exporter),
+	)
+
+	for i := int64(1); i <= 10; i++ {
+		reporter.Record(context.Background(), "issue.created", "v1", "ok", i, nil)
+	}
+	if err := reporter.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if len(exporter.records) != 1 || exporter.records[0].Event != histogramEvent {
+		t.Fatalf("Expected the histogram record on close, got %+v", exporter.records)
+	}
+	if exporter.records[0].Histogram.Count != 10 {
+		t.Errorf("Expected count 10, got %+v", exporter.records[0].Histogram)
+	}
+}
@@ -0,0 +1,99 @@
+package kiket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// defaultMaxRedirects matches net/http's limit.
+const defaultMaxRedirects = 10
+
+// credentialHeaders are removed from requests redirected to another host.
+// net/http already drops Authorization and cookies.
+var credentialHeaders = []string{apiKeyHeader, "X-Kiket-Runtime-Token"}
+
+// WithMaxRedirects caps the requests a call makes while following
+// redirects, counting the original one, so the default of 10 follows at
+// most 9 redirects like net/http. Zero or one disables following: a
+// redirect response fails the call instead.
+func WithMaxRedirects(max int) ClientOption {
+	return func(c *HTTPClient) {
+		if max >= 0 {
+			c.maxRedirects = max
+		}
+	}
+}
+
+// WithSameHostRedirects refuses redirects to a host other than the one the
+// call was made to. Redirects to another host never carry the SDK's
+// credentials either way.
+func WithSameHostRedirects() ClientOption {
+	return func(c *HTTPClient) {
+		c.sameHostRedirects = true
+	}
+}
+
+// WithProxyFromEnvironment controls whether calls honor the HTTP_PROXY,
+// HTTPS_PROXY, and NO_PROXY environment variables (the default). Pass false
+// to connect directly whatever the environment says.
+func WithProxyFromEnvironment(enabled bool) ClientOption {
+	return func(c *HTTPClient) {
+		if enabled {
+			c.transport().Proxy = http.ProxyFromEnvironment
+		} else {
+			c.transport().Proxy = nil
+		}
+	}
+}
+
+// WithProxyURL sends every call through the proxy at rawURL, e.g.
+// "http://proxy.internal:3128", ignoring the proxy environment variables.
+// An invalid URL fails each call.
+func WithProxyURL(rawURL string) ClientOption {
+	return func(c *HTTPClient) {
+		proxyURL, err := url.Parse(rawURL)
+		if err == nil && (proxyURL.Scheme == "" || proxyURL.Host == "") {
+			err = errors.New("missing scheme or host")
+		}
+		if err != nil {
+			err = fmt.Errorf("invalid proxy URL %q: %w", rawURL, err)
+			c.transport().Proxy = func(*http.Request) (*url.URL, error) { return nil, err }
+			return
+		}
+		c.transport().Proxy = http.ProxyURL(proxyURL)
+	}
+}
+
+// transport returns the client's own *http.Transport, cloning the default
+// one on first use so proxy settings don't leak into other clients.
+func (c *HTTPClient) transport() *http.Transport {
+	if t, ok := c.httpClient.Transport.(*http.Transport); ok {
+		return t
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	c.httpClient.Transport = t
+	return t
+}
+
+// checkRedirect applies the redirect options and strips the SDK's
+// credentials from requests redirected to another host.
+func (c *HTTPClient) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= c.maxRedirects {
+		if c.maxRedirects <= 1 {
+			return fmt.Errorf("redirect to %s not followed", req.URL.Redacted())
+		}
+		return fmt.Errorf("stopped after %d requests", c.maxRedirects)
+	}
+
+	if req.URL.Host != via[0].URL.Host {
+		if c.sameHostRedirects {
+			return fmt.Errorf("redirect to another host (%s) not allowed", req.URL.Host)
+		}
+		for _, header := range credentialHeaders {
+			req.Header.Del(header)
+		}
+	}
+	return nil
+}
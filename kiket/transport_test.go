@@ -0,0 +1,103 @@
+package kiket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPClient_RedirectsDropCredentialsAcrossHosts(t *testing.T) {
+	var leakedKey string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leakedKey = r.Header.Get(apiKeyHeader)
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer other.Close()
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/here", http.StatusFound)
+		case "/here":
+			w.Write([]byte(`{"data":{}}`))
+		default:
+			// 127.0.0.1 and localhost are different hosts to net/http
+			http.Redirect(w, r, strings.Replace(other.URL, "127.0.0.1", "localhost", 1)+"/away", http.StatusFound)
+		}
+	}))
+	defer origin.Close()
+
+	ctx := context.Background()
+	client := NewHTTPClient(WithBaseURL(origin.URL), WithAPIKey("secret-key"))
+	if _, err := client.Get(ctx, "/elsewhere", nil); err != nil {
+		t.Fatalf("Expected cross-host redirect to be followed, got %v", err)
+	}
+	if leakedKey != "" {
+		t.Errorf("Expected API key stripped on cross-host redirect, got %q", leakedKey)
+	}
+
+	sameHost := NewHTTPClient(WithBaseURL(origin.URL), WithSameHostRedirects())
+	if _, err := sameHost.Get(ctx, "/moved", nil); err != nil {
+		t.Errorf("Expected same-host redirect to be followed, got %v", err)
+	}
+	if _, err := sameHost.Get(ctx, "/elsewhere", nil); err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Errorf("Expected cross-host redirect refused, got %v", err)
+	}
+
+	noRedirects := NewHTTPClient(WithBaseURL(origin.URL), WithMaxRedirects(0))
+	if _, err := noRedirects.Get(ctx, "/moved", nil); err == nil || !strings.Contains(err.Error(), "not followed") {
+		t.Errorf("Expected redirect not followed, got %v", err)
+	}
+}
+
+func TestHTTPClient_ProxyURL(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer proxy.Close()
+
+	client := NewHTTPClient(WithBaseURL("http://kiket.internal"), WithProxyURL(proxy.URL))
+	if _, err := client.Get(context.Background(), "/api/v1/ext/workspace", nil); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if proxied != "http://kiket.internal/api/v1/ext/workspace" {
+		t.Errorf("Expected request through proxy, got %q", proxied)
+	}
+
+	invalid := NewHTTPClient(WithBaseURL("http://kiket.internal"), WithProxyURL("proxy.internal"))
+	if _, err := invalid.Get(context.Background(), "/", nil); err == nil || !strings.Contains(err.Error(), "invalid proxy URL") {
+		t.Errorf("Expected invalid proxy URL error, got %v", err)
+	}
+}
+
+func TestHTTPClient_MaxRedirectsCountsRequests(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		http.Redirect(w, r, fmt.Sprintf("/hop/%d", hits), http.StatusFound)
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		client *HTTPClient
+		hits   int
+	}{
+		{NewHTTPClient(WithBaseURL(server.URL)), 10},
+		{NewHTTPClient(WithBaseURL(server.URL), WithMaxRedirects(3)), 3},
+		{NewHTTPClient(WithBaseURL(server.URL), WithMaxRedirects(0)), 1},
+	}
+	for _, tc := range cases {
+		hits = 0
+		if _, err := tc.client.Get(context.Background(), "/loop", nil); err == nil {
+			t.Error("Expected error for a redirect loop")
+		}
+		if hits != tc.hits {
+			t.Errorf("Expected %d requests, got %d", tc.hits, hits)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package kiket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTunnelForwardTimeout = 30 * time.Second
+	tunnelCloseTimeout          = 10 * time.Second
+)
+
+// DevTunnel is a temporary webhook endpoint registered for development.
+// Kiket delivers the workspace's events to WebhookURL and relays them over
+// the WebSocket at ConnectURL until the tunnel is deleted or expires.
+type DevTunnel struct {
+	ID         string     `json:"id"`
+	WebhookURL string     `json:"webhook_url"`
+	ConnectURL string     `json:"connect_url"`
+	Events     []string   `json:"events,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreateDevTunnel registers a temporary webhook endpoint subscribed to
+// events (all manifest events if empty).
+func (e *Endpoints) CreateDevTunnel(ctx context.Context, events []string) (*DevTunnel, error) {
+	if e.extensionID == "" {
+		return nil, errors.New("extension ID required for dev tunnels")
+	}
+
+	payload := map[string]interface{}{}
+	if len(events) > 0 {
+		payload["events"] = events
+	}
+	resp, err := e.client.Post(ctx, e.basePath+"/dev_tunnels", payload, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeData[DevTunnel](resp)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteDevTunnel removes a dev tunnel registered with CreateDevTunnel.
+func (e *Endpoints) DeleteDevTunnel(ctx context.Context, tunnelID string) error {
+	if e.extensionID == "" {
+		return errors.New("extension ID required for dev tunnels")
+	}
+
+	_, err := e.client.Delete(ctx, joinPath(e.basePath+"/dev_tunnels", tunnelID), nil)
+	return err
+}
+
+// TunnelOptions configures SDK.Tunnel.
+type TunnelOptions struct {
+	// Local webhook URL deliveries are forwarded to, e.g.
+	// "http://localhost:9292/webhook". When empty the SDK's own handlers
+	// run them.
+	ForwardURL string
+	// Events to subscribe the tunnel to (default the manifest's events)
+	Events []string
+	// Where received deliveries are printed (default os.Stdout)
+	Output io.Writer
+	// Client used for forwarding (default one with a 30 second timeout)
+	HTTPClient *http.Client
+}
+
+// Tunnel registers a temporary webhook endpoint with Kiket and serves the
+// events delivered to it over the outbound WebSocket, printing each one.
+// It lets developers exercise real workspace events against a laptop
+// without exposing a public URL. Deliveries are either forwarded unchanged,
+// signature included, to TunnelOptions.ForwardURL or handled by the SDK's
+// own handlers.
+//
+// Tunnel blocks until ctx is done, then deletes the endpoint. Use it for
+// development only; production extensions use a webhook URL or Connect.
+func (s *SDK) Tunnel(ctx context.Context, opts TunnelOptions) error {
+	if opts.Output == nil {
+		opts.Output = os.Stdout
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: defaultTunnelForwardTimeout}
+	}
+	events := opts.Events
+	if len(events) == 0 {
+		s.settingsMu.RLock()
+		events = s.manifest.EventNames()
+		s.settingsMu.RUnlock()
+	}
+
+	tunnel, err := s.endpoints.CreateDevTunnel(ctx, events)
+	if err != nil {
+		return fmt.Errorf("failed to create dev tunnel: %w", err)
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), tunnelCloseTimeout)
+		defer cancel()
+		if err := s.endpoints.DeleteDevTunnel(closeCtx, tunnel.ID); err != nil {
+			s.config.Logger.Printf("kiket: failed to delete dev tunnel %s: %v", tunnel.ID, err)
+		}
+	}()
+
+	socketURL := tunnel.ConnectURL
+	if socketURL == "" {
+		socketURL = joinPath(s.endpoints.basePath+"/dev_tunnels", tunnel.ID) + "/connect"
+	}
+	if strings.HasPrefix(socketURL, "/") {
+		socketURL = strings.TrimSuffix(s.config.BaseURL, "/") + socketURL
+	}
+
+	fmt.Fprintf(opts.Output, "Tunnel %s forwarding %s", tunnel.ID, tunnel.WebhookURL)
+	if opts.ForwardURL != "" {
+		fmt.Fprintf(opts.Output, " -> %s", opts.ForwardURL)
+	}
+	if tunnel.ExpiresAt != nil {
+		fmt.Fprintf(opts.Output, " (expires %s)", tunnel.ExpiresAt.Local().Format(time.Kitchen))
+	}
+	fmt.Fprintln(opts.Output)
+
+	handle := s.handleSocketDelivery
+	if opts.ForwardURL != "" {
+		handle = forwardDelivery(opts.HTTPClient, opts.ForwardURL)
+	}
+	printer := &tunnelPrinter{out: opts.Output}
+	return s.serveSocketURL(ctx, socketURL, func(ctx context.Context, msg socketMessage) socketMessage {
+		reply := handle(ctx, msg)
+		printer.print(msg, reply)
+		return reply
+	})
+}
+
+// forwardDelivery returns a handler that posts each delivery to target
+// with its original headers and relays the response.
+func forwardDelivery(client *http.Client, target string) socketHandler {
+	return func(ctx context.Context, msg socketMessage) socketMessage {
+		reply := socketMessage{Type: "response", ID: msg.ID}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(msg.Body))
+		if err != nil {
+			reply.Status = http.StatusInternalServerError
+			reply.Error = err.Error()
+			return reply
+		}
+		for name, value := range msg.Headers {
+			req.Header.Set(name, value)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			reply.Status = http.StatusBadGateway
+			reply.Error = err.Error()
+			return reply
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			reply.Status = http.StatusBadGateway
+			reply.Error = err.Error()
+			return reply
+		}
+
+		reply.Status = resp.StatusCode
+		body = bytes.TrimSpace(body)
+		switch {
+		case len(body) == 0:
+		case json.Valid(body):
+			reply.Body = body
+		case resp.StatusCode >= 400:
+			reply.Error = string(body)
+		default:
+			reply.Body, _ = json.Marshal(string(body))
+		}
+		return reply
+	}
+}
+
+// tunnelPrinter writes one entry per delivery, serialized across the
+// concurrent handlers.
+type tunnelPrinter struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func (p *tunnelPrinter) print(msg socketMessage, reply socketMessage) {
+	var envelope struct {
+		Event string `json:"event"`
+	}
+	json.Unmarshal(msg.Body, &envelope)
+	event := envelope.Event
+	if event == "" {
+		event = headerValue(msg.Headers, "X-Kiket-Event")
+	}
+	version := headerValue(msg.Headers, "X-Kiket-Event-Version")
+
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, msg.Body, "  ", "  "); err != nil {
+		pretty.Reset()
+		pretty.Write(msg.Body)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprintf(p.out, "%s  %s %s", time.Now().Format("15:04:05"), event, version)
+	if id := headerValue(msg.Headers, "X-Kiket-Delivery-Id"); id != "" {
+		fmt.Fprintf(p.out, "  delivery=%s", id)
+	}
+	fmt.Fprintf(p.out, "  -> %d", reply.Status)
+	if reply.Error != "" {
+		fmt.Fprintf(p.out, " %s", reply.Error)
+	}
+	fmt.Fprintf(p.out, "\n  %s\n", pretty.String())
+}
@@ -0,0 +1,95 @@
+package kiket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSDK_TunnelForwardsDeliveriesAndDeletesEndpoint(t *testing.T) {
+	body := `{"event":"issue.created","issue":{"id":1,"title":"Bug"}}`
+	signature, timestamp := GenerateSignature("secret", body, nil)
+
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ := io.ReadAll(r.Body)
+		if string(got) != body || r.Header.Get("X-Kiket-Signature") != signature {
+			http.Error(w, "unexpected delivery", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"status":"triaged"}`))
+	}))
+	defer local.Close()
+
+	replies := make(chan socketMessage, 1)
+	deleted := make(chan string, 1)
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/extensions/com.example.ext/dev_tunnels":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"data":{"id":"tun_1","webhook_url":"https://kiket.dev/hooks/tun_1","connect_url":"/api/v1/extensions/com.example.ext/dev_tunnels/tun_1/connect"}}`))
+		case r.Method == http.MethodDelete:
+			deleted <- r.URL.Path
+		case r.URL.Path == "/api/v1/extensions/com.example.ext/dev_tunnels/tun_1/connect":
+			conn := acceptWebsocket(t, w, r)
+			defer conn.Close()
+			out, _ := json.Marshal(socketMessage{
+				Type: "delivery", ID: "d1", Body: json.RawMessage(body),
+				Headers: Headers{"X-Kiket-Signature": signature, "X-Kiket-Timestamp": timestamp, "X-Kiket-Event-Version": "v1", "X-Kiket-Delivery-Id": "del_1"},
+			})
+			conn.WriteMessage(out)
+			if data, err := conn.ReadMessage(); err == nil {
+				var reply socketMessage
+				json.Unmarshal(data, &reply)
+				replies <- reply
+			}
+			<-r.Context().Done()
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	sdk, err := New(Config{
+		ExtensionID:     "com.example.ext",
+		ExtensionAPIKey: "key",
+		BaseURL:         server.URL,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var output bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		done <- sdk.Tunnel(ctx, TunnelOptions{ForwardURL: local.URL, Events: []string{"issue.created"}, Output: &output})
+	}()
+
+	reply := <-replies
+	if reply.ID != "d1" || reply.Status != http.StatusOK || string(reply.Body) != `{"status":"triaged"}` {
+		t.Errorf("Expected forwarded 200 reply, got %+v", reply)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Expected nil error after cancel, got %v", err)
+	}
+
+	if path := <-deleted; path != "/api/v1/extensions/com.example.ext/dev_tunnels/tun_1" {
+		t.Errorf("Expected tunnel deletion, got %s", path)
+	}
+	if events, _ := created["events"].([]interface{}); len(events) != 1 || events[0] != "issue.created" {
+		t.Errorf("Expected events [issue.created], got %v", created["events"])
+	}
+	if out := output.String(); !strings.Contains(out, "https://kiket.dev/hooks/tun_1") || !strings.Contains(out, "issue.created v1  delivery=del_1  -> 200") {
+		t.Errorf("Expected printed tunnel and delivery, got %q", out)
+	}
+}
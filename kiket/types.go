@@ -3,8 +3,13 @@ package kiket
 
 import (
 	"context"
+	"io"
+	"io/fs"
+	"log"
 	"os"
 	"time"
+
+	"github.com/kiket-dev/kiket/sdk/go/kiket/models"
 )
 
 // WebhookPayload represents a generic webhook payload.
@@ -19,12 +24,23 @@ type Settings map[string]interface{}
 // WebhookHandler is the function signature for webhook handlers.
 type WebhookHandler func(ctx context.Context, payload WebhookPayload, handlerCtx *HandlerContext) (interface{}, error)
 
+// RawWebhookHandler handles a webhook without decoding its payload up front
+// (see SDK.OnRaw).
+type RawWebhookHandler func(ctx context.Context, payload *RawPayload, handlerCtx *HandlerContext) (interface{}, error)
+
 // HandlerContext provides context to webhook handlers.
 type HandlerContext struct {
 	// Event name (e.g., "issue.created")
 	Event string
 	// Event version (e.g., "v1", "v2")
 	EventVersion string
+	// Unique delivery identifier for deduplicating redeliveries, from the
+	// X-Kiket-Delivery-Id header or the CloudEvents id
+	DeliveryID string
+	// Workspace the delivery belongs to, from the X-Kiket-Workspace-Id header
+	WorkspaceID string
+	// CloudEvents attributes, when the delivery arrived as a CloudEvent
+	CloudEvent *CloudEvent
 	// Request headers
 	Headers Headers
 	// Kiket API client
@@ -39,6 +55,16 @@ type HandlerContext struct {
 	ExtensionVersion string
 	// Secret manager for API-based secret operations
 	Secrets SecretManager
+	// Custom metrics reported with this delivery's telemetry
+	Metrics *Metrics
+	// The delivery's payload with fields decoded on demand
+	Payload *RawPayload
+	// When the delivery's processing budget runs out (zero without
+	// Config.ProcessingBudget; see BudgetRemaining)
+	Deadline time.Time
+	// Mutations applied only if the handler succeeds (nil without
+	// Config.OutboxStore; see Outbox)
+	Outbox *Outbox
 	// Payload secrets (per-org configuration bundled by SecretResolver)
 	payloadSecrets map[string]string
 }
@@ -69,6 +95,11 @@ type Config struct {
 	WorkspaceToken string
 	// Extension API key for /api/v1/ext endpoints
 	ExtensionAPIKey string
+	// Per-workspace credentials, resolved for each delivery by its
+	// X-Kiket-Workspace-Id header and for each API call whose context
+	// carries a workspace (see ContextWithWorkspace). Wrap it with
+	// CacheCredentials to avoid a lookup per call.
+	CredentialResolver CredentialResolver
 	// Kiket API base URL
 	BaseURL string
 	// Extension settings
@@ -77,14 +108,94 @@ type Config struct {
 	ExtensionID string
 	// Extension version
 	ExtensionVersion string
-	// Path to manifest file (extension.yaml or manifest.yaml)
+	// Path to manifest file (extension.yaml, manifest.yaml, or a .json equivalent)
 	ManifestPath string
+	// File system to load the manifest from (e.g. an embed.FS); ManifestPath is relative to it
+	ManifestFS fs.FS
+	// Reject unknown manifest fields instead of ignoring them
+	StrictManifest bool
 	// Auto-load secrets from KIKET_SECRET_* environment variables
 	AutoEnvSecrets bool
+	// Fetch the settings configured in the Kiket UI at startup, and again on
+	// each SettingsUpdatedEvent delivery (see SDK.SyncSettings)
+	RemoteSettings bool
 	// Enable telemetry reporting
 	TelemetryEnabled bool
 	// Telemetry reporting URL
 	TelemetryURL string
+	// Interval between liveness heartbeats (0 disables them)
+	HeartbeatInterval time.Duration
+	// Additional HTTP client options (timeouts, persisted GraphQL queries, ...)
+	ClientOptions []ClientOption
+	// Additional telemetry reporter options (sampling, flush interval, ...)
+	TelemetryOptions []TelemetryOption
+	// Logger for warnings such as manifest validation issues (defaults to log.Default())
+	Logger *log.Logger
+	// Clock for signature timestamps, telemetry, and processing budgets (defaults to SystemClock)
+	Clock Clock
+	// Called the first time an API call hits each endpoint marked deprecated
+	// by Deprecation/Sunset response headers. Deprecations are also logged
+	// and recorded in telemetry.
+	OnDeprecation func(Deprecation)
+	// Interval between automatic health reports to Kiket's extension
+	// health dashboard (0 disables them; see SDK.HealthStatus)
+	HealthInterval time.Duration
+	// Receives handler errors and panics with scrubbed payload metadata
+	// (see ErrorReporter). Panics are recovered and returned as *PanicError.
+	ErrorReporter ErrorReporter
+	// Binary formats accepted for webhook bodies and API responses, most
+	// preferred first (e.g. MsgPackFormat(), CBORFormat()). JSON is always
+	// accepted and remains the default.
+	PayloadFormats []PayloadFormat
+	// Maximum age of a delivery's signature timestamp (defaults to 5m).
+	// Raise it when deliveries are relayed with delay, such as from Kafka.
+	SignatureTolerance time.Duration
+	// Process deliveries in the background, acknowledging them with 202 once
+	// the signature is verified. The value bounds the queue length (0 keeps
+	// processing synchronous). A full queue answers 503 with Retry-After.
+	AsyncQueueSize int
+	// Upper bound on the total size of queued bodies (0 = no limit)
+	AsyncQueueBytes int64
+	// Background workers processing async deliveries (defaults to 4)
+	AsyncWorkers int
+	// Retry-After sent when the async queue is full (defaults to 5s)
+	AsyncRetryAfter time.Duration
+	// Buffer Endpoints.LogEvent calls and post them in batches of up to
+	// this many events (0 posts each event immediately; see EventBatcher)
+	EventBatchSize int
+	// Interval between batched event posts (defaults to 5s)
+	EventFlushInterval time.Duration
+	// Receives a JSON line per outbound API call for compliance audits
+	// (see WithAuditLog)
+	AuditLog io.Writer
+	// Total time HandleWebhook allows per delivery, e.g. 25s (0 = no
+	// limit). API calls end BudgetHeadroom early so the handler can still
+	// answer; a delivery that runs out is answered with 504.
+	ProcessingBudget time.Duration
+	// Time reserved at the end of the budget for answering the delivery
+	// (defaults to 10% of ProcessingBudget, at most 2s)
+	BudgetHeadroom time.Duration
+	// Check granted scopes at startup (see SDK.PreflightScopes): New fails
+	// when manifest scopes are missing, and calls needing a scope that was
+	// not granted fail with a *MissingScopeError instead of a 403.
+	ScopePreflight bool
+	// Called when an API response reports fewer than RateLimitThreshold
+	// calls left in the rate limit window (see WithRateLimitThreshold)
+	OnRateLimitLow func(RateLimitState)
+	// Remaining call count below which OnRateLimitLow fires
+	RateLimitThreshold int
+	// What API calls do when throttled with 429 (see WithRateLimitHandling);
+	// by default they return a *RateLimitError
+	RateLimitHandling RateLimitMode
+	// Decides whether throttled calls wait and retry; setting it selects
+	// RateLimitModeCallback (see WithRateLimitCallback)
+	OnRateLimited RateLimitCallback
+	// Sign webhook responses with the webhook secret so the platform can
+	// verify they came from the extension (see SignResponse)
+	SignResponses bool
+	// Persists the mutations handlers queue on HandlerContext.Outbox until
+	// they are applied (see NewMemoryOutboxStore)
+	OutboxStore OutboxStore
 }
 
 // Manifest represents the extension manifest structure.
@@ -93,10 +204,26 @@ type Manifest struct {
 	ID string `yaml:"id"`
 	// Extension version
 	Version string `yaml:"version"`
+	// Human-readable name
+	Name string `yaml:"name,omitempty"`
+	// Short description shown in the marketplace
+	Description string `yaml:"description,omitempty"`
+	// SDK the extension is built with ("go")
+	SDK string `yaml:"sdk,omitempty"`
+	// Version of the SDK that generated the manifest
+	SDKVersion string `yaml:"sdk_version,omitempty"`
 	// Webhook delivery secret
 	DeliverySecret string `yaml:"delivery_secret,omitempty"`
 	// Settings with defaults
 	Settings []ManifestSetting `yaml:"settings,omitempty"`
+	// Event subscriptions
+	Events []ManifestEvent `yaml:"events,omitempty"`
+	// API scopes the extension requires (e.g. "issues:read")
+	Scopes []string `yaml:"scopes,omitempty"`
+	// Webhook endpoints exposed by the extension
+	Endpoints []ManifestEndpoint `yaml:"endpoints,omitempty"`
+	// UI contribution points
+	UI []ManifestUIContribution `yaml:"ui,omitempty"`
 }
 
 // ManifestSetting represents a setting definition in the manifest.
@@ -104,6 +231,41 @@ type ManifestSetting struct {
 	Key     string      `yaml:"key"`
 	Default interface{} `yaml:"default,omitempty"`
 	Secret  bool        `yaml:"secret,omitempty"`
+	// Value type: "string", "integer", "number", "boolean", "duration", or "list"
+	Type string `yaml:"type,omitempty"`
+	// Whether a value must be present at startup (not enforced for secrets)
+	Required bool `yaml:"required,omitempty"`
+	// Allowed values
+	Enum []interface{} `yaml:"enum,omitempty"`
+	// Bounds on the value (numbers) or length (strings and lists)
+	Min *float64 `yaml:"min,omitempty"`
+	Max *float64 `yaml:"max,omitempty"`
+	// Help text shown in the Kiket UI
+	Description string `yaml:"description,omitempty"`
+}
+
+// ManifestEvent represents an event subscription in the manifest. It may be
+// written as a plain event name or as a mapping with versions.
+type ManifestEvent struct {
+	Name     string   `yaml:"name"`
+	Versions []string `yaml:"versions,omitempty"`
+}
+
+// ManifestEndpoint represents a webhook endpoint declared in the manifest.
+type ManifestEndpoint struct {
+	Path   string   `yaml:"path"`
+	Method string   `yaml:"method,omitempty"`
+	Events []string `yaml:"events,omitempty"`
+}
+
+// ManifestUIContribution represents a UI surface contributed by the extension.
+type ManifestUIContribution struct {
+	Key string `yaml:"key"`
+	// Contribution point, e.g. "issue_panel", "project_tab", "dashboard_widget"
+	Location string `yaml:"location"`
+	Title    string `yaml:"title,omitempty"`
+	URL      string `yaml:"url,omitempty"`
+	Icon     string `yaml:"icon,omitempty"`
 }
 
 // TelemetryRecord represents a telemetry entry.
@@ -115,8 +277,11 @@ type TelemetryRecord struct {
 	ErrorMessage     string                 `json:"error_message,omitempty"`
 	ErrorClass       string                 `json:"error_class,omitempty"`
 	Metadata         map[string]interface{} `json:"metadata,omitempty"`
+	Metrics          []Metric               `json:"metrics,omitempty"`
+	Histogram        *DurationHistogram     `json:"histogram,omitempty"`
 	ExtensionID      string                 `json:"extension_id,omitempty"`
 	ExtensionVersion string                 `json:"extension_version,omitempty"`
+	SampleRate       float64                `json:"sample_rate,omitempty"` // set when sampled below 100%
 	Timestamp        time.Time              `json:"timestamp"`
 }
 
@@ -146,23 +311,204 @@ type SecretManager interface {
 	Rotate(ctx context.Context, key string, newValue string) error
 }
 
-// CustomDataClient provides access to custom data operations.
+// KVStore is a small key-value store scoped to the extension and workspace,
+// for state such as sync cursors. Values are stored as JSON.
+type KVStore interface {
+	// Get decodes the value for key into out and reports whether it exists.
+	Get(ctx context.Context, key string, out interface{}) (bool, error)
+	// Set stores value under key; a positive ttl expires it.
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+	// List returns the keys starting with prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// JobsClient schedules jobs on the Kiket side. Due jobs are delivered back
+// as job.due webhooks (see SDK.OnJob).
+type JobsClient interface {
+	Enqueue(ctx context.Context, req JobRequest) (*Job, error)
+	Get(ctx context.Context, jobID interface{}) (*Job, error)
+	Cancel(ctx context.Context, jobID interface{}) error
+}
+
+// MessagesClient publishes and subscribes to named messages exchanged
+// between extensions in the same workspace. Messages on subscribed topics
+// are delivered as message.received webhooks (see SDK.OnMessage). Requires
+// the messages:write scope.
+type MessagesClient interface {
+	// RegisterTopic declares a topic this extension publishes, with an
+	// optional JSON Schema the platform validates payloads against.
+	RegisterTopic(ctx context.Context, topic MessageTopic) error
+	Publish(ctx context.Context, topic string, payload map[string]interface{}) (*Message, error)
+	Subscribe(ctx context.Context, topic string) error
+	Unsubscribe(ctx context.Context, topic string) error
+	Subscriptions(ctx context.Context) ([]string, error)
+}
+
+// StreamingClient is implemented by clients that can hold a response open
+// and hand back its body as it arrives. HTTPClient implements it.
+type StreamingClient interface {
+	Stream(ctx context.Context, path string, opts *RequestOptions) (io.ReadCloser, error)
+}
+
+// EventsClient subscribes to Kiket's real-time event stream. Requires the
+// events:read scope.
+type EventsClient interface {
+	// Subscribe streams events for topics until ctx is done, reconnecting
+	// and resuming after the last received event when the connection drops.
+	// Errors establishing the first connection are returned directly.
+	Subscribe(ctx context.Context, topics []string) (<-chan Event, error)
+}
+
+// ReportsClient triggers and downloads platform report exports. Exports run
+// asynchronously: Export starts one, Wait polls until it completes.
+// Requires the reports:read scope.
+type ReportsClient interface {
+	Export(ctx context.Context, req ReportRequest) (*ReportExport, error)
+	Get(ctx context.Context, exportID interface{}) (*ReportExport, error)
+	// Wait polls every interval (defaults to 2s) until the export completes
+	// or fails.
+	Wait(ctx context.Context, exportID interface{}, interval time.Duration) (*ReportExport, error)
+	// Download writes a completed export's file to w.
+	Download(ctx context.Context, exportID interface{}, w io.Writer) error
+}
+
+// EmailClient sends templated email through Kiket's delivery
+// infrastructure. Exhausted workspace quotas are reported as
+// *EmailRateLimitError. Requires the email:send scope.
+type EmailClient interface {
+	Send(ctx context.Context, message EmailMessage) (*EmailReceipt, error)
+}
+
+// UIClient registers the extension's UI surfaces and pushes dynamic content
+// to them.
+type UIClient interface {
+	List(ctx context.Context) ([]ManifestUIContribution, error)
+	// Register creates or updates a UI contribution.
+	Register(ctx context.Context, contribution ManifestUIContribution) error
+	Remove(ctx context.Context, key string) error
+	// Push updates what a contribution shows for an issue, a project, or
+	// (with an empty target) everywhere.
+	Push(ctx context.Context, key string, target UITarget, content UIContent) error
+}
+
+// CustomDataClient provides access to custom data operations. Reads require
+// the custom_data:read scope, changes custom_data:write.
 type CustomDataClient interface {
 	List(ctx context.Context, moduleKey, table string, opts *CustomDataListOptions) (*CustomDataListResponse, error)
 	Get(ctx context.Context, moduleKey, table string, recordID interface{}) (*CustomDataRecordResponse, error)
 	Create(ctx context.Context, moduleKey, table string, record map[string]interface{}) (*CustomDataRecordResponse, error)
 	Update(ctx context.Context, moduleKey, table string, recordID interface{}, record map[string]interface{}) (*CustomDataRecordResponse, error)
 	Delete(ctx context.Context, moduleKey, table string, recordID interface{}) error
+	// CreateWithPermissions creates a record visible only as permissions
+	// allow, instead of with the table's permissions.
+	CreateWithPermissions(ctx context.Context, moduleKey, table string, record map[string]interface{}, permissions CustomDataPermissions) (*CustomDataRecordResponse, error)
+	// TablePermissions returns the permissions records of a table get by
+	// default.
+	TablePermissions(ctx context.Context, moduleKey, table string) (*CustomDataPermissions, error)
+	// RecordPermissions returns the effective permissions of a record.
+	RecordPermissions(ctx context.Context, moduleKey, table string, recordID interface{}) (*CustomDataPermissions, error)
+	// SetRecordPermissions replaces the permissions of a record.
+	SetRecordPermissions(ctx context.Context, moduleKey, table string, recordID interface{}, permissions CustomDataPermissions) (*CustomDataPermissions, error)
 }
 
-// SLAEventsClient provides access to SLA event operations.
+// SLAEventsClient provides access to SLA event operations. List requires
+// the sla:read scope, Pause and Resume sla:write.
 type SLAEventsClient interface {
 	List(ctx context.Context, opts *SLAEventsListOptions) (*SLAEventsListResponse, error)
+	// Pause stops an issue's SLA timers, e.g. while it waits on a vendor.
+	// Time until Resume does not count against the SLA.
+	Pause(ctx context.Context, issueID interface{}, reason string) (*SLATimer, error)
+	// Resume restarts SLA timers paused with Pause.
+	Resume(ctx context.Context, issueID interface{}) (*SLATimer, error)
+}
+
+// IssuesClient provides access to issue operations. Reads require the
+// issues:read scope, changes issues:write; AddLabel requires labels:write.
+type IssuesClient interface {
+	Get(ctx context.Context, issueID interface{}) (*Issue, error)
+	List(ctx context.Context, opts *IssueListOptions) (*IssueListResponse, error)
+	Create(ctx context.Context, issue IssueInput) (*Issue, error)
+	Update(ctx context.Context, issueID interface{}, issue IssueInput) (*Issue, error)
+	Transition(ctx context.Context, issueID interface{}, state string) (*Issue, error)
+	Assign(ctx context.Context, issueID interface{}, assigneeID interface{}) (*Issue, error)
+	AddLabel(ctx context.Context, issueID interface{}, label string) (*Issue, error)
+	// BulkUpdate applies the same changes to many issues, 100 per request.
+	BulkUpdate(ctx context.Context, issueIDs []interface{}, changes IssueInput) (*BulkResult, error)
+	// BulkTransition moves many issues to a state, 100 per request.
+	BulkTransition(ctx context.Context, issueIDs []interface{}, state string) (*BulkResult, error)
+	// Links lists the links of an issue in both directions.
+	Links(ctx context.Context, issueID interface{}) ([]IssueLink, error)
+	// Link creates a typed link from issueID to targetID.
+	Link(ctx context.Context, issueID interface{}, linkType IssueLinkType, targetID interface{}) (*IssueLink, error)
+	Unlink(ctx context.Context, issueID interface{}, linkID interface{}) error
+}
+
+// LabelsClient manages a project's labels and applies them to issues and the
+// project itself. List requires the labels:read scope, the other methods
+// labels:write.
+type LabelsClient interface {
+	List(ctx context.Context) ([]Label, error)
+	Create(ctx context.Context, label Label) (*Label, error)
+	// FindOrCreate returns the label with the same name (ignoring case),
+	// creating it if it does not exist yet.
+	FindOrCreate(ctx context.Context, label Label) (*Label, error)
+	ApplyToIssue(ctx context.Context, issueID interface{}, names ...string) error
+	RemoveFromIssue(ctx context.Context, issueID interface{}, name string) error
+	ApplyToProject(ctx context.Context, names ...string) error
+	RemoveFromProject(ctx context.Context, name string) error
+}
+
+// WorkflowClient reads an issue's workflow and executes transitions. Get
+// requires the issues:read scope, Transition issues:write.
+type WorkflowClient interface {
+	// Get returns the issue's current state, the workflow's states, and the
+	// transitions available from the current state.
+	Get(ctx context.Context, issueID interface{}) (*IssueWorkflow, error)
+	// Transition executes a transition, passing the fields its screen
+	// requires. Missing or invalid fields are reported as *TransitionError.
+	Transition(ctx context.Context, issueID interface{}, transition string, fields map[string]interface{}) (*Issue, error)
+}
+
+// SearchClient runs platform searches. Requires the search:read scope.
+type SearchClient interface {
+	Query(ctx context.Context, query *SearchQuery) (*SearchResult, error)
+}
+
+// WorklogsClient provides access to time tracking entries. Reads require the
+// worklogs:read scope, changes worklogs:write.
+type WorklogsClient interface {
+	List(ctx context.Context, opts *WorklogListOptions) (*WorklogListResponse, error)
+	Get(ctx context.Context, worklogID interface{}) (*Worklog, error)
+	Create(ctx context.Context, input WorklogInput) (*Worklog, error)
+	Update(ctx context.Context, worklogID interface{}, input WorklogInput) (*Worklog, error)
+	Delete(ctx context.Context, worklogID interface{}) error
+}
+
+// ActivityClient reads the activity stream of issues and projects. Results
+// are newest first; pass NextCursor back as Cursor to fetch older entries.
+// Requires the issues:read or projects:read scope respectively.
+type ActivityClient interface {
+	ForIssue(ctx context.Context, issueID interface{}, opts *ActivityListOptions) (*ActivityListResponse, error)
+	ForProject(ctx context.Context, projectID interface{}, opts *ActivityListOptions) (*ActivityListResponse, error)
+}
+
+// FiltersClient reads and executes the saved filters users maintain in Kiket.
+// Requires the filters:read scope.
+type FiltersClient interface {
+	// List returns the saved filters visible to the extension, optionally
+	// limited to one project (nil for all).
+	List(ctx context.Context, projectID interface{}) ([]SavedFilter, error)
+	// Get resolves a saved filter's definition.
+	Get(ctx context.Context, filterID interface{}) (*SavedFilter, error)
+	// Execute returns the issues currently matching a saved filter.
+	Execute(ctx context.Context, filterID interface{}, limit, page int) (*IssueListResponse, error)
 }
 
 // CustomDataListOptions holds options for listing custom data records.
 type CustomDataListOptions struct {
 	Limit   int
+	Page    int // 1-based; 0 requests the first page
 	Filters map[string]interface{}
 }
 
@@ -171,6 +517,28 @@ type CustomDataListResponse struct {
 	Data []map[string]interface{} `json:"data"`
 }
 
+// Custom data visibility levels.
+const (
+	CustomDataVisibilityExtension  = "extension"  // the extension only (default)
+	CustomDataVisibilityProject    = "project"    // members of the project
+	CustomDataVisibilityWorkspace  = "workspace"  // every workspace member
+	CustomDataVisibilityRestricted = "restricted" // the listed roles and users
+)
+
+// CustomDataPermissions describes who can see and change custom data
+// records, enforced by the platform under the workspace's access rules.
+type CustomDataPermissions struct {
+	Visibility string `json:"visibility"`
+	// Roles and users that can read "restricted" records
+	ReadRoles   []string      `json:"read_roles,omitempty"`
+	ReadUserIDs []interface{} `json:"read_user_ids,omitempty"`
+	// Roles and users that can change records besides the extension
+	WriteRoles   []string      `json:"write_roles,omitempty"`
+	WriteUserIDs []interface{} `json:"write_user_ids,omitempty"`
+	// Where the permissions are set, "table" or "record"; read-only
+	Source string `json:"source,omitempty"`
+}
+
 // CustomDataRecordResponse represents a single custom data record response.
 type CustomDataRecordResponse struct {
 	Data map[string]interface{} `json:"data"`
@@ -200,6 +568,406 @@ type SLAEventsListResponse struct {
 	Data []SLAEventRecord `json:"data"`
 }
 
+// SLATimer represents the SLA timer state of an issue.
+type SLATimer struct {
+	IssueID   interface{} `json:"issue_id"`
+	ProjectID interface{} `json:"project_id"`
+	Paused    bool        `json:"paused"`
+	Reason    string      `json:"reason,omitempty"`
+	PausedAt  *string     `json:"paused_at,omitempty"`
+	// Total paused time, excluded from SLA accounting
+	PausedSeconds int64 `json:"paused_seconds"`
+}
+
+// Core platform resources, generated from the API spec in package models.
+type (
+	Issue       = models.Issue
+	Project     = models.Project
+	User        = models.User
+	Comment     = models.Comment
+	CustomField = models.CustomField
+)
+
+// IssueInput holds the fields for creating or updating an issue. Zero
+// fields are omitted, so an update only changes the fields that are set.
+type IssueInput struct {
+	ProjectID    interface{}            `json:"project_id,omitempty"` // required for Create
+	Title        string                 `json:"title,omitempty"`      // required for Create
+	Description  string                 `json:"description,omitempty"`
+	State        string                 `json:"state,omitempty"`
+	Priority     string                 `json:"priority,omitempty"`
+	IssueType    string                 `json:"issue_type,omitempty"`
+	AssigneeID   interface{}            `json:"assignee_id,omitempty"`
+	Labels       []string               `json:"labels,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+	DueDate      string                 `json:"due_date,omitempty"` // YYYY-MM-DD
+}
+
+// IssueLinkType is the relationship a link expresses, read from source to
+// target.
+type IssueLinkType string
+
+const (
+	LinkBlocks       IssueLinkType = "blocks"
+	LinkBlockedBy    IssueLinkType = "blocked_by"
+	LinkRelatesTo    IssueLinkType = "relates_to"
+	LinkDuplicates   IssueLinkType = "duplicates"
+	LinkDuplicatedBy IssueLinkType = "duplicated_by"
+)
+
+var knownIssueLinkTypes = map[IssueLinkType]bool{
+	LinkBlocks: true, LinkBlockedBy: true, LinkRelatesTo: true,
+	LinkDuplicates: true, LinkDuplicatedBy: true,
+}
+
+// IssueLink represents a typed link between two issues.
+type IssueLink struct {
+	ID        interface{}   `json:"id"`
+	Type      IssueLinkType `json:"type"`
+	SourceID  interface{}   `json:"source_id"`
+	TargetID  interface{}   `json:"target_id"`
+	CreatedAt string        `json:"created_at,omitempty"`
+}
+
+// BulkFailure reports an issue a bulk operation could not change.
+type BulkFailure struct {
+	ID    interface{} `json:"id"`
+	Error string      `json:"error"`
+}
+
+// BulkResult reports the outcome of a bulk operation per issue.
+type BulkResult struct {
+	Succeeded []interface{} `json:"updated"`
+	Failed    []BulkFailure `json:"failed"`
+}
+
+// OK reports whether every issue was changed.
+func (r *BulkResult) OK() bool {
+	return len(r.Failed) == 0
+}
+
+// IssueListOptions holds filters for listing issues.
+type IssueListOptions struct {
+	ProjectID  interface{}
+	State      string
+	AssigneeID interface{}
+	IssueType  string
+	Labels     []string // issues with all of these labels
+	Query      string   // full-text search
+	Limit      int
+	Page       int
+}
+
+// IssueListResponse represents the response from listing issues.
+type IssueListResponse struct {
+	Data []Issue `json:"data"`
+}
+
+// Label represents a project label.
+type Label struct {
+	ID          interface{} `json:"id,omitempty"`
+	Name        string      `json:"name"`
+	Color       string      `json:"color,omitempty"` // hex, e.g. "#d73a4a"
+	Description string      `json:"description,omitempty"`
+}
+
+// WorkflowState represents a workflow state.
+type WorkflowState struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"` // "todo", "in_progress", "done"
+}
+
+// TransitionField describes a field a transition screen asks for.
+type TransitionField struct {
+	Key      string        `json:"key"`
+	Name     string        `json:"name,omitempty"`
+	Type     string        `json:"type,omitempty"`
+	Required bool          `json:"required,omitempty"`
+	Options  []interface{} `json:"options,omitempty"`
+}
+
+// TransitionFieldError reports a problem with one transition field.
+type TransitionFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// WorkflowTransition represents a transition available from a state.
+type WorkflowTransition struct {
+	Key    string            `json:"key"`
+	Name   string            `json:"name"`
+	To     string            `json:"to"`
+	Fields []TransitionField `json:"fields,omitempty"`
+}
+
+// IssueWorkflow describes an issue's position in its workflow.
+type IssueWorkflow struct {
+	State       WorkflowState        `json:"state"`
+	States      []WorkflowState      `json:"states"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+// Transition returns the available transition with the given key or name.
+func (w *IssueWorkflow) Transition(keyOrName string) *WorkflowTransition {
+	for i := range w.Transitions {
+		if w.Transitions[i].Key == keyOrName || w.Transitions[i].Name == keyOrName {
+			return &w.Transitions[i]
+		}
+	}
+	return nil
+}
+
+// SearchHit is a single search result.
+type SearchHit struct {
+	Type       string                 `json:"type"` // entity type, e.g. "issue"
+	ID         interface{}            `json:"id"`
+	Title      string                 `json:"title,omitempty"`
+	URL        string                 `json:"url,omitempty"`
+	Score      float64                `json:"score,omitempty"`
+	Fields     map[string]interface{} `json:"fields,omitempty"`
+	Highlights map[string][]string    `json:"highlights,omitempty"`
+}
+
+// SearchResult represents a page of search hits.
+type SearchResult struct {
+	Hits []SearchHit `json:"data"`
+	Meta struct {
+		Total    int `json:"total"`
+		Page     int `json:"page"`
+		NextPage int `json:"next_page,omitempty"` // 0 on the last page
+	} `json:"meta"`
+}
+
+// Workspace describes the workspace an extension is installed in.
+type Workspace struct {
+	ID       interface{}     `json:"id"`
+	Name     string          `json:"name"`
+	Slug     string          `json:"slug,omitempty"`
+	Plan     string          `json:"plan"`               // e.g. "free", "team", "enterprise"
+	Features map[string]bool `json:"features,omitempty"` // feature flags
+	Locale   string          `json:"locale,omitempty"`   // BCP 47, e.g. "en-US"
+	Timezone string          `json:"timezone,omitempty"` // IANA, e.g. "Europe/Berlin"
+}
+
+// HasFeature reports whether a feature flag is enabled for the workspace.
+func (w *Workspace) HasFeature(name string) bool {
+	return w.Features[name]
+}
+
+// Location returns the workspace timezone, falling back to UTC when it is
+// unset or unknown.
+func (w *Workspace) Location() *time.Location {
+	if w.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Worklog represents time logged against an issue.
+type Worklog struct {
+	ID              interface{} `json:"id"`
+	IssueID         interface{} `json:"issue_id"`
+	UserID          interface{} `json:"user_id"`
+	StartedAt       string      `json:"started_at"`
+	DurationSeconds int64       `json:"duration_seconds"`
+	Description     string      `json:"description,omitempty"`
+	Billable        bool        `json:"billable"`
+	CreatedAt       string      `json:"created_at,omitempty"`
+	UpdatedAt       string      `json:"updated_at,omitempty"`
+}
+
+// Duration returns the logged time as a time.Duration.
+func (w *Worklog) Duration() time.Duration {
+	return time.Duration(w.DurationSeconds) * time.Second
+}
+
+// WorklogInput holds the fields for creating or updating a worklog. Zero
+// fields are not sent.
+type WorklogInput struct {
+	IssueID     interface{} // required for Create
+	UserID      interface{} // defaults to the API key's user
+	StartedAt   time.Time
+	Duration    time.Duration // required for Create; whole seconds are sent
+	Description string
+	Billable    *bool
+}
+
+// WorklogListOptions holds filters for listing worklogs.
+type WorklogListOptions struct {
+	IssueID interface{}
+	UserID  interface{}
+	From    time.Time // started at or after
+	To      time.Time // started before
+	Limit   int
+	Page    int
+}
+
+// WorklogListResponse represents the response from listing worklogs.
+type WorklogListResponse struct {
+	Data []Worklog `json:"data"`
+}
+
+// ActivityChange describes a single field change of an activity entry.
+// Convert a list to FieldChanges for its helpers.
+type ActivityChange = FieldChange
+
+// ActivityEntry is one item of an activity stream: who changed what, when.
+type ActivityEntry struct {
+	ID          interface{}            `json:"id"`
+	Action      string                 `json:"action"` // e.g. "issue.updated", "comment.created"
+	ActorID     interface{}            `json:"actor_id,omitempty"`
+	ActorName   string                 `json:"actor_name,omitempty"`
+	SubjectType string                 `json:"subject_type"`
+	SubjectID   interface{}            `json:"subject_id"`
+	Changes     []ActivityChange       `json:"changes,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	OccurredAt  string                 `json:"occurred_at"`
+}
+
+// ActivityListOptions holds filters for reading an activity stream.
+type ActivityListOptions struct {
+	Since   time.Time
+	Until   time.Time
+	Actions []string
+	ActorID interface{}
+	Limit   int
+	Cursor  string // NextCursor from the previous page
+}
+
+// ActivityListResponse represents a page of activity entries.
+type ActivityListResponse struct {
+	Data       []ActivityEntry `json:"data"`
+	NextCursor string          `json:"next_cursor,omitempty"` // empty on the last page
+}
+
+// SavedFilter represents a saved issue filter (view).
+type SavedFilter struct {
+	ID          interface{}            `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	ProjectID   interface{}            `json:"project_id,omitempty"` // nil for cross-project filters
+	OwnerID     interface{}            `json:"owner_id,omitempty"`
+	Shared      bool                   `json:"shared"`
+	Query       string                 `json:"query,omitempty"`    // query text as entered by the user
+	Criteria    map[string]interface{} `json:"criteria,omitempty"` // structured conditions
+	Sort        string                 `json:"sort,omitempty"`
+}
+
+// JobRequest describes a job to schedule. Set at most one of RunAt, Delay,
+// and Cron; with none set the job is due immediately.
+type JobRequest struct {
+	// Job name used to route the job.due delivery
+	Name    string
+	Payload map[string]interface{}
+	RunAt   time.Time
+	Delay   time.Duration
+	// Cron expression for recurring jobs, e.g. "0 9 * * MON"
+	Cron string
+	// Deduplicates jobs: enqueueing an existing key replaces the pending job
+	UniqueKey string
+}
+
+// Job represents a scheduled job.
+type Job struct {
+	ID        interface{}            `json:"id"`
+	Name      string                 `json:"name"`
+	Status    string                 `json:"status"` // "scheduled", "running", "done", "failed", "cancelled"
+	RunAt     string                 `json:"run_at,omitempty"`
+	Cron      string                 `json:"cron,omitempty"`
+	Payload   map[string]interface{} `json:"payload,omitempty"`
+	Attempts  int                    `json:"attempts,omitempty"`
+	CreatedAt string                 `json:"created_at,omitempty"`
+}
+
+// MessageTopic declares a message topic.
+type MessageTopic struct {
+	Name        string                 `json:"name"` // e.g. "crm.contact_synced"
+	Description string                 `json:"description,omitempty"`
+	Schema      map[string]interface{} `json:"schema,omitempty"` // JSON Schema for payloads
+}
+
+// Message is a message published by an extension.
+type Message struct {
+	ID                interface{}            `json:"id"`
+	Topic             string                 `json:"topic"`
+	SourceExtensionID string                 `json:"source_extension_id,omitempty"`
+	Payload           map[string]interface{} `json:"payload"`
+	PublishedAt       string                 `json:"published_at,omitempty"`
+}
+
+// ReportRequest describes a report export.
+type ReportRequest struct {
+	Report    string       // e.g. "issues", "worklogs", "sla", "velocity"
+	Format    ReportFormat // defaults to CSV
+	ProjectID interface{}  // nil for the whole workspace
+	From      time.Time
+	To        time.Time
+	Filters   map[string]interface{}
+}
+
+// ReportExport represents an asynchronous report export.
+type ReportExport struct {
+	ID          interface{}  `json:"id"`
+	Report      string       `json:"report"`
+	Format      ReportFormat `json:"format"`
+	Status      string       `json:"status"` // ReportPending, ReportRunning, ReportCompleted, ReportFailed
+	RowCount    int          `json:"row_count,omitempty"`
+	Error       string       `json:"error,omitempty"`
+	CreatedAt   string       `json:"created_at,omitempty"`
+	CompletedAt string       `json:"completed_at,omitempty"`
+}
+
+// EmailRecipient is a Kiket user or a raw address (see EmailToUser and
+// EmailToAddress).
+type EmailRecipient struct {
+	UserID  interface{} `json:"user_id,omitempty"`
+	Address string      `json:"address,omitempty"`
+	Name    string      `json:"name,omitempty"`
+}
+
+// EmailMessage describes a templated email.
+type EmailMessage struct {
+	// Template key registered for the extension, e.g. "digest.weekly"
+	Template  string                 `json:"template"`
+	To        []EmailRecipient       `json:"to"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+	// Overrides the template subject
+	Subject string `json:"subject,omitempty"`
+	ReplyTo string `json:"reply_to,omitempty"`
+}
+
+// EmailReceipt confirms an email was accepted for delivery.
+type EmailReceipt struct {
+	ID       interface{} `json:"id"`
+	Status   string      `json:"status"` // "queued"
+	Accepted int         `json:"accepted"`
+	Rejected []string    `json:"rejected,omitempty"` // addresses suppressed by the platform
+	// Remaining quota after this send, when reported
+	RateLimit *RateLimitInfo `json:"-"`
+}
+
+// UITarget selects where pushed UI content applies.
+type UITarget struct {
+	IssueID   interface{}
+	ProjectID interface{}
+}
+
+// UIContent is dynamic content for a UI contribution.
+type UIContent struct {
+	Title string `json:"title,omitempty"`
+	// Short status shown on collapsed surfaces, e.g. "3 open"
+	Badge string `json:"badge,omitempty"`
+	// Structured data rendered by the extension frontend
+	Data map[string]interface{} `json:"data,omitempty"`
+	// Arbitrary state persisted for the frontend
+	State map[string]interface{} `json:"state,omitempty"`
+}
+
 // RateLimitInfo contains rate limit metadata.
 type RateLimitInfo struct {
 	Limit         int `json:"limit"`
@@ -210,7 +978,8 @@ type RateLimitInfo struct {
 
 // HandlerMetadata holds information about a registered handler.
 type HandlerMetadata struct {
-	Event   string
-	Version string
-	Handler WebhookHandler
+	Event      string
+	Version    string
+	Handler    WebhookHandler
+	RawHandler RawWebhookHandler // set instead of Handler by SDK.OnRaw
 }
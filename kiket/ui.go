@@ -0,0 +1,117 @@
+package kiket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// uiClient implements the UIClient interface.
+type uiClient struct {
+	client      Client
+	extensionID string
+	basePath    string
+}
+
+// NewUIClient creates a new UI contributions client.
+func NewUIClient(client Client, extensionID string) UIClient {
+	return &uiClient{
+		client:      client,
+		extensionID: extensionID,
+		basePath:    extensionPath(extensionID) + "/ui",
+	}
+}
+
+func (c *uiClient) path(key string) string {
+	if key == "" {
+		return c.basePath
+	}
+	return joinPath(c.basePath, key)
+}
+
+func (c *uiClient) List(ctx context.Context) ([]ManifestUIContribution, error) {
+	if c.extensionID == "" {
+		return nil, errors.New("extension ID required for UI operations")
+	}
+
+	resp, err := c.client.Get(ctx, c.path(""), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeData[[]ManifestUIContribution](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *uiClient) Register(ctx context.Context, contribution ManifestUIContribution) error {
+	if c.extensionID == "" {
+		return errors.New("extension ID required for UI operations")
+	}
+	if contribution.Key == "" || contribution.Location == "" {
+		return errors.New("UI contribution key and location are required")
+	}
+
+	_, err := c.client.Put(ctx, c.path(contribution.Key), map[string]interface{}{
+		"contribution": map[string]string{
+			"key":      contribution.Key,
+			"location": contribution.Location,
+			"title":    contribution.Title,
+			"url":      contribution.URL,
+			"icon":     contribution.Icon,
+		},
+	}, nil)
+	return err
+}
+
+func (c *uiClient) Remove(ctx context.Context, key string) error {
+	if c.extensionID == "" {
+		return errors.New("extension ID required for UI operations")
+	}
+
+	_, err := c.client.Delete(ctx, c.path(key), nil)
+	return err
+}
+
+func (c *uiClient) Push(ctx context.Context, key string, target UITarget, content UIContent) error {
+	if c.extensionID == "" {
+		return errors.New("extension ID required for UI operations")
+	}
+	if key == "" {
+		return errors.New("UI contribution key is required")
+	}
+
+	body := map[string]interface{}{"content": content}
+	if target.IssueID != nil {
+		body["issue_id"] = target.IssueID
+	}
+	if target.ProjectID != nil {
+		body["project_id"] = target.ProjectID
+	}
+
+	_, err := c.client.Post(ctx, c.path(key)+"/content", body, nil)
+	return err
+}
+
+// RegisterUI registers every UI contribution declared in the manifest,
+// creating or updating them.
+func (s *SDK) RegisterUI(ctx context.Context) error {
+	s.settingsMu.RLock()
+	manifest := s.manifest
+	s.settingsMu.RUnlock()
+
+	if manifest == nil {
+		return nil
+	}
+
+	ui := s.endpoints.UI()
+	for _, contribution := range manifest.UI {
+		if err := ui.Register(ctx, contribution); err != nil {
+			return fmt.Errorf("failed to register UI contribution %s: %w", contribution.Key, err)
+		}
+	}
+	return nil
+}
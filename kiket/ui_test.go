@@ -0,0 +1,114 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestUIClient_BuildsRequests(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body map[string]interface{}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			json.Unmarshal(data, &body)
+		}
+		bodies = append(bodies, body)
+		w.Write([]byte(`{"data": [{"key": "sync-panel", "location": "issue_panel", "title": "Sync", "url": "/panel"}]}`))
+	}))
+	defer server.Close()
+
+	ui := NewUIClient(NewHTTPClient(WithBaseURL(server.URL)), "com.example.ext")
+	ctx := context.Background()
+
+	contributions, err := ui.List(ctx)
+	expectedList := []ManifestUIContribution{{Key: "sync-panel", Location: "issue_panel", Title: "Sync", URL: "/panel"}}
+	if err != nil || !reflect.DeepEqual(contributions, expectedList) {
+		t.Fatalf("Unexpected contributions %+v (%v)", contributions, err)
+	}
+	if err := ui.Register(ctx, ManifestUIContribution{Key: "sync-panel", Location: "issue_panel", Title: "Sync", URL: "/panel", Icon: "refresh"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := ui.Push(ctx, "sync-panel", UITarget{IssueID: 42}, UIContent{Badge: "3 open", Data: map[string]interface{}{"open": 3}}); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if err := ui.Push(ctx, "sync-panel", UITarget{}, UIContent{Title: "Synced"}); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if err := ui.Remove(ctx, "sync-panel"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+
+	expected := []string{
+		"GET /api/v1/extensions/com.example.ext/ui",
+		"PUT /api/v1/extensions/com.example.ext/ui/sync-panel",
+		"POST /api/v1/extensions/com.example.ext/ui/sync-panel/content",
+		"POST /api/v1/extensions/com.example.ext/ui/sync-panel/content",
+		"DELETE /api/v1/extensions/com.example.ext/ui/sync-panel",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+	register := map[string]interface{}{"contribution": map[string]interface{}{
+		"key": "sync-panel", "location": "issue_panel", "title": "Sync", "url": "/panel", "icon": "refresh",
+	}}
+	if !reflect.DeepEqual(bodies[1], register) {
+		t.Errorf("Expected body %v, got %v", register, bodies[1])
+	}
+	issuePush := map[string]interface{}{
+		"content":  map[string]interface{}{"badge": "3 open", "data": map[string]interface{}{"open": float64(3)}},
+		"issue_id": float64(42),
+	}
+	if !reflect.DeepEqual(bodies[2], issuePush) {
+		t.Errorf("Expected body %v, got %v", issuePush, bodies[2])
+	}
+	globalPush := map[string]interface{}{"content": map[string]interface{}{"title": "Synced"}}
+	if !reflect.DeepEqual(bodies[3], globalPush) {
+		t.Errorf("Expected an untargeted push to omit issue and project IDs, got %v", bodies[3])
+	}
+
+	if err := ui.Register(ctx, ManifestUIContribution{Key: "sync-panel"}); err == nil {
+		t.Error("Expected error for a contribution without a location")
+	}
+	if err := NewUIClient(NewHTTPClient(WithBaseURL(server.URL)), "").Remove(ctx, "sync-panel"); err == nil {
+		t.Error("Expected error without an extension ID")
+	}
+}
+
+func TestSDK_RegisterUIRegistersManifestContributions(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	sdk, err := New(Config{
+		ExtensionAPIKey: "key",
+		WebhookSecret:   "secret",
+		ExtensionID:     "com.example.ext",
+		BaseURL:         server.URL,
+		ManifestPath:    writeTestManifest(t, "extension.yaml", "id: com.example.ext\nversion: 1.0.0\nui:\n  - key: sync-panel\n    location: issue_panel\n  - key: overview\n    location: project_tab\n"),
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sdk.Close()
+
+	if err := sdk.RegisterUI(context.Background()); err != nil {
+		t.Fatalf("RegisterUI failed: %v", err)
+	}
+	expected := []string{
+		"PUT /api/v1/extensions/com.example.ext/ui/sync-panel",
+		"PUT /api/v1/extensions/com.example.ext/ui/overview",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+}
@@ -0,0 +1,254 @@
+package kiket
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ValidationSeverity indicates whether a validation issue is fatal.
+type ValidationSeverity string
+
+const (
+	SeverityError   ValidationSeverity = "error"
+	SeverityWarning ValidationSeverity = "warning"
+)
+
+// ValidationIssue describes a problem found in a manifest or configuration.
+type ValidationIssue struct {
+	Severity ValidationSeverity
+	// Manifest field the issue refers to, e.g. "settings[2].key"
+	Field   string
+	Message string
+}
+
+func (i ValidationIssue) Error() string {
+	return fmt.Sprintf("%s: %s: %s", i.Severity, i.Field, i.Message)
+}
+
+// ValidationError is returned when a manifest or its settings have
+// error-severity issues.
+type ValidationError struct {
+	Issues []ValidationIssue
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		msgs = append(msgs, issue.Field+": "+issue.Message)
+	}
+	return "invalid configuration: " + strings.Join(msgs, "; ")
+}
+
+// KnownEvents lists the event names documented by the Kiket platform.
+// Subscriptions to other names produce a validation warning.
+var KnownEvents = []string{
+	"issue.created",
+	"issue.updated",
+	"issue.status_changed",
+	"issue.assigned",
+	"issue.closed",
+	"workflow.triggered",
+	"workflow.sla_status",
+	"workflow.before_transition",
+	"comment.created",
+	"job.due",
+	"message.received",
+}
+
+var semverPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// ValidateManifest checks a manifest for missing required fields, malformed
+// versions, duplicate settings, unknown events, and secrets without a value.
+func ValidateManifest(m *Manifest) []ValidationIssue {
+	if m == nil {
+		return []ValidationIssue{{Severity: SeverityError, Field: "manifest", Message: "manifest is nil"}}
+	}
+
+	var issues []ValidationIssue
+	add := func(severity ValidationSeverity, field, format string, args ...interface{}) {
+		issues = append(issues, ValidationIssue{Severity: severity, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if m.ID == "" {
+		add(SeverityError, "id", "is required (e.g. com.example.my-extension)")
+	}
+	if m.Version == "" {
+		add(SeverityError, "version", "is required (e.g. 1.0.0)")
+	} else if !semverPattern.MatchString(m.Version) {
+		add(SeverityWarning, "version", "%q is not a semantic version (MAJOR.MINOR.PATCH)", m.Version)
+	}
+
+	seen := make(map[string]int)
+	for i, setting := range m.Settings {
+		field := fmt.Sprintf("settings[%d].key", i)
+		if setting.Key == "" {
+			add(SeverityError, field, "is required")
+			continue
+		}
+		if first, ok := seen[setting.Key]; ok {
+			add(SeverityError, field, "duplicate setting %q (first defined at settings[%d])", setting.Key, first)
+			continue
+		}
+		seen[setting.Key] = i
+
+		if setting.Secret && setting.Default == nil {
+			envKey := "KIKET_SECRET_" + toUpperSnake(setting.Key)
+			if os.Getenv(envKey) == "" {
+				add(SeverityWarning, field, "secret %q has no value; set %s or configure it in Kiket", setting.Key, envKey)
+			}
+		}
+	}
+
+	known := make(map[string]bool, len(KnownEvents))
+	for _, e := range KnownEvents {
+		known[e] = true
+	}
+	for i, event := range m.Events {
+		field := fmt.Sprintf("events[%d]", i)
+		if event.Name == "" {
+			add(SeverityError, field, "event name is required")
+		} else if !known[event.Name] {
+			add(SeverityWarning, field, "unknown event %q", event.Name)
+		}
+	}
+
+	for i, setting := range m.Settings {
+		field := fmt.Sprintf("settings[%d]", i)
+		if setting.Type != "" && !knownSettingTypes[setting.Type] {
+			add(SeverityError, field+".type", "unknown type %q", setting.Type)
+		}
+		if setting.Min != nil && setting.Max != nil && *setting.Min > *setting.Max {
+			add(SeverityError, field, "min %v is greater than max %v", *setting.Min, *setting.Max)
+		}
+		if setting.Default != nil {
+			if msg := checkSettingValue(setting, setting.Default); msg != "" {
+				add(SeverityError, field+".default", "%s", msg)
+			}
+		}
+	}
+
+	for i, endpoint := range m.Endpoints {
+		if !strings.HasPrefix(endpoint.Path, "/") {
+			add(SeverityError, fmt.Sprintf("endpoints[%d].path", i), "must start with /")
+		}
+	}
+
+	return issues
+}
+
+var knownSettingTypes = map[string]bool{
+	"string":   true,
+	"integer":  true,
+	"number":   true,
+	"boolean":  true,
+	"duration": true,
+	"list":     true,
+}
+
+// ValidateSettings checks effective settings against the manifest's setting
+// schema: required values, types, enums, and min/max bounds. Settings not
+// declared in the manifest are ignored.
+func ValidateSettings(m *Manifest, settings Settings) []ValidationIssue {
+	if m == nil {
+		return nil
+	}
+
+	var issues []ValidationIssue
+	for _, setting := range m.Settings {
+		field := "settings." + setting.Key
+		value, ok := settings[setting.Key]
+		if !ok || value == nil || value == "" {
+			if setting.Required && !setting.Secret {
+				issues = append(issues, ValidationIssue{
+					Severity: SeverityError,
+					Field:    field,
+					Message:  "is required but has no value",
+				})
+			}
+			continue
+		}
+
+		if msg := checkSettingValue(setting, value); msg != "" {
+			issues = append(issues, ValidationIssue{Severity: SeverityError, Field: field, Message: msg})
+		}
+	}
+
+	return issues
+}
+
+// checkSettingValue validates a single value against its definition and
+// returns a description of the problem, or "" when it is valid.
+func checkSettingValue(setting ManifestSetting, value interface{}) string {
+	probe := Settings{"v": value}
+
+	var size float64
+	var sized bool
+	switch setting.Type {
+	case "integer":
+		if _, ok := toInt64(value); !ok {
+			return fmt.Sprintf("expected an integer, got %v", value)
+		}
+		size, sized = probe.GetFloat("v", 0), true
+	case "number":
+		f, ok := toFloat64(value)
+		if !ok {
+			return fmt.Sprintf("expected a number, got %v", value)
+		}
+		size, sized = f, true
+	case "boolean":
+		if probe.GetBool("v", true) != probe.GetBool("v", false) {
+			return fmt.Sprintf("expected a boolean, got %v", value)
+		}
+	case "duration":
+		if probe.GetDuration("v", -1) == -1 {
+			return fmt.Sprintf("expected a duration such as \"30s\", got %v", value)
+		}
+	case "list":
+		list := probe.GetStringSlice("v", nil)
+		if list == nil {
+			return fmt.Sprintf("expected a list, got %v", value)
+		}
+		size, sized = float64(len(list)), true
+	case "string", "":
+		if str, ok := value.(string); ok {
+			size, sized = float64(len(str)), true
+		}
+	}
+
+	if len(setting.Enum) > 0 {
+		allowed := make([]string, 0, len(setting.Enum))
+		match := false
+		for _, option := range setting.Enum {
+			allowed = append(allowed, fmt.Sprint(option))
+			if fmt.Sprint(option) == fmt.Sprint(value) {
+				match = true
+			}
+		}
+		if !match {
+			return fmt.Sprintf("%v is not one of [%s]", value, strings.Join(allowed, ", "))
+		}
+	}
+
+	if sized && setting.Min != nil && size < *setting.Min {
+		return fmt.Sprintf("%v is below the minimum of %v", value, *setting.Min)
+	}
+	if sized && setting.Max != nil && size > *setting.Max {
+		return fmt.Sprintf("%v is above the maximum of %v", value, *setting.Max)
+	}
+
+	return ""
+}
+
+// splitIssues separates error-severity issues from warnings.
+func splitIssues(issues []ValidationIssue) (errs, warnings []ValidationIssue) {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			errs = append(errs, issue)
+		} else {
+			warnings = append(warnings, issue)
+		}
+	}
+	return errs, warnings
+}
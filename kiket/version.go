@@ -0,0 +1,4 @@
+package kiket
+
+// SDKVersion is the version of this SDK.
+const SDKVersion = "0.1.0"
@@ -0,0 +1,98 @@
+package kiket
+
+import (
+	"strconv"
+	"strings"
+)
+
+// handlerVersions returns the versions passed to On and OnRaw, defaulting
+// to v1.
+func handlerVersions(versions []string) []string {
+	if len(versions) == 0 {
+		return []string{"v1"}
+	}
+	return versions
+}
+
+// isVersionRange reports whether a handler version is a range such as
+// ">=v2" or ">=v2,<v4" rather than a single version.
+func isVersionRange(version string) bool {
+	return strings.ContainsAny(version, "<>=")
+}
+
+// versionInRange reports whether version satisfies every comma-separated
+// comparison in spec, e.g. ">=v2,<v4". Versions are compared numerically
+// by their dot-separated parts ("v2.1" < "v10"). An unparsable version or
+// spec never matches.
+func versionInRange(version, spec string) bool {
+	v, ok := parseEventVersion(version)
+	if !ok {
+		return false
+	}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		op := part[:len(part)-len(strings.TrimLeft(part, "<>="))]
+		bound, ok := parseEventVersion(strings.TrimSpace(part[len(op):]))
+		if !ok {
+			return false
+		}
+		cmp := compareEventVersions(v, bound)
+		switch op {
+		case ">=":
+			ok = cmp >= 0
+		case ">":
+			ok = cmp > 0
+		case "<=":
+			ok = cmp <= 0
+		case "<":
+			ok = cmp < 0
+		case "=", "":
+			ok = cmp == 0
+		default:
+			ok = false
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// parseEventVersion splits a version such as "v2" or "v2.1" into its
+// numeric parts.
+func parseEventVersion(version string) ([]int, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if version == "" {
+		return nil, false
+	}
+	parts := strings.Split(version, ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		numbers[i] = n
+	}
+	return numbers, true
+}
+
+// compareEventVersions returns -1, 0, or 1; missing parts count as zero.
+func compareEventVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
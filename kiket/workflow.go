@@ -0,0 +1,112 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// workflowClient implements the WorkflowClient interface.
+type workflowClient struct {
+	client Client
+}
+
+// NewWorkflowClient creates a new workflow client.
+func NewWorkflowClient(client Client) WorkflowClient {
+	return &workflowClient{client: client}
+}
+
+func (c *workflowClient) Get(ctx context.Context, issueID interface{}) (*IssueWorkflow, error) {
+	if issueID == nil || issueID == "" {
+		return nil, errors.New("issue ID is required")
+	}
+
+	path := joinPath(issuesPath, issueID, "workflow")
+	resp, err := c.client.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeData[IssueWorkflow](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *workflowClient) Transition(ctx context.Context, issueID interface{}, transition string, fields map[string]interface{}) (*Issue, error) {
+	if issueID == nil || issueID == "" {
+		return nil, errors.New("issue ID is required")
+	}
+	if transition == "" {
+		return nil, errors.New("transition is required")
+	}
+
+	body := map[string]interface{}{}
+	if len(fields) > 0 {
+		body["fields"] = fields
+	}
+
+	path := joinPath(issuesPath, issueID, "transitions", transition)
+	resp, err := c.client.Post(ctx, path, body, nil)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnprocessableEntity {
+			return nil, parseTransitionError(transition, apiErr)
+		}
+		return nil, err
+	}
+	return parseIssue(resp)
+}
+
+// parseTransitionError converts a 422 response into a *TransitionError. The
+// body is expected to look like:
+//
+//	{"errors": [{"field": "resolution", "message": "is required"}],
+//	 "required_fields": [{"key": "resolution", "name": "Resolution", "type": "select"}]}
+func parseTransitionError(transition string, apiErr *APIError) error {
+	var body struct {
+		Errors         []TransitionFieldError `json:"errors"`
+		RequiredFields []TransitionField      `json:"required_fields"`
+	}
+	if err := json.Unmarshal([]byte(apiErr.Body), &body); err != nil {
+		return apiErr
+	}
+
+	return &TransitionError{
+		Transition:     transition,
+		FieldErrors:    body.Errors,
+		RequiredFields: body.RequiredFields,
+		Err:            apiErr,
+	}
+}
+
+// TransitionError is returned when a transition is rejected because its
+// screen requires fields that were missing or invalid.
+type TransitionError struct {
+	Transition     string
+	FieldErrors    []TransitionFieldError
+	RequiredFields []TransitionField
+	Err            *APIError
+}
+
+func (e *TransitionError) Error() string {
+	parts := make([]string, 0, len(e.FieldErrors))
+	for _, fieldErr := range e.FieldErrors {
+		parts = append(parts, fieldErr.Field+" "+fieldErr.Message)
+	}
+	if len(parts) == 0 {
+		for _, field := range e.RequiredFields {
+			parts = append(parts, field.Key+" is required")
+		}
+	}
+	return fmt.Sprintf("transition %q rejected: %s", e.Transition, strings.Join(parts, "; "))
+}
+
+func (e *TransitionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,116 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestWorkflowClient_BuildsRequests(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		var body map[string]interface{}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			json.Unmarshal(data, &body)
+		}
+		bodies = append(bodies, body)
+
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"data": {
+				"state": {"key": "open", "name": "Open", "category": "todo"},
+				"states": [{"key": "open", "name": "Open"}, {"key": "done", "name": "Done", "category": "done"}],
+				"transitions": [{"key": "resolve", "name": "Resolve", "to": "done", "fields": [{"key": "resolution", "type": "select", "required": true, "options": ["fixed"]}]}]
+			}}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"id": 42, "key": "OPS-42", "state": "done"}}`))
+	}))
+	defer server.Close()
+
+	workflow := NewWorkflowClient(NewHTTPClient(WithBaseURL(server.URL)))
+	ctx := context.Background()
+
+	current, err := workflow.Get(ctx, 42)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if current.State.Key != "open" || current.State.Category != "todo" || len(current.States) != 2 {
+		t.Errorf("Unexpected states %+v", current)
+	}
+	resolve := current.Transition("Resolve")
+	expected := &WorkflowTransition{
+		Key:    "resolve",
+		Name:   "Resolve",
+		To:     "done",
+		Fields: []TransitionField{{Key: "resolution", Type: "select", Required: true, Options: []interface{}{"fixed"}}},
+	}
+	if !reflect.DeepEqual(resolve, expected) {
+		t.Errorf("Expected transition %+v, got %+v", expected, resolve)
+	}
+	if current.Transition("reopen") != nil {
+		t.Error("Expected no transition for an unknown key")
+	}
+
+	issue, err := workflow.Transition(ctx, 42, "resolve", map[string]interface{}{"resolution": "fixed"})
+	if err != nil || issue.Key != "OPS-42" || issue.State != "done" {
+		t.Fatalf("Unexpected issue %+v (%v)", issue, err)
+	}
+	if _, err := workflow.Transition(ctx, "OPS-42", "won't fix", nil); err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+
+	expectedRequests := []string{
+		"GET /api/v1/ext/issues/42/workflow",
+		"POST /api/v1/ext/issues/42/transitions/resolve",
+		"POST /api/v1/ext/issues/OPS-42/transitions/won%27t%20fix",
+	}
+	if !reflect.DeepEqual(requests, expectedRequests) {
+		t.Errorf("Expected requests %v, got %v", expectedRequests, requests)
+	}
+	if fields, _ := bodies[1]["fields"].(map[string]interface{}); fields["resolution"] != "fixed" {
+		t.Errorf("Expected the transition fields, got %v", bodies[1])
+	}
+	if len(bodies[2]) != 0 {
+		t.Errorf("Expected an empty body without fields, got %v", bodies[2])
+	}
+
+	if _, err := workflow.Get(ctx, ""); err == nil {
+		t.Error("Expected error when issue ID is missing")
+	}
+	if _, err := workflow.Transition(ctx, 42, "", nil); err == nil {
+		t.Error("Expected error when transition is missing")
+	}
+}
+
+func TestWorkflowClient_TransitionReportsRequiredFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/ext/issues/42/transitions/resolve" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"errors": [{"field": "resolution", "message": "is required"}], "required_fields": [{"key": "resolution", "type": "select"}]}`))
+	}))
+	defer server.Close()
+
+	workflow := NewWorkflowClient(NewHTTPClient(WithBaseURL(server.URL)))
+	_, err := workflow.Transition(context.Background(), 42, "resolve", nil)
+
+	var transitionErr *TransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("Expected *TransitionError, got %v", err)
+	}
+	if len(transitionErr.RequiredFields) != 1 || transitionErr.RequiredFields[0].Key != "resolution" {
+		t.Errorf("Expected resolution to be required, got %+v", transitionErr.RequiredFields)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected wrapped 422 APIError, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const worklogsPath = "/api/v1/ext/worklogs"
+
+// worklogsClient implements the WorklogsClient interface.
+type worklogsClient struct {
+	client Client
+}
+
+// NewWorklogsClient creates a new worklogs client.
+func NewWorklogsClient(client Client) WorklogsClient {
+	return &worklogsClient{client: client}
+}
+
+func (c *worklogsClient) buildParams(opts *WorklogListOptions) map[string]string {
+	params := map[string]string{}
+	if opts == nil {
+		return params
+	}
+
+	if opts.IssueID != nil {
+		params["issue_id"] = fmt.Sprintf("%v", opts.IssueID)
+	}
+	if opts.UserID != nil {
+		params["user_id"] = fmt.Sprintf("%v", opts.UserID)
+	}
+	if !opts.From.IsZero() {
+		params["from"] = opts.From.UTC().Format(time.RFC3339)
+	}
+	if !opts.To.IsZero() {
+		params["to"] = opts.To.UTC().Format(time.RFC3339)
+	}
+	if opts.Limit > 0 {
+		params["limit"] = strconv.Itoa(opts.Limit)
+	}
+	if opts.Page > 0 {
+		params["page"] = strconv.Itoa(opts.Page)
+	}
+
+	return params
+}
+
+// buildBody converts the input to the API representation; only set fields
+// are sent.
+func (c *worklogsClient) buildBody(input WorklogInput) map[string]interface{} {
+	worklog := map[string]interface{}{}
+	if input.IssueID != nil {
+		worklog["issue_id"] = input.IssueID
+	}
+	if input.UserID != nil {
+		worklog["user_id"] = input.UserID
+	}
+	if !input.StartedAt.IsZero() {
+		worklog["started_at"] = input.StartedAt.UTC().Format(time.RFC3339)
+	}
+	if input.Duration > 0 {
+		worklog["duration_seconds"] = int64(input.Duration / time.Second)
+	}
+	if input.Description != "" {
+		worklog["description"] = input.Description
+	}
+	if input.Billable != nil {
+		worklog["billable"] = *input.Billable
+	}
+	return map[string]interface{}{"worklog": worklog}
+}
+
+func parseWorklog(resp []byte) (*Worklog, error) {
+	result, err := decodeData[Worklog](resp)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *worklogsClient) List(ctx context.Context, opts *WorklogListOptions) (*WorklogListResponse, error) {
+	resp, err := c.client.Get(ctx, worklogsPath, &RequestOptions{
+		Params: c.buildParams(opts),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result WorklogListResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
+
+func (c *worklogsClient) Get(ctx context.Context, worklogID interface{}) (*Worklog, error) {
+	if worklogID == nil || worklogID == "" {
+		return nil, errors.New("worklog ID is required")
+	}
+
+	resp, err := c.client.Get(ctx, joinPath(worklogsPath, worklogID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseWorklog(resp)
+}
+
+func (c *worklogsClient) Create(ctx context.Context, input WorklogInput) (*Worklog, error) {
+	if input.IssueID == nil || input.IssueID == "" {
+		return nil, errors.New("issue ID is required to log work")
+	}
+	if input.Duration <= 0 {
+		return nil, errors.New("worklog duration must be positive")
+	}
+
+	resp, err := c.client.Post(ctx, worklogsPath, c.buildBody(input), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseWorklog(resp)
+}
+
+func (c *worklogsClient) Update(ctx context.Context, worklogID interface{}, input WorklogInput) (*Worklog, error) {
+	if worklogID == nil || worklogID == "" {
+		return nil, errors.New("worklog ID is required")
+	}
+
+	resp, err := c.client.Patch(ctx, joinPath(worklogsPath, worklogID), c.buildBody(input), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseWorklog(resp)
+}
+
+func (c *worklogsClient) Delete(ctx context.Context, worklogID interface{}) error {
+	if worklogID == nil || worklogID == "" {
+		return errors.New("worklog ID is required")
+	}
+
+	_, err := c.client.Delete(ctx, joinPath(worklogsPath, worklogID), nil)
+	return err
+}
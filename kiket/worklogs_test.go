@@ -0,0 +1,94 @@
+package kiket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWorklogsClient_BuildsRequests(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		var body map[string]interface{}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			json.Unmarshal(data, &body)
+		}
+		bodies = append(bodies, body)
+
+		if r.Method == http.MethodGet && r.URL.Path == "/api/v1/ext/worklogs" {
+			w.Write([]byte(`{"data": [{"id": 1, "issue_id": 42, "duration_seconds": 1800}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"id": 5, "issue_id": 42, "user_id": 9, "started_at": "2024-03-01T09:00:00Z", "duration_seconds": 5400, "billable": true}}`))
+	}))
+	defer server.Close()
+
+	worklogs := NewWorklogsClient(NewHTTPClient(WithBaseURL(server.URL)))
+	ctx := context.Background()
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	list, err := worklogs.List(ctx, &WorklogListOptions{IssueID: 42, From: from, Limit: 50})
+	if err != nil || len(list.Data) != 1 || list.Data[0].Duration() != 30*time.Minute {
+		t.Fatalf("Unexpected list result %+v (%v)", list, err)
+	}
+
+	billable := true
+	worklog, err := worklogs.Create(ctx, WorklogInput{
+		IssueID:     42,
+		StartedAt:   time.Date(2024, 3, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600)),
+		Duration:    90*time.Minute + 500*time.Millisecond,
+		Description: "Pairing",
+		Billable:    &billable,
+	})
+	if err != nil || worklog.Duration() != 90*time.Minute || !worklog.Billable {
+		t.Fatalf("Unexpected create result %+v (%v)", worklog, err)
+	}
+	if _, err := worklogs.Get(ctx, 5); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if _, err := worklogs.Update(ctx, 5, WorklogInput{Description: "Pairing on CI"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := worklogs.Delete(ctx, 5); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	expected := []string{
+		"GET /api/v1/ext/worklogs?from=2024-03-01T00%3A00%3A00Z&issue_id=42&limit=50",
+		"POST /api/v1/ext/worklogs",
+		"GET /api/v1/ext/worklogs/5",
+		"PATCH /api/v1/ext/worklogs/5",
+		"DELETE /api/v1/ext/worklogs/5",
+	}
+	for i, want := range expected {
+		if requests[i] != want {
+			t.Errorf("Expected request %q, got %q", want, requests[i])
+		}
+	}
+
+	created := map[string]interface{}{"worklog": map[string]interface{}{
+		"issue_id":         float64(42),
+		"started_at":       "2024-03-01T09:00:00Z",
+		"duration_seconds": float64(5400),
+		"description":      "Pairing",
+		"billable":         true,
+	}}
+	if !reflect.DeepEqual(bodies[1], created) {
+		t.Errorf("Expected create body %v, got %v", created, bodies[1])
+	}
+	updated := map[string]interface{}{"worklog": map[string]interface{}{"description": "Pairing on CI"}}
+	if !reflect.DeepEqual(bodies[3], updated) {
+		t.Errorf("Expected only set fields in the update body, got %v", bodies[3])
+	}
+
+	if _, err := worklogs.Create(ctx, WorklogInput{IssueID: 42}); err == nil {
+		t.Error("Expected error for a worklog without duration")
+	}
+}